@@ -0,0 +1,89 @@
+package opt
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AnyOption is implemented by every Option[T], Boxed[T] and PtrOption[T], and
+// lets code that does not
+// know T, such as encoders, ORMs and validators working through interfaces,
+// find out whether an Option is Some and what it contains:
+//
+//	if o, ok := v.(opt.AnyOption); ok {
+//		if !o.IsSome() {
+//			return nil
+//		}
+//		v = o.UnwrapAny()
+//	}
+type AnyOption interface {
+	// IsSome reports whether the Option is Some.
+	IsSome() bool
+	// UnwrapAny returns the value the Option contains as an any, or nil if it
+	// is None.
+	UnwrapAny() any
+}
+
+// AnyOptionSetter is implemented by every *Option[T], *Boxed[T] and
+// *PtrOption[T], and lets code that does not know T set it.
+type AnyOptionSetter interface {
+	AnyOption
+	// SetAny sets the Option to Some(v), or to None if v is nil. It returns
+	// an error if v cannot be assigned to a T.
+	SetAny(v any) error
+}
+
+var (
+	_ AnyOption       = Option[int]{}
+	_ AnyOptionSetter = (*Option[int])(nil)
+	_ AnyOption       = Boxed[int]{}
+	_ AnyOptionSetter = (*Boxed[int])(nil)
+	_ AnyOption       = PtrOption[int]{}
+	_ AnyOptionSetter = (*PtrOption[int])(nil)
+)
+
+// IsSome reports whether the Option is Some. It is the same as Some, for use
+// through AnyOption.
+func (o Option[T]) IsSome() bool {
+	return o.ok
+}
+
+// UnwrapAny returns the contained value as an any, or nil if there is none.
+func (o Option[T]) UnwrapAny() any {
+	if !o.ok {
+		return nil
+	}
+	return o.v
+}
+
+// SetAny replaces the Option with Some(v), or with None if v is nil. v may
+// also be an Option[T], which is copied. It returns an error, leaving the
+// Option as it is, if v cannot be assigned to a T.
+func (o *Option[T]) SetAny(v any) error {
+	x, ok := fromAny[T](v)
+	if !ok {
+		return fmt.Errorf("opt: cannot set %T from a value of type %T", *o, v)
+	}
+	*o = x
+	return nil
+}
+
+// fromAny converts the argument of SetAny to an Option, reporting false if it
+// cannot be assigned to a T.
+func fromAny[T any](v any) (Option[T], bool) {
+	switch v := v.(type) {
+	case nil:
+		return None[T](), true
+	case T:
+		return Some(v), true
+	case Option[T]:
+		return v, true
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.Type().AssignableTo(reflect.TypeFor[T]()) {
+		return None[T](), false
+	}
+	var x T
+	reflect.ValueOf(&x).Elem().Set(rv)
+	return Some(x), true
+}
@@ -0,0 +1,72 @@
+package opt
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ints []int
+
+func TestAnyOption(t *testing.T) {
+	values := []any{Some(5), None[string](), Some[error](nil), 5}
+	var got []string
+	for _, v := range values {
+		o, ok := v.(AnyOption)
+		if !ok {
+			got = append(got, "not an option")
+			continue
+		}
+		got = append(got, fmt.Sprintf("%v %v", o.IsSome(), o.UnwrapAny()))
+	}
+	require.Equal(t, []string{"true 5", "false <nil>", "true <nil>", "not an option"}, got)
+}
+
+func TestSetAny(t *testing.T) {
+	var o Option[int]
+	var s AnyOptionSetter = &o
+	require.NoError(t, s.SetAny(5))
+	require.Equal(t, Some(5), o)
+	require.NoError(t, s.SetAny(nil))
+	require.Equal(t, None[int](), o)
+	require.NoError(t, s.SetAny(Some(7)))
+	require.Equal(t, Some(7), o)
+
+	require.EqualError(t, s.SetAny("5"), "opt: cannot set opt.Option[int] from a value of type string")
+	require.Equal(t, Some(7), o)
+	require.Error(t, s.SetAny(int64(5)))
+	require.Error(t, s.SetAny(Some(int64(5))))
+
+	var is Option[ints]
+	require.NoError(t, is.SetAny([]int{1, 2}))
+	require.Equal(t, Some(ints{1, 2}), is)
+
+	var e Option[error]
+	require.NoError(t, e.SetAny(errors.New("x")))
+	require.EqualError(t, e.Unwrap(), "x")
+	require.NoError(t, e.SetAny(nil))
+	require.True(t, e.None())
+}
+
+func TestAnyOptionBoxed(t *testing.T) {
+	for _, s := range []AnyOptionSetter{new(Boxed[int]), new(PtrOption[int])} {
+		require.False(t, s.IsSome())
+		require.Nil(t, s.UnwrapAny())
+
+		require.NoError(t, s.SetAny(5))
+		require.True(t, s.IsSome())
+		require.Equal(t, 5, s.UnwrapAny())
+
+		require.NoError(t, s.SetAny(Some(7)))
+		require.Equal(t, 7, s.UnwrapAny())
+
+		require.Error(t, s.SetAny("5"))
+		require.Equal(t, 7, s.UnwrapAny())
+
+		require.NoError(t, s.SetAny(nil))
+		require.False(t, s.IsSome())
+	}
+	require.EqualError(t, new(Boxed[int]).SetAny("5"), "opt: cannot set opt.Boxed[int] from a value of type string")
+}
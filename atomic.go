@@ -0,0 +1,52 @@
+package opt
+
+import "sync/atomic"
+
+// AtomicOption is an Option that can be loaded and stored by several
+// goroutines at once. The zero AtomicOption holds None and is ready to use;
+// an AtomicOption must not be copied after first use.
+//
+// AtomicOption implements expvar.Var, so it can hold optional runtime state
+// and be published on /debug/vars, where it shows as the JSON encoding of its
+// value, or null if it is None:
+//
+//	var leader opt.AtomicOption[string]
+//
+//	func init() {
+//		expvar.Publish("leader", &leader)
+//	}
+type AtomicOption[T any] struct {
+	p atomic.Pointer[Option[T]]
+}
+
+// Load returns the Option a holds.
+func (a *AtomicOption[T]) Load() Option[T] {
+	if p := a.p.Load(); p != nil {
+		return *p
+	}
+	return None[T]()
+}
+
+// Store sets the Option a holds to o.
+func (a *AtomicOption[T]) Store(o Option[T]) {
+	a.p.Store(&o)
+}
+
+// Swap sets the Option a holds to o and returns the Option it held before.
+func (a *AtomicOption[T]) Swap(o Option[T]) Option[T] {
+	if p := a.p.Swap(&o); p != nil {
+		return *p
+	}
+	return None[T]()
+}
+
+// String returns the JSON encoding of the Option a holds, as expvar.Var
+// requires, with None encoded as null. If the value cannot be encoded, String
+// returns null.
+func (a *AtomicOption[T]) String() string {
+	b, err := a.Load().MarshalJSON()
+	if err != nil {
+		return "null"
+	}
+	return string(b)
+}
@@ -0,0 +1,52 @@
+package opt
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicOption(t *testing.T) {
+	var a AtomicOption[int]
+	require.Equal(t, None[int](), a.Load())
+	require.Equal(t, "null", a.String())
+
+	a.Store(Some(1))
+	require.Equal(t, Some(1), a.Load())
+	require.Equal(t, Some(1), a.Swap(None[int]()))
+	require.Equal(t, None[int](), a.Swap(Some(2)))
+	require.Equal(t, "2", a.String())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			a.Store(Some(i))
+			a.Load()
+		}(i)
+	}
+	wg.Wait()
+	require.True(t, a.Load().Some())
+}
+
+func TestAtomicOptionExpvar(t *testing.T) {
+	var leader AtomicOption[string]
+	var lastSync AtomicOption[time.Time]
+	expvar.Publish("opt_test_leader", &leader)
+	expvar.Publish("opt_test_last_sync", &lastSync)
+
+	require.Equal(t, "null", expvar.Get("opt_test_leader").String())
+	leader.Store(Some(`node "a"`))
+	require.Equal(t, `"node \"a\""`, expvar.Get("opt_test_leader").String())
+
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lastSync.Store(Some(ts))
+	var got time.Time
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("opt_test_last_sync").String()), &got))
+	require.True(t, ts.Equal(got))
+}
@@ -0,0 +1,167 @@
+package opt
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// FromPointers converts each of ps with FromPointer. It bridges Options with
+// APIs such as the AWS SDK that model optional values as pointers, the
+// inverse of helpers like aws.StringSlice.
+func FromPointers[T any](ps []*T) []Option[T] {
+	if ps == nil {
+		return nil
+	}
+	out := make([]Option[T], len(ps))
+	for i, p := range ps {
+		out[i] = FromPointer(p)
+	}
+	return out
+}
+
+// ToPointers converts each of os with ToPointer.
+func ToPointers[T any](os []Option[T]) []*T {
+	if os == nil {
+		return nil
+	}
+	out := make([]*T, len(os))
+	for i, o := range os {
+		out[i] = ToPointer(o)
+	}
+	return out
+}
+
+// FromPointerMap converts each value of m with FromPointer.
+func FromPointerMap[K comparable, T any](m map[K]*T) map[K]Option[T] {
+	if m == nil {
+		return nil
+	}
+	out := make(map[K]Option[T], len(m))
+	for k, p := range m {
+		out[k] = FromPointer(p)
+	}
+	return out
+}
+
+// ToPointerMap converts each value of m with ToPointer.
+func ToPointerMap[K comparable, T any](m map[K]Option[T]) map[K]*T {
+	if m == nil {
+		return nil
+	}
+	out := make(map[K]*T, len(m))
+	for k, o := range m {
+		out[k] = ToPointer(o)
+	}
+	return out
+}
+
+// ToAWSInput copies the Option fields of src that are Some into the fields of
+// the same name of the struct dst points to, which are typically the pointer
+// fields of an AWS SDK input struct. src must be a struct or a pointer to one.
+// It builds a request from partial input without a nil check per field:
+//
+//	type Query struct {
+//		TableName opt.Option[string]
+//		Limit     opt.Option[int32]
+//	}
+//
+//	in := &dynamodb.QueryInput{}
+//	if err := opt.ToAWSInput(in, req); err != nil {
+//		return err
+//	}
+//
+// A value is stored in a pointer field as a pointer to a copy, in an Option
+// field as Some, and in any other field as it is. Values are converted to
+// types of the same kind, such as the string enum types of the SDK, and
+// structs are copied into the struct a field points to with ToAWSInput in
+// turn. Fields of src that are not Options, are None or have no counterpart
+// in dst are skipped, and the fields of dst they would set are left as they
+// are.
+func ToAWSInput(dst, src any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("opt: ToAWSInput: dst must be a non-nil pointer to a struct, not %T", dst)
+	}
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("opt: ToAWSInput: src must be a struct or a pointer to one, not %T", src)
+	}
+	if err := copySomeFields(dv.Elem(), sv); err != nil {
+		return fmt.Errorf("opt: ToAWSInput: %w", err)
+	}
+	return nil
+}
+
+func copySomeFields(dst, src reflect.Value) error {
+	for _, sf := range reflect.VisibleFields(src.Type()) {
+		if sf.Anonymous || !sf.IsExported() || !optreflect.Is(sf.Type) {
+			continue
+		}
+		fv, err := src.FieldByIndexErr(sf.Index)
+		if err != nil {
+			continue // in a nil embedded pointer
+		}
+		x, some := optreflect.Get(fv)
+		if !some {
+			continue
+		}
+		df, ok := dst.Type().FieldByName(sf.Name)
+		if !ok || !df.IsExported() {
+			continue
+		}
+		dv, err := dst.FieldByIndexErr(df.Index)
+		if err != nil {
+			continue
+		}
+		if err := setAWSField(dv, x); err != nil {
+			return fmt.Errorf("field %s: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// setAWSField stores x in dst, which is a pointer or Option of its type, or
+// its type.
+func setAWSField(dst, x reflect.Value) error {
+	t := dst.Type()
+	switch {
+	case x.Type().AssignableTo(t):
+		dst.Set(x)
+		return nil
+	case optreflect.Is(t):
+		elem, _ := optreflect.Elem(t)
+		v := reflect.New(elem).Elem()
+		if err := assignAWS(v, x); err != nil {
+			return err
+		}
+		optreflect.Set(dst.Addr(), v)
+		return nil
+	case t.Kind() == reflect.Pointer:
+		p := reflect.New(t.Elem())
+		if err := assignAWS(p.Elem(), x); err != nil {
+			return err
+		}
+		dst.Set(p)
+		return nil
+	}
+	return assignAWS(dst, x)
+}
+
+func assignAWS(dst, x reflect.Value) error {
+	switch t := dst.Type(); {
+	case x.Type().AssignableTo(t):
+		dst.Set(x)
+	case x.Kind() == reflect.Struct && t.Kind() == reflect.Struct:
+		return copySomeFields(dst, x)
+	case x.Kind() == t.Kind() && x.Type().ConvertibleTo(t):
+		dst.Set(x.Convert(t))
+	default:
+		return fmt.Errorf("cannot store %s in %s", x.Type(), t)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package opt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointers(t *testing.T) {
+	a, b := "a", "b"
+	os := FromPointers([]*string{&a, nil, &b})
+	require.Equal(t, []Option[string]{Some("a"), None[string](), Some("b")}, os)
+	require.Equal(t, []*string{&a, nil, &b}, ToPointers(os))
+	require.Nil(t, FromPointers[string](nil))
+	require.Nil(t, ToPointers[string](nil))
+
+	m := FromPointerMap(map[string]*string{"a": &a, "none": nil})
+	require.Equal(t, map[string]Option[string]{"a": Some("a"), "none": None[string]()}, m)
+	require.Equal(t, map[string]*string{"a": &a, "none": nil}, ToPointerMap(m))
+	require.Nil(t, FromPointerMap[string, string](nil))
+	require.Nil(t, ToPointerMap[string, string](nil))
+}
+
+// Types shaped like those of the AWS SDK.
+type (
+	awsBillingMode string
+
+	awsTag struct {
+		Key   *string
+		Value *string
+	}
+
+	awsCreateTableInput struct {
+		TableName   *string
+		BillingMode awsBillingMode
+		ReadUnits   *int64
+		Tag         *awsTag
+		Deletion    Option[bool]
+		Existing    *string
+		Untouched   *string
+	}
+)
+
+type tag struct {
+	Key   Option[string]
+	Value Option[string]
+}
+
+type common struct {
+	Deletion Option[bool]
+}
+
+type createTable struct {
+	common
+	TableName   Option[string]
+	BillingMode Option[string]
+	ReadUnits   Option[int64]
+	Tag         Option[tag]
+	Existing    Option[string]
+	Plain       string
+	Extra       Option[int]
+}
+
+func TestToAWSInput(t *testing.T) {
+	keep := "keep"
+	in := &awsCreateTableInput{Existing: &keep, Untouched: &keep}
+	require.NoError(t, ToAWSInput(in, createTable{
+		common:      common{Deletion: Some(true)},
+		TableName:   Some("users"),
+		BillingMode: Some("PAY_PER_REQUEST"),
+		Tag:         Some(tag{Key: Some("env")}),
+		Plain:       "ignored",
+		Extra:       Some(1),
+	}))
+	require.Equal(t, "users", *in.TableName)
+	require.Equal(t, awsBillingMode("PAY_PER_REQUEST"), in.BillingMode)
+	require.Nil(t, in.ReadUnits)
+	require.Equal(t, "env", *in.Tag.Key)
+	require.Nil(t, in.Tag.Value)
+	require.Equal(t, Some(true), in.Deletion)
+	require.Same(t, &keep, in.Existing)
+	require.Same(t, &keep, in.Untouched)
+
+	var ptrSrc awsCreateTableInput
+	require.NoError(t, ToAWSInput(&ptrSrc, &createTable{ReadUnits: Some[int64](5)}))
+	require.Equal(t, int64(5), *ptrSrc.ReadUnits)
+
+	type mismatch struct{ ReadUnits Option[string] }
+	err := ToAWSInput(&awsCreateTableInput{}, mismatch{ReadUnits: Some("5")})
+	require.EqualError(t, err, "opt: ToAWSInput: field ReadUnits: cannot store string in int64")
+
+	require.ErrorContains(t, ToAWSInput(awsCreateTableInput{}, createTable{}), "dst must be a non-nil pointer to a struct")
+	require.ErrorContains(t, ToAWSInput(&awsCreateTableInput{}, 1), "src must be a struct or a pointer to one, not int")
+	require.ErrorContains(t, ToAWSInput(&awsCreateTableInput{}, (*createTable)(nil)), "src must be a struct")
+}
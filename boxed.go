@@ -0,0 +1,138 @@
+package opt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Boxed is an optional value like Option, but it keeps its value behind a
+// pointer instead of inline. Copying a Boxed copies only the pointer, which
+// costs the same whatever the size of the value, but creating a Boxed costs
+// an allocation, and copying the value into it, which can cost more than the
+// copies it saves. Where the balance lies depends on the value and the
+// machine: BenchmarkBoxed and BenchmarkNewBoxed measure the two costs
+// separately, so measure before switching.
+//
+// The zero value is None. The value a Boxed points to is never modified, so
+// copies can be shared freely.
+type Boxed[T any] struct {
+	p *T
+}
+
+// NewBoxed returns a Boxed that is Some(v).
+func NewBoxed[T any](v T) Boxed[T] {
+	return Boxed[T]{p: &v}
+}
+
+// Box returns a Boxed holding the same value as o.
+func Box[T any](o Option[T]) Boxed[T] {
+	if !o.ok {
+		return Boxed[T]{}
+	}
+	return NewBoxed(o.v)
+}
+
+// Option returns an Option holding the same value as b.
+func (b Boxed[T]) Option() Option[T] {
+	if b.p == nil {
+		return None[T]()
+	}
+	return Some(*b.p)
+}
+
+// Some returns true if there is an underlying value.
+func (b Boxed[T]) Some() bool {
+	return b.p != nil
+}
+
+// None returns true if there is no underlying value.
+func (b Boxed[T]) None() bool {
+	return b.p == nil
+}
+
+// IsSome reports whether b holds a value. It is the same as Some, for use
+// through AnyOption.
+func (b Boxed[T]) IsSome() bool {
+	return b.p != nil
+}
+
+// IsZero reports whether b is None.
+func (b Boxed[T]) IsZero() bool {
+	return b.p == nil
+}
+
+// Unwrap retrieves the underlying value if there is one. Unwrap WILL PANIC
+// if there is no value.
+func (b Boxed[T]) Unwrap() T {
+	if b.p != nil {
+		return *b.p
+	}
+	panic(unwrapPanic(b))
+}
+
+// UnwrapOr returns the underlying value, or v if there is none.
+func (b Boxed[T]) UnwrapOr(v T) T {
+	if b.p == nil {
+		return v
+	}
+	return *b.p
+}
+
+// MaybeUnwrap returns the underlying value and true if there is one, or the
+// zero value of T and false if there is not.
+func (b Boxed[T]) MaybeUnwrap() (T, bool) {
+	if b.p != nil {
+		return *b.p, true
+	}
+	var zv T
+	return zv, false
+}
+
+// UnwrapOrZero returns the underlying value, or the zero value of T if there
+// is none.
+func (b Boxed[T]) UnwrapOrZero() T {
+	if b.p != nil {
+		return *b.p
+	}
+	var zv T
+	return zv
+}
+
+// UnwrapAny returns the value b holds as an any, or nil if b is None.
+func (b Boxed[T]) UnwrapAny() any {
+	if b.p == nil {
+		return nil
+	}
+	return *b.p
+}
+
+// SetAny replaces b with a Boxed holding v, or with None if v is nil. v may
+// also be an Option[T]. It returns an error, leaving b as it is, if v cannot
+// be assigned to a T.
+func (b *Boxed[T]) SetAny(v any) error {
+	o, ok := fromAny[T](v)
+	if !ok {
+		return fmt.Errorf("opt: cannot set %T from a value of type %T", *b, v)
+	}
+	*b = Box(o)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (b Boxed[T]) MarshalJSON() ([]byte, error) {
+	if b.p != nil {
+		return json.Marshal(b.p)
+	}
+	return json.RawMessage("null"), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *Boxed[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		b.p = nil
+		return nil
+	}
+	b.p = new(T)
+	return json.Unmarshal(data, b.p)
+}
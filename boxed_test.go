@@ -0,0 +1,111 @@
+package opt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoxed(t *testing.T) {
+	var none Boxed[int]
+	require.True(t, none.None())
+	require.False(t, none.Some())
+	require.True(t, none.IsZero())
+	require.Equal(t, 7, none.UnwrapOr(7))
+	require.Equal(t, 0, none.UnwrapOrZero())
+	require.Equal(t, None[int](), none.Option())
+	require.Panics(t, func() { none.Unwrap() })
+	_, ok := none.MaybeUnwrap()
+	require.False(t, ok)
+
+	some := NewBoxed(5)
+	require.True(t, some.Some())
+	require.False(t, some.None())
+	require.False(t, some.IsZero())
+	require.Equal(t, 5, some.Unwrap())
+	require.Equal(t, 5, some.UnwrapOr(7))
+	require.Equal(t, 5, some.UnwrapOrZero())
+	require.Equal(t, Some(5), some.Option())
+	v, ok := some.MaybeUnwrap()
+	require.True(t, ok)
+	require.Equal(t, 5, v)
+
+	require.Equal(t, none, Box(None[int]()))
+	require.Equal(t, some, Box(Some(5)))
+}
+
+func TestBoxedJSON(t *testing.T) {
+	type TestStruct struct {
+		Name  Boxed[string]
+		Items Boxed[[]int]
+	}
+	data, err := json.Marshal(TestStruct{Name: NewBoxed("x")})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Name":"x","Items":null}`, string(data))
+
+	var ts TestStruct
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":null,"Items":[1,2]}`), &ts))
+	require.True(t, ts.Name.None())
+	require.Equal(t, []int{1, 2}, ts.Items.Unwrap())
+
+	ts.Name = NewBoxed("x")
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":null}`), &ts))
+	require.True(t, ts.Name.None())
+}
+
+//go:noinline
+func passOption[T any](o Option[T]) Option[T] { return o }
+
+//go:noinline
+func passBoxed[T any](b Boxed[T]) Boxed[T] { return b }
+
+var sink any
+
+// benchmarkBoxed copies an Option and a Boxed holding v through a function,
+// so that the cost of copying them can be compared. Both are created before
+// the timer starts; the cost of allocating a Boxed is measured separately by
+// BenchmarkNewBoxed.
+func benchmarkBoxed[T any](b *testing.B, v T) {
+	b.Run("Option", func(b *testing.B) {
+		s := []Option[T]{Some(v)}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s[0] = passOption(s[0])
+		}
+		sink = s
+	})
+	b.Run("Boxed", func(b *testing.B) {
+		s := []Boxed[T]{NewBoxed(v)}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s[0] = passBoxed(s[0])
+		}
+		sink = s
+	})
+}
+
+func BenchmarkBoxed(b *testing.B) {
+	b.Run("8", func(b *testing.B) { benchmarkBoxed(b, [8]byte{}) })
+	b.Run("64", func(b *testing.B) { benchmarkBoxed(b, [64]byte{}) })
+	b.Run("256", func(b *testing.B) { benchmarkBoxed(b, [256]byte{}) })
+	b.Run("1024", func(b *testing.B) { benchmarkBoxed(b, [1024]byte{}) })
+	b.Run("4096", func(b *testing.B) { benchmarkBoxed(b, [4096]byte{}) })
+}
+
+func BenchmarkNewBoxed(b *testing.B) {
+	b.Run("8", func(b *testing.B) { benchmarkNewBoxed(b, [8]byte{}) })
+	b.Run("64", func(b *testing.B) { benchmarkNewBoxed(b, [64]byte{}) })
+	b.Run("256", func(b *testing.B) { benchmarkNewBoxed(b, [256]byte{}) })
+	b.Run("1024", func(b *testing.B) { benchmarkNewBoxed(b, [1024]byte{}) })
+	b.Run("4096", func(b *testing.B) { benchmarkNewBoxed(b, [4096]byte{}) })
+}
+
+func benchmarkNewBoxed[T any](b *testing.B, v T) {
+	b.ReportAllocs()
+	s := make([]Boxed[T], 1)
+	for i := 0; i < b.N; i++ {
+		s[0] = NewBoxed(v)
+	}
+	sink = s
+}
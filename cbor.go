@@ -0,0 +1,36 @@
+package opt
+
+import (
+	"github.com/fxamacker/cbor/v2"
+)
+
+// MarshalCBOR implements cbor.Marshaler from github.com/fxamacker/cbor/v2.
+// None is encoded as CBOR null, and a Some value is encoded as the value
+// would be by cbor.Marshal. Fields tagged with "omitzero" leave None values
+// out entirely.
+//
+// cbor does not pass the EncMode in use on to marshalers, so the value is
+// always encoded with the default options. The functions of optcbor encode
+// Options with the caller's modes instead.
+func (o Option[T]) MarshalCBOR() ([]byte, error) {
+	if !o.ok {
+		return []byte{0xf6}, nil
+	}
+	return cbor.Marshal(o.v)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler. Both null and undefined are
+// decoded as None, and anything else is decoded into a Some as cbor.Unmarshal
+// would.
+func (o *Option[T]) UnmarshalCBOR(data []byte) error {
+	if len(data) == 1 && (data[0] == 0xf6 || data[0] == 0xf7) {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
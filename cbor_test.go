@@ -0,0 +1,69 @@
+package opt
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBOR(t *testing.T) {
+	type Reading struct {
+		Sensor string                 `cbor:"1,keyasint"`
+		Temp   Option[float64]        `cbor:"2,keyasint"`
+		Labels Option[[]string]       `cbor:"3,keyasint,omitzero"`
+		Extra  Option[map[string]int] `cbor:"4,keyasint,omitzero"`
+	}
+
+	t.Run("None encodes as null", func(t *testing.T) {
+		data, err := cbor.Marshal(None[int]())
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xf6}, data)
+	})
+	t.Run("Some encodes as the value", func(t *testing.T) {
+		data, err := cbor.Marshal(Some(uint8(10)))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x0a}, data)
+	})
+	t.Run("omitzero", func(t *testing.T) {
+		data, err := cbor.Marshal(Reading{Sensor: "a"})
+		require.NoError(t, err)
+
+		var m map[int]any
+		require.NoError(t, cbor.Unmarshal(data, &m))
+		require.Equal(t, map[int]any{1: "a", 2: nil}, m)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		in := Reading{
+			Sensor: "a",
+			Temp:   Some(21.5),
+			Labels: Some([]string{}),
+			Extra:  Some(map[string]int{"b": 1}),
+		}
+		data, err := cbor.Marshal(in)
+		require.NoError(t, err)
+
+		var out Reading
+		require.NoError(t, cbor.Unmarshal(data, &out))
+		require.Equal(t, in, out)
+
+		data, err = cbor.Marshal(Reading{Sensor: "b"})
+		require.NoError(t, err)
+		out = Reading{Temp: Some(1.0), Labels: Some([]string{"x"})}
+		require.NoError(t, cbor.Unmarshal(data, &out))
+		require.Equal(t, "b", out.Sensor)
+		require.True(t, out.Temp.None())
+		require.Equal(t, Some([]string{"x"}), out.Labels)
+	})
+	t.Run("undefined decodes as None", func(t *testing.T) {
+		o := Some(1)
+		require.NoError(t, cbor.Unmarshal([]byte{0xf7}, &o))
+		require.True(t, o.None())
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		data, err := cbor.Marshal(map[int]any{2: "nope"})
+		require.NoError(t, err)
+		var out Reading
+		require.Error(t, cbor.Unmarshal(data, &out))
+	})
+}
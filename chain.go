@@ -0,0 +1,51 @@
+package opt
+
+// Chain wraps an Option so that a sequence of steps that keep its type can be
+// written left to right as method calls instead of nested function calls:
+//
+//	port := opt.NewChain(env("PORT")).
+//		Then(parsePort).
+//		Filter(func(p int) bool { return p < 65536 }).
+//		OrElse(func() opt.Option[int] { return cfg.Port }).
+//		Result()
+//
+// Methods cannot introduce type parameters, so steps that change the type
+// have to go through Map or Lift on the Option returned by Result.
+type Chain[T any] struct {
+	o Option[T]
+}
+
+// NewChain starts a Chain from o.
+func NewChain[T any](o Option[T]) Chain[T] {
+	return Chain[T]{o: o}
+}
+
+// Then calls fn with the value of the Chain if there is one, and continues
+// with the value it returns if it reports true, or with None if it reports
+// false. A Chain that is None is passed on without calling fn.
+func (c Chain[T]) Then(fn func(T) (T, bool)) Chain[T] {
+	return Chain[T]{o: Map(c.o, func(v T) Option[T] { return FromMaybe(fn(v)) })}
+}
+
+// Filter continues with None if the Chain has a value that pred reports false
+// for, and with the Chain as it is otherwise.
+func (c Chain[T]) Filter(pred func(T) bool) Chain[T] {
+	if c.o.Some() && !pred(c.o.v) {
+		return Chain[T]{}
+	}
+	return c
+}
+
+// OrElse continues with the Option fn returns if the Chain is None. fn is not
+// called if the Chain has a value.
+func (c Chain[T]) OrElse(fn func() Option[T]) Chain[T] {
+	if c.o.None() {
+		return Chain[T]{o: fn()}
+	}
+	return c
+}
+
+// Result returns the Option at the end of the Chain.
+func (c Chain[T]) Result() Option[T] {
+	return c.o
+}
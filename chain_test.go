@@ -0,0 +1,50 @@
+package opt
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	parse := func(s string) (string, bool) {
+		n, err := strconv.Atoi(s)
+		return strconv.Itoa(n * 2), err == nil
+	}
+	short := func(s string) bool { return len(s) < 3 }
+	fallback := func() Option[string] { return Some("fallback") }
+
+	tests := []struct {
+		name string
+		in   Option[string]
+		want Option[string]
+	}{
+		{"some", Some("21"), Some("42")},
+		{"then fails", Some("x"), Some("fallback")},
+		{"filtered", Some("500"), Some("fallback")},
+		{"none", None[string](), Some("fallback")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewChain(tt.in).Then(parse).Filter(short).OrElse(fallback).Result()
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChainLazy(t *testing.T) {
+	called := false
+	got := NewChain(None[int]()).
+		Then(func(v int) (int, bool) { called = true; return v, true }).
+		Filter(func(int) bool { called = true; return true }).
+		Result()
+	require.True(t, got.None())
+	require.False(t, called)
+
+	got = NewChain(Some(1)).
+		OrElse(func() Option[int] { called = true; return Some(2) }).
+		Result()
+	require.Equal(t, Some(1), got)
+	require.False(t, called)
+}
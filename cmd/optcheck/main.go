@@ -0,0 +1,15 @@
+// Command optcheck reports misuse of opt.Option. It can be run on its own or
+// as a vet tool:
+//
+//	optcheck ./...
+//	go vet -vettool=$(which optcheck) ./...
+package main
+
+import (
+	"code.nkcmr.net/opt/optcheck"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+func main() {
+	multichecker.Main(optcheck.Analyzers...)
+}
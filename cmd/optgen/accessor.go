@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// accessors adds a function to the output for each of paths, which are of
+// the form Type.Field.Field..., that returns the value at the end of the path
+// as an Option. It is None if any pointer along the way is nil.
+func (g *generator) accessors(paths []string) error {
+	for _, path := range paths {
+		if err := g.accessor(path); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) accessor(path string) error {
+	parts := strings.Split(path, ".")
+	if len(parts) < 2 {
+		return fmt.Errorf("want Type.Field[.Field...]")
+	}
+	root, fields := parts[0], parts[1:]
+	typ := root
+	_, st, file, err := g.pkg.lookup(typ)
+	if err != nil {
+		return err
+	}
+
+	// nilable collects the expressions that must not be nil for the value
+	// at the end of the path to be reached.
+	nilable := []string{"v"}
+	expr := "v"
+	for i, name := range fields {
+		f, err := field(st, typ, name)
+		if err != nil {
+			return err
+		}
+		expr += "." + name
+		t := f.Type
+		if i == len(fields)-1 {
+			return g.accessorFunc(root, fields, nilable, expr, t, file)
+		}
+		if star, ok := t.(*ast.StarExpr); ok {
+			nilable = append(nilable, expr)
+			t = star.X
+		}
+		id, ok := t.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("cannot follow field %s, its type is not a struct declared in package %s", name, g.pkg.name)
+		}
+		typ = id.Name
+		if _, st, file, err = g.pkg.lookup(typ); err != nil {
+			return fmt.Errorf("cannot follow field %s: %w", name, err)
+		}
+	}
+	panic("unreachable")
+}
+
+// accessorFunc writes the function for a path through the fields of root
+// that ends with the expression expr, of type t as declared in file.
+func (g *generator) accessorFunc(root string, fields, nilable []string, expr string, t ast.Expr, file *ast.File) error {
+	value := "opt.Some(" + expr + ")"
+	var result string
+	switch {
+	case isOptionExpr(t, file):
+		result = g.expr(t.(*ast.IndexExpr).Index, file)
+		value = expr
+	case isStar(t):
+		nilable = append(nilable, expr)
+		result = g.expr(t.(*ast.StarExpr).X, file)
+		value = "opt.Some(*" + expr + ")"
+	default:
+		result = g.expr(t, file)
+	}
+	name := "Get" + strings.Join(fields, "")
+	doc := fmt.Sprintf("%s returns v.%s, or None if v is nil.", name, strings.Join(fields, "."))
+	if len(nilable) > 1 {
+		doc = fmt.Sprintf("%s returns v.%s, or None if v or any pointer along the way is nil.", name, strings.Join(fields, "."))
+	}
+	g.comment(doc)
+	g.printf("func %s(v *%s) opt.Option[%s] {\n", name, root, result)
+	g.printf("if %s == nil {\n", strings.Join(nilable, " == nil || "))
+	g.printf("return opt.None[%s]()\n}\n", result)
+	g.printf("return %s\n}\n", value)
+	return nil
+}
+
+// field finds the field called name in st, the declaration of the struct
+// type called typ.
+func field(st *ast.StructType, typ, name string) (*ast.Field, error) {
+	for _, f := range st.Fields.List {
+		names := f.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{embeddedName(f.Type)}
+		}
+		for _, n := range names {
+			if n.Name == name {
+				return f, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("type %s has no field %s", typ, name)
+}
+
+func isStar(t ast.Expr) bool {
+	_, ok := t.(*ast.StarExpr)
+	return ok
+}
+
+// isOptionExpr reports whether t, as it appears in file, is opt.Option[T].
+func isOptionExpr(t ast.Expr, file *ast.File) bool {
+	idx, ok := t.(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := idx.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Option" {
+		return false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	path, _, ok := importOf(file, id.Name)
+	return ok && path == optPath
+}
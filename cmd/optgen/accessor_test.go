@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAccessors(t *testing.T) {
+	p, err := parsePackage("testdata/order")
+	require.NoError(t, err)
+	src, err := generate(p, request{accessors: []string{
+		"Order.ID",
+		"Order.Note",
+		"Order.User.Address.City",
+		"Order.User.Address.Updated",
+		"Order.User.Phone",
+		"Order.Shipping.To.City",
+		"Order.Shipping.Carrier",
+	}})
+	require.NoError(t, err)
+	golden(t, "testdata/order", "order_accessors.go", src)
+	check(t, "testdata/order", src)
+
+	for path, msg := range map[string]string{
+		"Order":                  "want Type.Field",
+		"Order.Missing":          "type Order has no field Missing",
+		"Order.User.Phone.Len":   "cannot follow field Phone",
+		"Order.User.Name.Length": "cannot follow field Name",
+		"Invoice.ID":             "type Invoice not found",
+	} {
+		_, err := generate(p, request{accessors: []string{path}})
+		require.ErrorContains(t, err, msg, path)
+	}
+}
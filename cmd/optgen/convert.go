@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+// conversion is a pair of structs given to -convert: a domain type declared
+// in the package being generated for, with Option fields, and a DTO type with
+// pointer fields, which may be declared in another package.
+type conversion struct {
+	domain string
+	dto    dtoType
+}
+
+type dtoType struct {
+	pkg  *pkg
+	path string // import path, or "" if in the package being generated for
+	name string
+}
+
+// ref returns the name of the type as code in the generated file refers to it.
+func (t dtoType) ref() string {
+	if t.path == "" {
+		return t.name
+	}
+	return t.pkg.name + "." + t.name
+}
+
+// conversions adds a ToOption and a FromOption function to the output for
+// each of specs, which are of the form Domain=DTO or Domain=path/to/pkg.DTO.
+func (g *generator) conversions(specs []string) error {
+	var convs []conversion
+	for _, spec := range specs {
+		domain, dto, ok := strings.Cut(spec, "=")
+		if !ok || domain == "" || dto == "" {
+			return fmt.Errorf("%s: want Domain=DTO or Domain=path/to/pkg.DTO", spec)
+		}
+		t := dtoType{pkg: g.pkg, name: dto}
+		if i := strings.LastIndex(dto, "."); i >= 0 {
+			t.path, t.name = dto[:i], dto[i+1:]
+			if err := g.loadDTOPackage(&t); err != nil {
+				return fmt.Errorf("%s: %w", spec, err)
+			}
+		}
+		convs = append(convs, conversion{domain: domain, dto: t})
+	}
+	for _, c := range convs {
+		if err := g.conversion(c, convs); err != nil {
+			return fmt.Errorf("%s=%s: %w", c.domain, c.dto.name, err)
+		}
+	}
+	return nil
+}
+
+// loadDTOPackage finds and parses the package t is declared in.
+func (g *generator) loadDTOPackage(t *dtoType) error {
+	dir, err := filepath.Abs(g.pkg.dir)
+	if err != nil {
+		return err
+	}
+	bp, err := build.Default.Import(t.path, dir, build.FindOnly)
+	if err != nil {
+		return err
+	}
+	if t.pkg, err = parsePackage(bp.Dir); err != nil {
+		return err
+	}
+	g.imports[t.path] = t.pkg.name
+	return nil
+}
+
+// convField is how a field is converted in each direction.
+type convField struct {
+	name           string
+	toOpt, fromOpt string
+}
+
+func (g *generator) conversion(c conversion, all []conversion) error {
+	_, dst, dstFile, err := g.pkg.lookup(c.domain)
+	if err != nil {
+		return err
+	}
+	_, src, _, err := c.dto.pkg.lookup(c.dto.name)
+	if err != nil {
+		return err
+	}
+	qual := ""
+	if c.dto.path != "" {
+		qual = c.dto.pkg.name
+	}
+
+	var fields []convField
+	for _, df := range dst.Fields.List {
+		for _, name := range fieldNames(df) {
+			if !name.IsExported() {
+				continue
+			}
+			sf, err := field(src, c.dto.name, name.Name)
+			if err != nil {
+				continue
+			}
+			f, err := g.convField(name.Name, df.Type, dstFile, sf.Type, qual, all)
+			if err != nil {
+				return err
+			}
+			fields = append(fields, f)
+		}
+	}
+
+	to := c.dto.name + "ToOption"
+	g.comment(fmt.Sprintf("%s converts v to type %s, with each nil pointer field of v becoming None. A nil v becomes the zero %[2]s.", to, c.domain))
+	g.printf("func %s(v *%s) %s {\n", to, c.dto.ref(), c.domain)
+	g.printf("var out %s\nif v == nil {\nreturn out\n}\n", c.domain)
+	for _, f := range fields {
+		g.printf("%s\n", f.toOpt)
+	}
+	g.printf("return out\n}\n")
+
+	from := c.dto.name + "FromOption"
+	g.comment(fmt.Sprintf("%s converts v to type *%s, with each None field of v becoming a nil pointer.", from, c.dto.ref()))
+	g.printf("func %s(v %s) *%s {\n", from, c.domain, c.dto.ref())
+	g.printf("out := &%s{}\n", c.dto.ref())
+	for _, f := range fields {
+		g.printf("%s\n", f.fromOpt)
+	}
+	g.printf("return out\n}\n")
+	return nil
+}
+
+// convField works out how to convert the field called name between its type
+// dt in the domain struct, declared in dfile, and st in the DTO.
+func (g *generator) convField(name string, dt ast.Expr, dfile *ast.File, st ast.Expr, qual string, all []conversion) (convField, error) {
+	f := convField{name: name}
+	sptr := isStar(st)
+	selem := st
+	if sptr {
+		selem = st.(*ast.StarExpr).X
+	}
+	sstr, selemStr := g.typeString(st, qual), g.typeString(selem, qual)
+
+	optional := isOptionExpr(dt, dfile)
+	delem := dt
+	if optional {
+		delem = dt.(*ast.IndexExpr).Index
+	}
+	delemStr := g.typeString(delem, "")
+	// dfile may import opt under another name.
+	dstr := delemStr
+	if optional {
+		dstr = "opt.Option[" + delemStr + "]"
+	}
+
+	// A nested pair of structs is converted with their own functions.
+	if id, ok := delem.(*ast.Ident); ok {
+		for _, c := range all {
+			if c.domain != id.Name || c.dto.ref() != selemStr {
+				continue
+			}
+			to, from := c.dto.name+"ToOption", c.dto.name+"FromOption"
+			ref := "&v." + name
+			if sptr {
+				ref = "v." + name
+			}
+			deref := ""
+			if !sptr {
+				deref = "*"
+			}
+			switch {
+			case !optional:
+				f.toOpt = fmt.Sprintf("out.%s = %s(%s)", name, to, ref)
+				f.fromOpt = fmt.Sprintf("out.%s = %s%s(v.%[1]s)", name, deref, from)
+			case sptr:
+				f.toOpt = fmt.Sprintf("if v.%s != nil {\nout.%[1]s = opt.Some(%s(v.%[1]s))\n}", name, to)
+				f.fromOpt = fmt.Sprintf("if x, ok := v.%s.MaybeUnwrap(); ok {\nout.%[1]s = %s(x)\n}", name, from)
+			default:
+				f.toOpt = fmt.Sprintf("out.%s = opt.Some(%s(&v.%[1]s))", name, to)
+				f.fromOpt = fmt.Sprintf("if x, ok := v.%s.MaybeUnwrap(); ok {\nout.%[1]s = *%s(x)\n}", name, from)
+			}
+			return f, nil
+		}
+	}
+
+	switch {
+	case dstr == sstr:
+		f.toOpt = fmt.Sprintf("out.%s = v.%[1]s", name)
+		f.fromOpt = f.toOpt
+	case optional && sptr && delemStr == selemStr:
+		f.toOpt = fmt.Sprintf("out.%s = opt.FromPointer(v.%[1]s)", name)
+		f.fromOpt = fmt.Sprintf("out.%s = opt.ToPointer(v.%[1]s)", name)
+	case optional && delemStr == sstr:
+		f.toOpt = fmt.Sprintf("out.%s = opt.Some(v.%[1]s)", name)
+		f.fromOpt = fmt.Sprintf("out.%s = v.%[1]s.UnwrapOrZero()", name)
+	default:
+		return f, fmt.Errorf("field %s: cannot convert between %s and %s", name, dstr, sstr)
+	}
+	return f, nil
+}
+
+// typeString formats the type expression e for comparison with others.
+// Types declared in the package e appears in are qualified with qual, unless
+// it is empty. Unlike expr, it records no imports, as the generated code only
+// refers to the types through fields.
+func (g *generator) typeString(e ast.Expr, qual string) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		if qual != "" && types.Universe.Lookup(e.Name) == nil {
+			return qual + "." + e.Name
+		}
+		return e.Name
+	case *ast.StarExpr:
+		return "*" + g.typeString(e.X, qual)
+	case *ast.ArrayType:
+		n := ""
+		if e.Len != nil {
+			n = types.ExprString(e.Len)
+		}
+		return "[" + n + "]" + g.typeString(e.Elt, qual)
+	case *ast.MapType:
+		return "map[" + g.typeString(e.Key, qual) + "]" + g.typeString(e.Value, qual)
+	case *ast.IndexExpr:
+		return g.typeString(e.X, qual) + "[" + g.typeString(e.Index, qual) + "]"
+	case *ast.IndexListExpr:
+		args := make([]string, len(e.Indices))
+		for i, x := range e.Indices {
+			args[i] = g.typeString(x, qual)
+		}
+		return g.typeString(e.X, qual) + "[" + strings.Join(args, ", ") + "]"
+	}
+	return types.ExprString(e)
+}
+
+// fieldNames returns the names of the fields f declares, or the name of the
+// type it embeds.
+func fieldNames(f *ast.Field) []*ast.Ident {
+	if len(f.Names) == 0 {
+		return []*ast.Ident{embeddedName(f.Type)}
+	}
+	return f.Names
+}
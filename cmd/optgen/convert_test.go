@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateConversions(t *testing.T) {
+	p, err := parsePackage("testdata/convert")
+	require.NoError(t, err)
+	src, err := generate(p, request{converts: []string{
+		"User=code.nkcmr.net/opt/cmd/optgen/testdata/convert/api.User",
+		"Address=code.nkcmr.net/opt/cmd/optgen/testdata/convert/api.Address",
+		"Local=LocalDTO",
+	}})
+	require.NoError(t, err)
+	golden(t, "testdata/convert", "user_convert.go", src)
+	check(t, "testdata/convert", src)
+
+	for spec, msg := range map[string]string{
+		"User":             "want Domain=DTO",
+		"Local=Missing":    "type Missing not found",
+		"Missing=LocalDTO": "type Missing not found",
+		"Mismatch=code.nkcmr.net/opt/cmd/optgen/testdata/convert/api.User": "field Age: cannot convert between opt.Option[string] and int",
+		"User=example.invalid/nothing.User":                                "example.invalid/nothing",
+	} {
+		_, err := generate(p, request{converts: []string{spec}})
+		require.ErrorContains(t, err, msg, spec)
+	}
+}
@@ -0,0 +1,148 @@
+// Code generated by optgen; DO NOT EDIT.
+
+package jsontest
+
+import (
+	"encoding/json"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/optjson"
+)
+
+// MarshalJSON implements json.Marshaler, encoding the fields of v without
+// reflection.
+func (v Event) MarshalJSON() ([]byte, error) {
+	var err error
+	buf := make([]byte, 0, 162)
+	// Each field is written after a comma, the first of which becomes the
+	// opening brace.
+	buf = append(buf, `,"id":`...)
+	if buf, err = opt.Some(v.ID).AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	buf = append(buf, `,"name":`...)
+	if buf, err = v.Name.AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	if v.Count.IsSome() {
+		buf = append(buf, `,"count":`...)
+		if buf, err = v.Count.AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	if v.Note.IsSome() {
+		buf = append(buf, `,"note":`...)
+		if buf, err = v.Note.AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	buf = append(buf, `,"at":`...)
+	if buf, err = v.At.AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	if len(v.Tags) != 0 {
+		buf = append(buf, `,"tags":`...)
+		if buf, err = opt.Some(v.Tags).AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	buf = append(buf, `,"place":`...)
+	if buf, err = v.Place.AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	if v.Label != "" {
+		buf = append(buf, `,"\u003clabel\u003e":`...)
+		if buf, err = opt.Some(v.Label).AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	buf = append(buf, `,"-":`...)
+	if buf, err = opt.Some(v.Dash).AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	buf = append(buf, `,"Public":`...)
+	if buf, err = opt.Some(v.Public).AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return []byte("{}"), nil
+	}
+	buf[0] = '{'
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the members of data into
+// the fields of v without reflection. Members are matched to fields by their
+// exact name, and members with no matching field are ignored.
+func (v *Event) UnmarshalJSON(data []byte) error {
+	return optjson.Members(data, func(key string, value []byte) error {
+		switch key {
+		case "id":
+			return json.Unmarshal(value, &v.ID)
+		case "name":
+			return v.Name.UnmarshalJSON(value)
+		case "count":
+			return v.Count.UnmarshalJSON(value)
+		case "note":
+			// null is kept as Some(None), telling it apart from a missing member.
+			x := v.Note.UnwrapOrZero()
+			if err := x.UnmarshalJSON(value); err != nil {
+				return err
+			}
+			v.Note = opt.Some(x)
+			return nil
+		case "at":
+			return v.At.UnmarshalJSON(value)
+		case "tags":
+			return json.Unmarshal(value, &v.Tags)
+		case "place":
+			return v.Place.UnmarshalJSON(value)
+		case "<label>":
+			return json.Unmarshal(value, &v.Label)
+		case "-":
+			return json.Unmarshal(value, &v.Dash)
+		case "Public":
+			return json.Unmarshal(value, &v.Public)
+		}
+		return nil
+	})
+}
+
+// MarshalJSON implements json.Marshaler, encoding the fields of v without
+// reflection.
+func (v Place) MarshalJSON() ([]byte, error) {
+	var err error
+	buf := make([]byte, 0, 34)
+	// Each field is written after a comma, the first of which becomes the
+	// opening brace.
+	buf = append(buf, `,"city":`...)
+	if buf, err = v.City.AppendJSON(buf); err != nil {
+		return nil, err
+	}
+	if v.Zip.IsSome() {
+		buf = append(buf, `,"zip":`...)
+		if buf, err = v.Zip.AppendJSON(buf); err != nil {
+			return nil, err
+		}
+	}
+	if len(buf) == 0 {
+		return []byte("{}"), nil
+	}
+	buf[0] = '{'
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the members of data into
+// the fields of v without reflection. Members are matched to fields by their
+// exact name, and members with no matching field are ignored.
+func (v *Place) UnmarshalJSON(data []byte) error {
+	return optjson.Members(data, func(key string, value []byte) error {
+		switch key {
+		case "city":
+			return v.City.UnmarshalJSON(value)
+		case "zip":
+			return v.Zip.UnmarshalJSON(value)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,31 @@
+// Package jsontest holds structs with JSON methods generated by optgen -json,
+// for testing them.
+package jsontest
+
+import (
+	"time"
+
+	"code.nkcmr.net/opt"
+)
+
+//go:generate go run code.nkcmr.net/opt/cmd/optgen -json Event,Place
+
+type Event struct {
+	ID       string                         `json:"id"`
+	Name     opt.Option[string]             `json:"name"`
+	Count    opt.Option[int]                `json:"count,omitempty"`
+	Note     opt.Option[opt.Option[string]] `json:"note"`
+	At       opt.Option[time.Time]          `json:"at"`
+	Tags     []string                       `json:"tags,omitempty"`
+	Place    opt.Option[Place]              `json:"place"`
+	Label    string                         `json:"<label>,omitempty"`
+	Skipped  string                         `json:"-"`
+	Dash     int                            `json:"-,"`
+	Public   bool
+	internal int
+}
+
+type Place struct {
+	City opt.Option[string] `json:"city"`
+	Zip  opt.Option[string] `json:"zip,omitzero"`
+}
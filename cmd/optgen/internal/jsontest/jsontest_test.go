@@ -0,0 +1,99 @@
+package jsontest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+func TestMarshal(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		in   Event
+		want string
+	}{
+		{Event{}, `{"id":"","name":null,"at":null,"place":null,"-":0,"Public":false}`},
+		{
+			Event{
+				ID:      "e1",
+				Name:    opt.Some("launch"),
+				Count:   opt.Some(3),
+				Note:    opt.Some(opt.None[string]()),
+				At:      opt.Some(at),
+				Tags:    []string{"a"},
+				Place:   opt.Some(Place{City: opt.Some("Oslo"), Zip: opt.Some("0150")}),
+				Label:   "x",
+				Skipped: "skipped",
+				Dash:    1,
+				Public:  true,
+			},
+			`{"id":"e1","name":"launch","count":3,"note":null,"at":"2024-05-01T12:00:00Z","tags":["a"],"place":{"city":"Oslo","zip":"0150"},"\u003clabel\u003e":"x","-":1,"Public":true}`,
+		},
+		{Event{Note: opt.Some(opt.Some("hi"))}, `{"id":"","name":null,"note":"hi","at":null,"place":null,"-":0,"Public":false}`},
+	} {
+		got, err := json.Marshal(tc.in)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, string(got))
+	}
+
+	got, err := json.Marshal(Place{})
+	require.NoError(t, err)
+	require.Equal(t, `{"city":null}`, string(got))
+}
+
+func TestUnmarshal(t *testing.T) {
+	var e Event
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "e1",
+		"name": "launch",
+		"count": null,
+		"note": null,
+		"at": "2024-05-01T12:00:00Z",
+		"tags": ["a", "b"],
+		"place": {"city": "Oslo", "extra": [1, {"x": "}"}]},
+		"<label>": "x",
+		"Skipped": "no",
+		"Public": true
+	}`), &e))
+	require.Equal(t, Event{
+		ID:     "e1",
+		Name:   opt.Some("launch"),
+		Note:   opt.Some(opt.None[string]()),
+		At:     opt.Some(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		Tags:   []string{"a", "b"},
+		Place:  opt.Some(Place{City: opt.Some("Oslo")}),
+		Label:  "x",
+		Public: true,
+	}, e)
+
+	e = Event{Note: opt.Some(opt.Some("kept"))}
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"n"}`), &e))
+	require.Equal(t, opt.Some(opt.Some("kept")), e.Note)
+	require.NoError(t, json.Unmarshal([]byte(`{"note":"set"}`), &e))
+	require.Equal(t, opt.Some(opt.Some("set")), e.Note)
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &e))
+	require.Equal(t, opt.Some("n"), e.Name)
+
+	err := json.Unmarshal([]byte(`{"count":"three"}`), &e)
+	require.ErrorContains(t, err, "count: ")
+	require.Error(t, json.Unmarshal([]byte(`[]`), &e))
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := Event{
+		ID:    "e1",
+		Name:  opt.Some("launch"),
+		Note:  opt.Some(opt.None[string]()),
+		Place: opt.Some(Place{Zip: opt.Some("0150")}),
+	}
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	var out Event
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Equal(t, in, out)
+}
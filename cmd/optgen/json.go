@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const optjsonPath = "code.nkcmr.net/opt/optjson"
+
+// jsonField is how a field is encoded and decoded.
+type jsonField struct {
+	name string // Go name
+	key  string // JSON name
+	// omit is the condition under which the field is written, or "" if it
+	// always is.
+	omit string
+	// option and nested are set for Options and Options of Options.
+	option, nested bool
+}
+
+// jsonMethods adds MarshalJSON and UnmarshalJSON methods to the output for
+// each of the struct types named. If omitNone is set, Option fields that are
+// None are left out rather than encoded as null.
+func (g *generator) jsonMethods(names []string, omitNone bool) error {
+	for _, name := range names {
+		if err := g.jsonMethodsFor(name, omitNone); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (g *generator) jsonMethodsFor(name string, omitNone bool) error {
+	_, st, file, err := g.pkg.lookup(name)
+	if err != nil {
+		return err
+	}
+	var fields []jsonField
+	seen := map[string]string{}
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return fmt.Errorf("embedded field %s is not supported", embeddedName(f.Type).Name)
+		}
+		tag := ""
+		if f.Tag != nil {
+			tag, _ = strconv.Unquote(f.Tag.Value)
+			tag = reflect.StructTag(tag).Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+		key, opts, _ := strings.Cut(tag, ",")
+		omitEmpty := hasTagOption(opts, "omitempty") || hasTagOption(opts, "omitzero")
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			if hasTagOption(opts, "string") {
+				return fmt.Errorf("field %s: the string tag option is not supported", n.Name)
+			}
+			jf := jsonField{name: n.Name, key: key}
+			if jf.key == "" {
+				jf.key = n.Name
+			}
+			if other, ok := seen[jf.key]; ok {
+				return fmt.Errorf("fields %s and %s both have the JSON name %q", other, n.Name, jf.key)
+			}
+			seen[jf.key] = n.Name
+			jf.option = isOptionExpr(f.Type, file)
+			jf.nested = jf.option && isOptionExpr(f.Type.(*ast.IndexExpr).Index, file)
+			switch {
+			case jf.nested || jf.option && (omitNone || omitEmpty):
+				jf.omit = "v." + n.Name + ".IsSome()"
+			case omitEmpty && !jf.option:
+				if jf.omit, err = nonEmpty("v."+n.Name, f.Type); err != nil {
+					return fmt.Errorf("field %s: %w", n.Name, err)
+				}
+			}
+			fields = append(fields, jf)
+		}
+	}
+
+	g.imports[optPath] = "opt"
+	g.imports[optjsonPath] = ""
+	g.comment("MarshalJSON implements json.Marshaler, encoding the fields of v without reflection.")
+	g.printf("func (v %s) MarshalJSON() ([]byte, error) {\n", name)
+	g.printf("var err error\nbuf := make([]byte, 0, %d)\n", 16*len(fields)+2)
+	g.printf("// Each field is written after a comma, the first of which becomes the\n// opening brace.\n")
+	for _, f := range fields {
+		if f.omit != "" {
+			g.printf("if %s {\n", f.omit)
+		}
+		g.printf("buf = append(buf, %s...)\n", goString(","+jsonString(f.key)+":"))
+		if f.option {
+			g.printf("if buf, err = v.%s.AppendJSON(buf); err != nil {\nreturn nil, err\n}\n", f.name)
+		} else {
+			g.printf("if buf, err = opt.Some(v.%s).AppendJSON(buf); err != nil {\nreturn nil, err\n}\n", f.name)
+		}
+		if f.omit != "" {
+			g.printf("}\n")
+		}
+	}
+	g.printf("if len(buf) == 0 {\nreturn []byte(\"{}\"), nil\n}\nbuf[0] = '{'\nreturn append(buf, '}'), nil\n}\n")
+
+	g.comment("UnmarshalJSON implements json.Unmarshaler, decoding the members of data into the fields of v without reflection. Members are matched to fields by their exact name, and members with no matching field are ignored.")
+	g.printf("func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+	g.printf("return optjson.Members(data, func(key string, value []byte) error {\nswitch key {\n")
+	for _, f := range fields {
+		g.printf("case %s:\n", strconv.Quote(f.key))
+		switch {
+		case f.nested:
+			g.printf("// null is kept as Some(None), telling it apart from a missing member.\n")
+			g.printf("x := v.%s.UnwrapOrZero()\nif err := x.UnmarshalJSON(value); err != nil {\nreturn err\n}\n", f.name)
+			g.printf("v.%s = opt.Some(x)\nreturn nil\n", f.name)
+		case f.option:
+			g.printf("return v.%s.UnmarshalJSON(value)\n", f.name)
+		default:
+			g.imports["encoding/json"] = ""
+			g.printf("return json.Unmarshal(value, &v.%s)\n", f.name)
+		}
+	}
+	g.printf("}\nreturn nil\n})\n}\n")
+	return nil
+}
+
+func hasTagOption(opts, name string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
+
+// nonEmpty returns the condition under which x, of type t, is not empty as
+// encoding/json defines it for the "omitempty" tag option.
+func nonEmpty(x string, t ast.Expr) (string, error) {
+	switch t := t.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return x + ` != ""`, nil
+		case "bool":
+			return x, nil
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"float32", "float64", "byte", "rune":
+			return x + " != 0", nil
+		case "any", "error":
+			return x + " != nil", nil
+		}
+	case *ast.ArrayType, *ast.MapType:
+		return "len(" + x + ") != 0", nil
+	case *ast.StarExpr, *ast.InterfaceType:
+		return x + " != nil", nil
+	}
+	return "", fmt.Errorf("omitempty is only supported on Options and built-in types, not %s", types.ExprString(t))
+}
+
+// jsonString returns s encoded as a JSON string.
+func jsonString(s string) string {
+	data, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// goString returns s as a Go string literal, raw if it can be.
+func goString(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+	return strconv.Quote(s)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJSON(t *testing.T) {
+	p, err := parsePackage("internal/jsontest")
+	require.NoError(t, err)
+	src, err := generate(p, request{json: []string{"Event", "Place"}})
+	require.NoError(t, err)
+	// The output is checked in to test it, rather than kept as a golden file.
+	const path = "internal/jsontest/event_json.go"
+	if os.Getenv("OPTGEN_UPDATE") != "" {
+		require.NoError(t, os.WriteFile(path, src, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(src))
+
+	src, err = generate(p, request{json: []string{"Event"}, omitNone: true})
+	require.NoError(t, err)
+	require.Contains(t, string(src), "if v.Name.IsSome() {\n\t\tbuf = append(buf, `,\"name\":`...)")
+	require.Contains(t, string(src), "if v.At.IsSome() {")
+	require.NotContains(t, string(src), "if v.ID")
+	check(t, "internal/jsontest", src)
+
+	p, err = parsePackage("testdata/json")
+	require.NoError(t, err)
+	for name, msg := range map[string]string{
+		"Embedded":  "embedded field Inner is not supported",
+		"Stringly":  "field N: the string tag option is not supported",
+		"Duplicate": `fields A and B both have the JSON name "x"`,
+		"Named":     "field S: omitempty is only supported on Options and built-in types, not Status",
+		"Missing":   "type Missing not found",
+	} {
+		_, err := generate(p, request{json: []string{name}})
+		require.ErrorContains(t, err, msg, name)
+	}
+}
@@ -0,0 +1,377 @@
+// Command optgen generates code for working with opt.Option from the structs
+// of a Go package. It is meant to be run by go generate:
+//
+//	//go:generate go run code.nkcmr.net/opt/cmd/optgen -type User,Team
+//
+// For each named struct type Xxx it writes a sibling XxxPatch type, with
+// every exported field of Xxx wrapped in an Option and the same struct tags,
+// along with two methods:
+//
+//	func (v Xxx) ToPatch() XxxPatch
+//	func (p XxxPatch) Apply(v *Xxx)
+//
+// ToPatch returns a patch that sets every field, and Apply sets the fields of
+// v for which the patch is Some.
+//
+// For each path Xxx.Field.Field... given to -accessors it writes a function
+// that follows the path from a *Xxx, returning the value at its end as an
+// Option that is None if any pointer along the way is nil:
+//
+//	//go:generate go run code.nkcmr.net/opt/cmd/optgen -accessors Order.User.Address.City
+//
+//	func GetUserAddressCity(v *Order) opt.Option[string]
+//
+// Every field on the path but the last must be a struct declared in the same
+// package, or a pointer to one. If the last field is a pointer, the Option
+// holds the value it points to, and if it is an Option, it is returned as it
+// is.
+//
+// For each pair Domain=DTO given to -convert it writes functions converting
+// between a DTO struct with pointer fields, such as those generated for API
+// clients, and a domain struct with Option fields in their place:
+//
+//	//go:generate go run code.nkcmr.net/opt/cmd/optgen -convert User=example.com/api/client.User
+//
+//	func UserToOption(v *client.User) User
+//	func UserFromOption(v User) *client.User
+//
+// The DTO may be declared in the same package as the domain struct, or in the
+// package with the given import path. Fields with the same name are
+// converted: a nil pointer becomes None and None a nil pointer, a field that
+// is not a pointer becomes Some, and fields of the same type are copied.
+// Fields of struct types that are themselves converted, or Options of them,
+// are converted with the functions generated for them. Fields only one of the
+// structs has are left out.
+//
+// For each struct type given to -json it writes MarshalJSON and UnmarshalJSON
+// methods that encode and decode the exported fields one by one, without the
+// reflection encoding/json uses, which matters most for structs with many
+// Option fields:
+//
+//	//go:generate go run code.nkcmr.net/opt/cmd/optgen -json UpdateUserRequest -json-omit-none
+//
+// Fields are named by their json tags as encoding/json names them. An Option
+// field that is None is encoded as null, or left out if it has the
+// "omitempty" or "omitzero" tag option or -json-omit-none is given. Other
+// fields are left out as encoding/json leaves them out for "omitempty", which
+// is only supported for built-in types. A field of type
+// opt.Option[opt.Option[T]] tells an explicit null apart from a missing
+// member: it is left out when None, encoded as null when Some(None), and
+// decoded from null as Some(None). Embedded fields and the ",string" tag
+// option are not supported, and members are matched to fields by their exact
+// name, not case-insensitively.
+//
+// The output is written to the package directory, in xxx_patch.go after the
+// first type given to -type, xxx_accessors.go after the type the first
+// accessor starts from, xxx_convert.go after the first domain type given to
+// -convert, or xxx_json.go after the first type given to -json, unless
+// -output says otherwise.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("optgen: ")
+
+	typeNames := flag.String("type", "", "comma-separated list of struct type names to generate patch types for")
+	accessorPaths := flag.String("accessors", "", "comma-separated list of Type.Field.Field... paths to generate accessors for")
+	convertPairs := flag.String("convert", "", "comma-separated list of Domain=DTO pairs to generate converters for; DTO may be path/to/pkg.Type")
+	jsonTypes := flag.String("json", "", "comma-separated list of struct type names to generate JSON methods for")
+	omitNone := flag.Bool("json-omit-none", false, "leave Option fields that are None out of the JSON generated methods encode")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_patch.go, <dir>/<type>_accessors.go, <dir>/<type>_convert.go or <dir>/<type>_json.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: optgen [-type T[,T...]] [-accessors T.F[.F...][,...]] [-convert T=DTO[,...]] [-json T[,T...] [-json-omit-none]] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" && *accessorPaths == "" && *convertPairs == "" && *jsonTypes == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	split := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, ",")
+	}
+	r := request{
+		types:     split(*typeNames),
+		accessors: split(*accessorPaths),
+		converts:  split(*convertPairs),
+		json:      split(*jsonTypes),
+		omitNone:  *omitNone,
+	}
+
+	pkg, err := parsePackage(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(pkg, r)
+	if err != nil {
+		log.Fatal(err)
+	}
+	name := *output
+	switch {
+	case name != "":
+	case len(r.types) > 0:
+		name = filepath.Join(dir, strings.ToLower(r.types[0])+"_patch.go")
+	case len(r.accessors) > 0:
+		root, _, _ := strings.Cut(r.accessors[0], ".")
+		name = filepath.Join(dir, strings.ToLower(root)+"_accessors.go")
+	case len(r.converts) > 0:
+		domain, _, _ := strings.Cut(r.converts[0], "=")
+		name = filepath.Join(dir, strings.ToLower(domain)+"_convert.go")
+	default:
+		name = filepath.Join(dir, strings.ToLower(r.json[0])+"_json.go")
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// request is what to generate.
+type request struct {
+	types     []string // to generate patch types for
+	accessors []string // paths to generate accessors for
+	converts  []string // Domain=DTO pairs to generate converters for
+	json      []string // types to generate JSON methods for
+	omitNone  bool     // whether the JSON methods leave out None fields
+}
+
+// generate returns a file with the code r asks for.
+func generate(p *pkg, r request) ([]byte, error) {
+	g := newGenerator(p)
+	if err := g.patches(r.types); err != nil {
+		return nil, err
+	}
+	if err := g.accessors(r.accessors); err != nil {
+		return nil, err
+	}
+	if err := g.conversions(r.converts); err != nil {
+		return nil, err
+	}
+	if err := g.jsonMethods(r.json, r.omitNone); err != nil {
+		return nil, err
+	}
+	return g.source()
+}
+
+// pkg is a parsed package.
+type pkg struct {
+	dir   string
+	name  string
+	fset  *token.FileSet
+	files []*ast.File
+}
+
+// parsePackage parses the non-test Go files in dir, skipping files generated
+// by optgen.
+func parsePackage(dir string) (*pkg, error) {
+	fset := token.NewFileSet()
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	p := &pkg{dir: dir, fset: fset}
+	for _, path := range matches {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if isGenerated(f) {
+			continue
+		}
+		if p.name == "" {
+			p.name = f.Name.Name
+		} else if f.Name.Name != p.name {
+			return nil, fmt.Errorf("%s: found packages %s and %s", dir, p.name, f.Name.Name)
+		}
+		p.files = append(p.files, f)
+	}
+	if len(p.files) == 0 {
+		return nil, fmt.Errorf("%s: no Go files", dir)
+	}
+	return p, nil
+}
+
+func isGenerated(f *ast.File) bool {
+	for _, group := range f.Comments {
+		if group.Pos() >= f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "// Code generated by optgen") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookup finds the struct type called name and the file it is declared in.
+func (p *pkg) lookup(name string) (*ast.TypeSpec, *ast.StructType, *ast.File, error) {
+	for _, f := range p.files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.Name.Name != name {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || ts.Assign.IsValid() {
+					return nil, nil, nil, fmt.Errorf("%s is not a struct type", name)
+				}
+				if ts.TypeParams != nil {
+					return nil, nil, nil, fmt.Errorf("%s is generic, which is not supported", name)
+				}
+				return ts, st, f, nil
+			}
+		}
+	}
+	return nil, nil, nil, fmt.Errorf("type %s not found", name)
+}
+
+const optPath = "code.nkcmr.net/opt"
+
+// generator accumulates the output file.
+type generator struct {
+	pkg     *pkg
+	buf     bytes.Buffer
+	imports map[string]string // path → name
+}
+
+func newGenerator(p *pkg) *generator {
+	return &generator{pkg: p, imports: map[string]string{optPath: "opt"}}
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment writes text as a comment, wrapped to fit in 80 columns.
+func (g *generator) comment(text string) {
+	g.printf("\n//")
+	n := 2
+	for _, word := range strings.Fields(text) {
+		if n > 2 && n+1+len(word) > 80 {
+			g.printf("\n//")
+			n = 2
+		}
+		g.printf(" %s", word)
+		n += 1 + len(word)
+	}
+	g.printf("\n")
+}
+
+// expr formats e, which appears in file, recording the imports it uses.
+func (g *generator) expr(e ast.Expr, file *ast.File) string {
+	ast.Inspect(e, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok {
+			if path, name, ok := importOf(file, id.Name); ok {
+				g.imports[path] = name
+			}
+		}
+		return true
+	})
+	var buf bytes.Buffer
+	if err := format.Node(&buf, g.pkg.fset, e); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+// importOf returns the path of the package file refers to as name, and the
+// name it should be imported under.
+func importOf(file *ast.File, name string) (path, as string, ok bool) {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return path, name, true
+			}
+			continue
+		}
+		// Assume the package name is the last element of its path, which
+		// holds for all but a few packages, ignoring a major version suffix.
+		base := filepath.Base(path)
+		if strings.HasPrefix(base, "v") && len(path) > len(base) {
+			if _, err := strconv.Atoi(base[1:]); err == nil {
+				base = filepath.Base(filepath.Dir(path))
+			}
+		}
+		if base == name {
+			return path, "", true
+		}
+	}
+	return "", "", false
+}
+
+// source returns the complete, formatted output file.
+func (g *generator) source() ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by optgen; DO NOT EDIT.\n\npackage %s\n\n", g.pkg.name)
+	// Standard library packages go in a group before the others.
+	var std, other []string
+	for path := range g.imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	out.WriteString("import (\n")
+	for i, group := range [][]string{std, other} {
+		if i > 0 && len(std) > 0 && len(other) > 0 {
+			out.WriteString("\n")
+		}
+		for _, path := range group {
+			if name := g.imports[path]; name != "" && name != filepath.Base(path) {
+				fmt.Fprintf(&out, "%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&out, "%q\n", path)
+			}
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting output: %w\n%s", err, out.Bytes())
+	}
+	return src, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// check type checks the package in dir along with the generated file src.
+func check(t *testing.T, dir string, src []byte) {
+	t.Helper()
+	p, err := parsePackage(dir)
+	require.NoError(t, err)
+	f, err := parser.ParseFile(p.fset, "generated.go", src, 0)
+	require.NoError(t, err)
+	conf := types.Config{Importer: importer.ForCompiler(p.fset, "source", nil)}
+	_, err = conf.Check(p.name, p.fset, append([]*ast.File{f}, p.files...), nil)
+	require.NoError(t, err)
+}
+
+// golden compares src to the golden file called name in dir, updating it if
+// the OPTGEN_UPDATE environment variable is set.
+func golden(t *testing.T, dir, name string, src []byte) {
+	t.Helper()
+	path := filepath.Join(dir, name+".golden")
+	if os.Getenv("OPTGEN_UPDATE") != "" {
+		require.NoError(t, os.WriteFile(path, src, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(src))
+}
+
+func TestParsePackage(t *testing.T) {
+	_, err := parsePackage("testdata/missing")
+	require.Error(t, err)
+
+	p, err := parsePackage("testdata/user")
+	require.NoError(t, err)
+	for _, name := range []string{"NotAStruct", "Missing"} {
+		_, _, _, err := p.lookup(name)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// patches adds an XxxPatch type, along with its ToPatch and Apply methods,
+// to the output for each of the struct types named.
+func (g *generator) patches(types []string) error {
+	for _, name := range types {
+		if err := g.patch(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type patchField struct {
+	name, typ, tag string
+}
+
+func (g *generator) patch(name string) error {
+	_, st, file, err := g.pkg.lookup(name)
+	if err != nil {
+		return err
+	}
+	var fields []patchField
+	for _, f := range st.Fields.List {
+		names := f.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{embeddedName(f.Type)}
+		}
+		var typ, tag string
+		for _, n := range names {
+			if !n.IsExported() {
+				continue
+			}
+			if typ == "" {
+				typ = g.expr(f.Type, file)
+				if f.Tag != nil {
+					tag = " " + f.Tag.Value
+				}
+			}
+			fields = append(fields, patchField{n.Name, typ, tag})
+		}
+	}
+
+	patch := name + "Patch"
+	g.comment(fmt.Sprintf("%s holds changes to the exported fields of a %s. Fields that are None are left unchanged by Apply.", patch, name))
+	g.printf("type %s struct {\n", patch)
+	for _, f := range fields {
+		g.printf("%s opt.Option[%s]%s\n", f.name, f.typ, f.tag)
+	}
+	g.printf("}\n")
+
+	g.comment(fmt.Sprintf("ToPatch returns a %s that sets every field to its value in v.", patch))
+	g.printf("func (v %s) ToPatch() %s {\n", name, patch)
+	g.printf("return %s{\n", patch)
+	for _, f := range fields {
+		g.printf("%s: opt.Some(v.%[1]s),\n", f.name)
+	}
+	g.printf("}\n}\n")
+
+	g.comment("Apply sets the fields of v for which p is Some.")
+	g.printf("func (p %s) Apply(v *%s) {\n", patch, name)
+	for _, f := range fields {
+		g.printf("if x, ok := p.%s.MaybeUnwrap(); ok {\nv.%[1]s = x\n}\n", f.name)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// embeddedName returns the name of an embedded field of type t.
+func embeddedName(t ast.Expr) *ast.Ident {
+	switch t := t.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel
+	case *ast.IndexExpr:
+		return embeddedName(t.X)
+	case *ast.IndexListExpr:
+		return embeddedName(t.X)
+	}
+	panic(fmt.Sprintf("optgen: unexpected embedded field type %T", t))
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePatches(t *testing.T) {
+	p, err := parsePackage("testdata/user")
+	require.NoError(t, err)
+	src, err := generate(p, request{types: []string{"User", "Team"}})
+	require.NoError(t, err)
+	golden(t, "testdata/user", "user_patch.go", src)
+	check(t, "testdata/user", src)
+
+	_, err = generate(p, request{types: []string{"NotAStruct"}})
+	require.Error(t, err)
+}
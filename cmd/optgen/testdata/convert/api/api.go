@@ -0,0 +1,21 @@
+package api
+
+import "time"
+
+type User struct {
+	ID        int64
+	Name      *string
+	Email     *string
+	Age       int
+	Tags      []string
+	CreatedAt *time.Time
+	Address   *Address
+	Billing   *Address
+	Office    Address
+	Extra     map[string]string
+}
+
+type Address struct {
+	City *string
+	Zip  *string
+}
@@ -0,0 +1,39 @@
+package convert
+
+import (
+	"time"
+
+	"code.nkcmr.net/opt"
+)
+
+type User struct {
+	ID        int64
+	Name      opt.Option[string]
+	Email     opt.Option[string]
+	Age       opt.Option[int]
+	Tags      []string
+	CreatedAt opt.Option[time.Time]
+	Address   opt.Option[Address]
+	Billing   Address
+	Office    opt.Option[Address]
+	Internal  string
+}
+
+type Address struct {
+	City opt.Option[string]
+	Zip  opt.Option[string]
+}
+
+type Local struct {
+	Name opt.Option[string]
+	Note string
+}
+
+type LocalDTO struct {
+	Name *string
+	Note string
+}
+
+type Mismatch struct {
+	Age opt.Option[string]
+}
@@ -0,0 +1,26 @@
+package json
+
+import "code.nkcmr.net/opt"
+
+type Embedded struct {
+	Inner
+}
+
+type Inner struct {
+	Name opt.Option[string]
+}
+
+type Stringly struct {
+	N int `json:",string"`
+}
+
+type Duplicate struct {
+	A opt.Option[string] `json:"x"`
+	B opt.Option[string] `json:"x"`
+}
+
+type Status string
+
+type Named struct {
+	S Status `json:",omitempty"`
+}
@@ -0,0 +1,30 @@
+package order
+
+import (
+	"time"
+
+	o "code.nkcmr.net/opt"
+)
+
+type Order struct {
+	ID       int64
+	User     *User
+	Shipping Shipping
+	Note     *string
+}
+
+type User struct {
+	Name    string
+	Address *Address
+	Phone   o.Option[string]
+}
+
+type Address struct {
+	City    string
+	Updated *time.Time
+}
+
+type Shipping struct {
+	To      *Address
+	Carrier string
+}
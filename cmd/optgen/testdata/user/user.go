@@ -0,0 +1,32 @@
+package user
+
+import (
+	"net/netip"
+	"time"
+
+	"code.nkcmr.net/opt"
+	str "strings"
+)
+
+type Base struct {
+	ID int64 `json:"id"`
+}
+
+type User struct {
+	*Base
+	Name, Email string `json:"name"`
+	Nickname    opt.Option[string]
+	Addr        netip.Addr
+	Roles       []string `json:"roles,omitempty"`
+	Updated     map[string]time.Time
+	Builder     *str.Builder
+	password    string
+	internal    netip.Prefix
+}
+
+type Team struct {
+	Name    string
+	Members []User
+}
+
+type NotAStruct int
@@ -0,0 +1,439 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: test.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_test_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0}
+}
+
+type User struct {
+	state    protoimpl.MessageState  `protogen:"open.v1"`
+	Id       string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     *string                 `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Age      *int32                  `protobuf:"varint,3,opt,name=age,proto3,oneof" json:"age,omitempty"`
+	Avatar   []byte                  `protobuf:"bytes,4,opt,name=avatar,proto3,oneof" json:"avatar,omitempty"`
+	Status   *Status                 `protobuf:"varint,5,opt,name=status,proto3,enum=opt.test.Status,oneof" json:"status,omitempty"`
+	Tags     []string                `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Scores   map[string]int64        `protobuf:"bytes,7,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Address  *User_Address           `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	Nickname *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	//	*User_Mail
+	Contact       isUser_Contact `protobuf_oneof:"contact"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil && x.Age != nil {
+		return *x.Age
+	}
+	return 0
+}
+
+func (x *User) GetAvatar() []byte {
+	if x != nil {
+		return x.Avatar
+	}
+	return nil
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *User) GetScores() map[string]int64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *User) GetAddress() *User_Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *User) GetNickname() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Nickname
+	}
+	return nil
+}
+
+func (x *User) GetContact() isUser_Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *User) GetPhone() int64 {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Phone); ok {
+			return x.Phone
+		}
+	}
+	return 0
+}
+
+func (x *User) GetMail() *User_Address {
+	if x != nil {
+		if x, ok := x.Contact.(*User_Mail); ok {
+			return x.Mail
+		}
+	}
+	return nil
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string `protobuf:"bytes,10,opt,name=email,proto3,oneof"`
+}
+
+type User_Phone struct {
+	Phone int64 `protobuf:"varint,11,opt,name=phone,proto3,oneof"`
+}
+
+type User_Mail struct {
+	Mail *User_Address `protobuf:"bytes,12,opt,name=mail,proto3,oneof"`
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+
+func (*User_Mail) isUser_Contact() {}
+
+type Plain struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plain) Reset() {
+	*x = Plain{}
+	mi := &file_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plain) ProtoMessage() {}
+
+func (x *Plain) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plain.ProtoReflect.Descriptor instead.
+func (*Plain) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Plain) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type User_Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          *string                `protobuf:"bytes,1,opt,name=city,proto3,oneof" json:"city,omitempty"`
+	Street        string                 `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User_Address) Reset() {
+	*x = User_Address{}
+	mi := &file_test_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User_Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User_Address) ProtoMessage() {}
+
+func (x *User_Address) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User_Address.ProtoReflect.Descriptor instead.
+func (*User_Address) Descriptor() ([]byte, []int) {
+	return file_test_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *User_Address) GetCity() string {
+	if x != nil && x.City != nil {
+		return *x.City
+	}
+	return ""
+}
+
+func (x *User_Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+var File_test_proto protoreflect.FileDescriptor
+
+const file_test_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"test.proto\x12\bopt.test\x1a\x1egoogle/protobuf/wrappers.proto\"\xd6\x04\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x15\n" +
+	"\x03age\x18\x03 \x01(\x05H\x02R\x03age\x88\x01\x01\x12\x1b\n" +
+	"\x06avatar\x18\x04 \x01(\fH\x03R\x06avatar\x88\x01\x01\x12-\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x10.opt.test.StatusH\x04R\x06status\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x122\n" +
+	"\x06scores\x18\a \x03(\v2\x1a.opt.test.User.ScoresEntryR\x06scores\x120\n" +
+	"\aaddress\x18\b \x01(\v2\x16.opt.test.User.AddressR\aaddress\x128\n" +
+	"\bnickname\x18\t \x01(\v2\x1c.google.protobuf.StringValueR\bnickname\x12\x16\n" +
+	"\x05email\x18\n" +
+	" \x01(\tH\x00R\x05email\x12\x16\n" +
+	"\x05phone\x18\v \x01(\x03H\x00R\x05phone\x12,\n" +
+	"\x04mail\x18\f \x01(\v2\x16.opt.test.User.AddressH\x00R\x04mail\x1aC\n" +
+	"\aAddress\x12\x17\n" +
+	"\x04city\x18\x01 \x01(\tH\x00R\x04city\x88\x01\x01\x12\x16\n" +
+	"\x06street\x18\x02 \x01(\tR\x06streetB\a\n" +
+	"\x05_city\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01B\t\n" +
+	"\acontactB\a\n" +
+	"\x05_nameB\x06\n" +
+	"\x04_ageB\t\n" +
+	"\a_avatarB\t\n" +
+	"\a_status\"\x17\n" +
+	"\x05Plain\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*3\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATUS_ACTIVE\x10\x01B7Z5code.nkcmr.net/opt/cmd/protoc-gen-opt/internal/testpbb\x06proto3"
+
+var (
+	file_test_proto_rawDescOnce sync.Once
+	file_test_proto_rawDescData []byte
+)
+
+func file_test_proto_rawDescGZIP() []byte {
+	file_test_proto_rawDescOnce.Do(func() {
+		file_test_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_test_proto_rawDesc), len(file_test_proto_rawDesc)))
+	})
+	return file_test_proto_rawDescData
+}
+
+var file_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_test_proto_goTypes = []any{
+	(Status)(0),                    // 0: opt.test.Status
+	(*User)(nil),                   // 1: opt.test.User
+	(*Plain)(nil),                  // 2: opt.test.Plain
+	(*User_Address)(nil),           // 3: opt.test.User.Address
+	nil,                            // 4: opt.test.User.ScoresEntry
+	(*wrapperspb.StringValue)(nil), // 5: google.protobuf.StringValue
+}
+var file_test_proto_depIdxs = []int32{
+	0, // 0: opt.test.User.status:type_name -> opt.test.Status
+	4, // 1: opt.test.User.scores:type_name -> opt.test.User.ScoresEntry
+	3, // 2: opt.test.User.address:type_name -> opt.test.User.Address
+	5, // 3: opt.test.User.nickname:type_name -> google.protobuf.StringValue
+	3, // 4: opt.test.User.mail:type_name -> opt.test.User.Address
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_test_proto_init() }
+func file_test_proto_init() {
+	if File_test_proto != nil {
+		return
+	}
+	file_test_proto_msgTypes[0].OneofWrappers = []any{
+		(*User_Email)(nil),
+		(*User_Phone)(nil),
+		(*User_Mail)(nil),
+	}
+	file_test_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_test_proto_rawDesc), len(file_test_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_test_proto_goTypes,
+		DependencyIndexes: file_test_proto_depIdxs,
+		EnumInfos:         file_test_proto_enumTypes,
+		MessageInfos:      file_test_proto_msgTypes,
+	}.Build()
+	File_test_proto = out.File
+	file_test_proto_goTypes = nil
+	file_test_proto_depIdxs = nil
+}
@@ -0,0 +1,185 @@
+// Code generated by protoc-gen-opt. DO NOT EDIT.
+// source: test.proto
+
+package testpb
+
+import (
+	opt "code.nkcmr.net/opt"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// OptName returns the name field of x, or None if it is not set.
+func (x *User) OptName() opt.Option[string] {
+	if x != nil && x.Name != nil {
+		return opt.Some(*x.Name)
+	}
+	return opt.None[string]()
+}
+
+// OptAge returns the age field of x, or None if it is not set.
+func (x *User) OptAge() opt.Option[int32] {
+	if x != nil && x.Age != nil {
+		return opt.Some(*x.Age)
+	}
+	return opt.None[int32]()
+}
+
+// OptAvatar returns the avatar field of x, or None if it is not set.
+func (x *User) OptAvatar() opt.Option[[]byte] {
+	if x != nil && x.Avatar != nil {
+		return opt.Some(x.Avatar)
+	}
+	return opt.None[[]byte]()
+}
+
+// OptStatus returns the status field of x, or None if it is not set.
+func (x *User) OptStatus() opt.Option[Status] {
+	if x != nil && x.Status != nil {
+		return opt.Some(*x.Status)
+	}
+	return opt.None[Status]()
+}
+
+// OptAddress returns the address field of x, or None if it is not set.
+func (x *User) OptAddress() opt.Option[*User_Address] {
+	if v := x.GetAddress(); v != nil {
+		return opt.Some(v)
+	}
+	return opt.None[*User_Address]()
+}
+
+// OptNickname returns the nickname field of x, or None if it is not set.
+func (x *User) OptNickname() opt.Option[string] {
+	if v := x.GetNickname(); v != nil {
+		return opt.Some(v.GetValue())
+	}
+	return opt.None[string]()
+}
+
+// OptEmail returns the email field of x, or None if it is not set.
+func (x *User) OptEmail() opt.Option[string] {
+	if v, ok := x.GetContact().(*User_Email); ok {
+		return opt.Some(v.Email)
+	}
+	return opt.None[string]()
+}
+
+// OptPhone returns the phone field of x, or None if it is not set.
+func (x *User) OptPhone() opt.Option[int64] {
+	if v, ok := x.GetContact().(*User_Phone); ok {
+		return opt.Some(v.Phone)
+	}
+	return opt.None[int64]()
+}
+
+// OptMail returns the mail field of x, or None if it is not set.
+func (x *User) OptMail() opt.Option[*User_Address] {
+	if v := x.GetMail(); v != nil {
+		return opt.Some(v)
+	}
+	return opt.None[*User_Address]()
+}
+
+// UserOpt mirrors User, with an opt.Option in place of each field
+// that tracks whether it is set.
+type UserOpt struct {
+	Id       string
+	Name     opt.Option[string]
+	Age      opt.Option[int32]
+	Avatar   opt.Option[[]byte]
+	Status   opt.Option[Status]
+	Tags     []string
+	Scores   map[string]int64
+	Address  opt.Option[*User_Address]
+	Nickname opt.Option[string]
+	Email    opt.Option[string]
+	Phone    opt.Option[int64]
+	Mail     opt.Option[*User_Address]
+}
+
+// ToOpt returns the fields of x as a UserOpt.
+func (x *User) ToOpt() UserOpt {
+	return UserOpt{
+		Id:       x.GetId(),
+		Name:     x.OptName(),
+		Age:      x.OptAge(),
+		Avatar:   x.OptAvatar(),
+		Status:   x.OptStatus(),
+		Tags:     x.GetTags(),
+		Scores:   x.GetScores(),
+		Address:  x.OptAddress(),
+		Nickname: x.OptNickname(),
+		Email:    x.OptEmail(),
+		Phone:    x.OptPhone(),
+		Mail:     x.OptMail(),
+	}
+}
+
+// ToProto returns a new User with the fields of m.
+func (m UserOpt) ToProto() *User {
+	x := &User{}
+	x.Id = m.Id
+	if v, ok := m.Name.MaybeUnwrap(); ok {
+		x.Name = &v
+	}
+	if v, ok := m.Age.MaybeUnwrap(); ok {
+		x.Age = &v
+	}
+	if v, ok := m.Avatar.MaybeUnwrap(); ok {
+		x.Avatar = v
+	}
+	if v, ok := m.Status.MaybeUnwrap(); ok {
+		x.Status = &v
+	}
+	x.Tags = m.Tags
+	x.Scores = m.Scores
+	if v, ok := m.Address.MaybeUnwrap(); ok {
+		x.Address = v
+	}
+	if v, ok := m.Nickname.MaybeUnwrap(); ok {
+		x.Nickname = wrapperspb.String(v)
+	}
+	if v, ok := m.Email.MaybeUnwrap(); ok {
+		x.Contact = &User_Email{Email: v}
+	}
+	if v, ok := m.Phone.MaybeUnwrap(); ok {
+		x.Contact = &User_Phone{Phone: v}
+	}
+	if v, ok := m.Mail.MaybeUnwrap(); ok {
+		x.Contact = &User_Mail{Mail: v}
+	}
+	return x
+}
+
+// OptCity returns the city field of x, or None if it is not set.
+func (x *User_Address) OptCity() opt.Option[string] {
+	if x != nil && x.City != nil {
+		return opt.Some(*x.City)
+	}
+	return opt.None[string]()
+}
+
+// User_AddressOpt mirrors User_Address, with an opt.Option in place of each field
+// that tracks whether it is set.
+type User_AddressOpt struct {
+	City   opt.Option[string]
+	Street string
+}
+
+// ToOpt returns the fields of x as a User_AddressOpt.
+func (x *User_Address) ToOpt() User_AddressOpt {
+	return User_AddressOpt{
+		City:   x.OptCity(),
+		Street: x.GetStreet(),
+	}
+}
+
+// ToProto returns a new User_Address with the fields of m.
+func (m User_AddressOpt) ToProto() *User_Address {
+	x := &User_Address{}
+	if v, ok := m.City.MaybeUnwrap(); ok {
+		x.City = &v
+	}
+	x.Street = m.Street
+	return x
+}
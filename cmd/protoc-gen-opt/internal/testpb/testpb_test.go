@@ -0,0 +1,76 @@
+package testpb
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAccessors(t *testing.T) {
+	var nilUser *User
+	require.True(t, nilUser.OptName().None())
+	require.True(t, nilUser.OptAddress().None())
+	require.True(t, nilUser.OptEmail().None())
+
+	u := (&UserOpt{}).ToProto()
+	require.True(t, u.OptName().None())
+	require.True(t, u.OptAge().None())
+	require.True(t, u.OptAvatar().None())
+	require.True(t, u.OptStatus().None())
+	require.True(t, u.OptAddress().None())
+	require.True(t, u.OptNickname().None())
+	require.True(t, u.OptEmail().None())
+	require.True(t, u.OptPhone().None())
+	require.True(t, u.OptMail().None())
+
+	u = UserOpt{
+		Name:     opt.Some(""),
+		Age:      opt.Some[int32](0),
+		Avatar:   opt.Some([]byte{}),
+		Status:   opt.Some(Status_STATUS_UNSPECIFIED),
+		Nickname: opt.Some(""),
+		Phone:    opt.Some[int64](0),
+	}.ToProto()
+	require.Equal(t, opt.Some(""), u.OptName())
+	require.Equal(t, opt.Some[int32](0), u.OptAge())
+	require.Equal(t, opt.Some([]byte{}), u.OptAvatar())
+	require.Equal(t, opt.Some(Status_STATUS_UNSPECIFIED), u.OptStatus())
+	require.Equal(t, opt.Some(""), u.OptNickname())
+	require.Equal(t, opt.Some[int64](0), u.OptPhone())
+	require.True(t, u.OptEmail().None())
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := UserOpt{
+		Id:       "u1",
+		Name:     opt.Some("ann"),
+		Status:   opt.Some(Status_STATUS_ACTIVE),
+		Tags:     []string{"a", "b"},
+		Scores:   map[string]int64{"x": 1},
+		Address:  opt.Some((&User_AddressOpt{City: opt.Some("Oslo")}).ToProto()),
+		Nickname: opt.Some("annie"),
+		Email:    opt.Some("ann@example.com"),
+	}
+	u := want.ToProto()
+
+	data, err := proto.Marshal(u)
+	require.NoError(t, err)
+	var got User
+	require.NoError(t, proto.Unmarshal(data, &got))
+	require.True(t, proto.Equal(u, &got))
+
+	m := got.ToOpt()
+	require.Equal(t, "u1", m.Id)
+	require.Equal(t, opt.Some("ann"), m.Name)
+	require.True(t, m.Age.None())
+	require.Equal(t, opt.Some(Status_STATUS_ACTIVE), m.Status)
+	require.Equal(t, []string{"a", "b"}, m.Tags)
+	require.Equal(t, map[string]int64{"x": 1}, m.Scores)
+	require.Equal(t, opt.Some("Oslo"), m.Address.Unwrap().OptCity())
+	require.Equal(t, opt.Some("annie"), m.Nickname)
+	require.Equal(t, opt.Some("ann@example.com"), m.Email)
+	require.True(t, m.Phone.None())
+	require.True(t, m.Mail.None())
+}
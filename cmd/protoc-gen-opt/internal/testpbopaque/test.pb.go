@@ -0,0 +1,733 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: test.proto
+
+package testpbopaque
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_test_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_test_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+type User struct {
+	state                  protoimpl.MessageState  `protogen:"opaque.v1"`
+	xxx_hidden_Id          string                  `protobuf:"bytes,1,opt,name=id,proto3"`
+	xxx_hidden_Name        *string                 `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+	xxx_hidden_Age         int32                   `protobuf:"varint,3,opt,name=age,proto3,oneof"`
+	xxx_hidden_Avatar      []byte                  `protobuf:"bytes,4,opt,name=avatar,proto3,oneof"`
+	xxx_hidden_Status      Status                  `protobuf:"varint,5,opt,name=status,proto3,enum=opt.test.opaque.Status,oneof"`
+	xxx_hidden_Tags        []string                `protobuf:"bytes,6,rep,name=tags,proto3"`
+	xxx_hidden_Scores      map[string]int64        `protobuf:"bytes,7,rep,name=scores,proto3" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	xxx_hidden_Address     *User_Address           `protobuf:"bytes,8,opt,name=address,proto3"`
+	xxx_hidden_Nickname    *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=nickname,proto3"`
+	xxx_hidden_Contact     isUser_Contact          `protobuf_oneof:"contact"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		if x.xxx_hidden_Name != nil {
+			return *x.xxx_hidden_Name
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User) GetAge() int32 {
+	if x != nil {
+		return x.xxx_hidden_Age
+	}
+	return 0
+}
+
+func (x *User) GetAvatar() []byte {
+	if x != nil {
+		return x.xxx_hidden_Avatar
+	}
+	return nil
+}
+
+func (x *User) GetStatus() Status {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 4) {
+			return x.xxx_hidden_Status
+		}
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.xxx_hidden_Tags
+	}
+	return nil
+}
+
+func (x *User) GetScores() map[string]int64 {
+	if x != nil {
+		return x.xxx_hidden_Scores
+	}
+	return nil
+}
+
+func (x *User) GetAddress() *User_Address {
+	if x != nil {
+		return x.xxx_hidden_Address
+	}
+	return nil
+}
+
+func (x *User) GetNickname() *wrapperspb.StringValue {
+	if x != nil {
+		return x.xxx_hidden_Nickname
+	}
+	return nil
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Contact.(*user_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *User) GetPhone() int64 {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Contact.(*user_Phone); ok {
+			return x.Phone
+		}
+	}
+	return 0
+}
+
+func (x *User) GetMail() *User_Address {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Contact.(*user_Mail); ok {
+			return x.Mail
+		}
+	}
+	return nil
+}
+
+func (x *User) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+func (x *User) SetName(v string) {
+	x.xxx_hidden_Name = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *User) SetAge(v int32) {
+	x.xxx_hidden_Age = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *User) SetAvatar(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Avatar = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *User) SetStatus(v Status) {
+	x.xxx_hidden_Status = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *User) SetTags(v []string) {
+	x.xxx_hidden_Tags = v
+}
+
+func (x *User) SetScores(v map[string]int64) {
+	x.xxx_hidden_Scores = v
+}
+
+func (x *User) SetAddress(v *User_Address) {
+	x.xxx_hidden_Address = v
+}
+
+func (x *User) SetNickname(v *wrapperspb.StringValue) {
+	x.xxx_hidden_Nickname = v
+}
+
+func (x *User) SetEmail(v string) {
+	x.xxx_hidden_Contact = &user_Email{v}
+}
+
+func (x *User) SetPhone(v int64) {
+	x.xxx_hidden_Contact = &user_Phone{v}
+}
+
+func (x *User) SetMail(v *User_Address) {
+	if v == nil {
+		x.xxx_hidden_Contact = nil
+		return
+	}
+	x.xxx_hidden_Contact = &user_Mail{v}
+}
+
+func (x *User) HasName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *User) HasAge() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *User) HasAvatar() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *User) HasStatus() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *User) HasAddress() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Address != nil
+}
+
+func (x *User) HasNickname() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Nickname != nil
+}
+
+func (x *User) HasContact() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Contact != nil
+}
+
+func (x *User) HasEmail() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Contact.(*user_Email)
+	return ok
+}
+
+func (x *User) HasPhone() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Contact.(*user_Phone)
+	return ok
+}
+
+func (x *User) HasMail() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Contact.(*user_Mail)
+	return ok
+}
+
+func (x *User) ClearName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Name = nil
+}
+
+func (x *User) ClearAge() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Age = 0
+}
+
+func (x *User) ClearAvatar() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_Avatar = nil
+}
+
+func (x *User) ClearStatus() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Status = Status_STATUS_UNSPECIFIED
+}
+
+func (x *User) ClearAddress() {
+	x.xxx_hidden_Address = nil
+}
+
+func (x *User) ClearNickname() {
+	x.xxx_hidden_Nickname = nil
+}
+
+func (x *User) ClearContact() {
+	x.xxx_hidden_Contact = nil
+}
+
+func (x *User) ClearEmail() {
+	if _, ok := x.xxx_hidden_Contact.(*user_Email); ok {
+		x.xxx_hidden_Contact = nil
+	}
+}
+
+func (x *User) ClearPhone() {
+	if _, ok := x.xxx_hidden_Contact.(*user_Phone); ok {
+		x.xxx_hidden_Contact = nil
+	}
+}
+
+func (x *User) ClearMail() {
+	if _, ok := x.xxx_hidden_Contact.(*user_Mail); ok {
+		x.xxx_hidden_Contact = nil
+	}
+}
+
+const User_Contact_not_set_case case_User_Contact = 0
+const User_Email_case case_User_Contact = 10
+const User_Phone_case case_User_Contact = 11
+const User_Mail_case case_User_Contact = 12
+
+func (x *User) WhichContact() case_User_Contact {
+	if x == nil {
+		return User_Contact_not_set_case
+	}
+	switch x.xxx_hidden_Contact.(type) {
+	case *user_Email:
+		return User_Email_case
+	case *user_Phone:
+		return User_Phone_case
+	case *user_Mail:
+		return User_Mail_case
+	default:
+		return User_Contact_not_set_case
+	}
+}
+
+type User_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id       string
+	Name     *string
+	Age      *int32
+	Avatar   []byte
+	Status   *Status
+	Tags     []string
+	Scores   map[string]int64
+	Address  *User_Address
+	Nickname *wrapperspb.StringValue
+	// Fields of oneof xxx_hidden_Contact:
+	Email *string
+	Phone *int64
+	Mail  *User_Address
+	// -- end of xxx_hidden_Contact
+}
+
+func (b0 User_builder) Build() *User {
+	m0 := &User{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	if b.Name != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_Name = b.Name
+	}
+	if b.Age != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_Age = *b.Age
+	}
+	if b.Avatar != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_Avatar = b.Avatar
+	}
+	if b.Status != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_Status = *b.Status
+	}
+	x.xxx_hidden_Tags = b.Tags
+	x.xxx_hidden_Scores = b.Scores
+	x.xxx_hidden_Address = b.Address
+	x.xxx_hidden_Nickname = b.Nickname
+	if b.Email != nil {
+		x.xxx_hidden_Contact = &user_Email{*b.Email}
+	}
+	if b.Phone != nil {
+		x.xxx_hidden_Contact = &user_Phone{*b.Phone}
+	}
+	if b.Mail != nil {
+		x.xxx_hidden_Contact = &user_Mail{b.Mail}
+	}
+	return m0
+}
+
+type case_User_Contact protoreflect.FieldNumber
+
+func (x case_User_Contact) String() string {
+	md := file_test_proto_msgTypes[0].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type user_Email struct {
+	Email string `protobuf:"bytes,10,opt,name=email,proto3,oneof"`
+}
+
+type user_Phone struct {
+	Phone int64 `protobuf:"varint,11,opt,name=phone,proto3,oneof"`
+}
+
+type user_Mail struct {
+	Mail *User_Address `protobuf:"bytes,12,opt,name=mail,proto3,oneof"`
+}
+
+func (*user_Email) isUser_Contact() {}
+
+func (*user_Phone) isUser_Contact() {}
+
+func (*user_Mail) isUser_Contact() {}
+
+type Plain struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id string                 `protobuf:"bytes,1,opt,name=id,proto3"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Plain) Reset() {
+	*x = Plain{}
+	mi := &file_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Plain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plain) ProtoMessage() {}
+
+func (x *Plain) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Plain) GetId() string {
+	if x != nil {
+		return x.xxx_hidden_Id
+	}
+	return ""
+}
+
+func (x *Plain) SetId(v string) {
+	x.xxx_hidden_Id = v
+}
+
+type Plain_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id string
+}
+
+func (b0 Plain_builder) Build() *Plain {
+	m0 := &Plain{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Id = b.Id
+	return m0
+}
+
+type User_Address struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_City        *string                `protobuf:"bytes,1,opt,name=city,proto3,oneof"`
+	xxx_hidden_Street      string                 `protobuf:"bytes,2,opt,name=street,proto3"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *User_Address) Reset() {
+	*x = User_Address{}
+	mi := &file_test_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User_Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User_Address) ProtoMessage() {}
+
+func (x *User_Address) ProtoReflect() protoreflect.Message {
+	mi := &file_test_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *User_Address) GetCity() string {
+	if x != nil {
+		if x.xxx_hidden_City != nil {
+			return *x.xxx_hidden_City
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *User_Address) GetStreet() string {
+	if x != nil {
+		return x.xxx_hidden_Street
+	}
+	return ""
+}
+
+func (x *User_Address) SetCity(v string) {
+	x.xxx_hidden_City = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *User_Address) SetStreet(v string) {
+	x.xxx_hidden_Street = v
+}
+
+func (x *User_Address) HasCity() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *User_Address) ClearCity() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_City = nil
+}
+
+type User_Address_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	City   *string
+	Street string
+}
+
+func (b0 User_Address_builder) Build() *User_Address {
+	m0 := &User_Address{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.City != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_City = b.City
+	}
+	x.xxx_hidden_Street = b.Street
+	return m0
+}
+
+var File_test_proto protoreflect.FileDescriptor
+
+const file_test_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"test.proto\x12\x0fopt.test.opaque\x1a\x1egoogle/protobuf/wrappers.proto\"\xf2\x04\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x01R\x04name\x88\x01\x01\x12\x15\n" +
+	"\x03age\x18\x03 \x01(\x05H\x02R\x03age\x88\x01\x01\x12\x1b\n" +
+	"\x06avatar\x18\x04 \x01(\fH\x03R\x06avatar\x88\x01\x01\x124\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x17.opt.test.opaque.StatusH\x04R\x06status\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x129\n" +
+	"\x06scores\x18\a \x03(\v2!.opt.test.opaque.User.ScoresEntryR\x06scores\x127\n" +
+	"\aaddress\x18\b \x01(\v2\x1d.opt.test.opaque.User.AddressR\aaddress\x128\n" +
+	"\bnickname\x18\t \x01(\v2\x1c.google.protobuf.StringValueR\bnickname\x12\x16\n" +
+	"\x05email\x18\n" +
+	" \x01(\tH\x00R\x05email\x12\x16\n" +
+	"\x05phone\x18\v \x01(\x03H\x00R\x05phone\x123\n" +
+	"\x04mail\x18\f \x01(\v2\x1d.opt.test.opaque.User.AddressH\x00R\x04mail\x1aC\n" +
+	"\aAddress\x12\x17\n" +
+	"\x04city\x18\x01 \x01(\tH\x00R\x04city\x88\x01\x01\x12\x16\n" +
+	"\x06street\x18\x02 \x01(\tR\x06streetB\a\n" +
+	"\x05_city\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01B\t\n" +
+	"\acontactB\a\n" +
+	"\x05_nameB\x06\n" +
+	"\x04_ageB\t\n" +
+	"\a_avatarB\t\n" +
+	"\a_status\"\x17\n" +
+	"\x05Plain\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*3\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rSTATUS_ACTIVE\x10\x01B=Z;code.nkcmr.net/opt/cmd/protoc-gen-opt/internal/testpbopaqueb\x06proto3"
+
+var file_test_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_test_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_test_proto_goTypes = []any{
+	(Status)(0),                    // 0: opt.test.opaque.Status
+	(*User)(nil),                   // 1: opt.test.opaque.User
+	(*Plain)(nil),                  // 2: opt.test.opaque.Plain
+	(*User_Address)(nil),           // 3: opt.test.opaque.User.Address
+	nil,                            // 4: opt.test.opaque.User.ScoresEntry
+	(*wrapperspb.StringValue)(nil), // 5: google.protobuf.StringValue
+}
+var file_test_proto_depIdxs = []int32{
+	0, // 0: opt.test.opaque.User.status:type_name -> opt.test.opaque.Status
+	4, // 1: opt.test.opaque.User.scores:type_name -> opt.test.opaque.User.ScoresEntry
+	3, // 2: opt.test.opaque.User.address:type_name -> opt.test.opaque.User.Address
+	5, // 3: opt.test.opaque.User.nickname:type_name -> google.protobuf.StringValue
+	3, // 4: opt.test.opaque.User.mail:type_name -> opt.test.opaque.User.Address
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_test_proto_init() }
+func file_test_proto_init() {
+	if File_test_proto != nil {
+		return
+	}
+	file_test_proto_msgTypes[0].OneofWrappers = []any{
+		(*user_Email)(nil),
+		(*user_Phone)(nil),
+		(*user_Mail)(nil),
+	}
+	file_test_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_test_proto_rawDesc), len(file_test_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_test_proto_goTypes,
+		DependencyIndexes: file_test_proto_depIdxs,
+		EnumInfos:         file_test_proto_enumTypes,
+		MessageInfos:      file_test_proto_msgTypes,
+	}.Build()
+	File_test_proto = out.File
+	file_test_proto_goTypes = nil
+	file_test_proto_depIdxs = nil
+}
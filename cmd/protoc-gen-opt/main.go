@@ -0,0 +1,302 @@
+// Command protoc-gen-opt is a protoc plugin that generates opt.Option based
+// accessors for the Go types protoc-gen-go generates. It is run alongside
+// protoc-gen-go, writing its output next to it:
+//
+//	protoc --go_out=. --opt_out=. user.proto
+//
+// For each field of a message that tracks whether it is set, which includes
+// proto3 optional fields, proto2 optional fields, message fields and members
+// of a oneof, it adds a method returning the field as an Option:
+//
+//	func (x *User) OptNickname() opt.Option[string]
+//
+// Fields holding one of the well-known wrapper types, such as
+// google.protobuf.StringValue, are returned as an Option of the value they
+// wrap.
+//
+// Each such message also gets a mirror struct in which those fields are
+// Options and every other field is as its getter returns it, along with
+// methods converting between the two:
+//
+//	type UserOpt struct {
+//		ID       string
+//		Nickname opt.Option[string]
+//		Tags     []string
+//	}
+//
+//	func (x *User) ToOpt() UserOpt
+//	func (m UserOpt) ToProto() *User
+//
+// If several members of a oneof are Some in a mirror, ToProto sets the last.
+// The output is written to xxx_opt.pb.go for each xxx.proto that has messages
+// with such fields.
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+const (
+	optPackage       = protogen.GoImportPath("code.nkcmr.net/opt")
+	wrappersPackage  = protogen.GoImportPath("google.golang.org/protobuf/types/known/wrapperspb")
+	generatedComment = "// Code generated by protoc-gen-opt. DO NOT EDIT."
+)
+
+func main() {
+	protogen.Options{}.Run(func(p *protogen.Plugin) error {
+		p.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+			pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+		p.SupportedEditionsMinimum = descriptorpb.Edition_EDITION_PROTO2
+		p.SupportedEditionsMaximum = descriptorpb.Edition_EDITION_2023
+		for _, f := range p.Files {
+			if f.Generate {
+				generateFile(p, f)
+			}
+		}
+		return nil
+	})
+}
+
+// generateFile writes the _opt.pb.go file for f, unless none of its messages
+// have fields that track whether they are set.
+func generateFile(p *protogen.Plugin, f *protogen.File) *protogen.GeneratedFile {
+	msgs := optionalMessages(f.Messages)
+	if len(msgs) == 0 {
+		return nil
+	}
+	g := p.NewGeneratedFile(f.GeneratedFilenamePrefix+"_opt.pb.go", f.GoImportPath)
+	g.P(generatedComment)
+	g.P("// source: ", f.Desc.Path())
+	g.P()
+	g.P("package ", f.GoPackageName)
+	for _, m := range msgs {
+		g.P()
+		generateMessage(g, m)
+	}
+	return g
+}
+
+// optionalMessages returns the messages among msgs and the messages nested
+// in them that have fields that track whether they are set.
+func optionalMessages(msgs []*protogen.Message) []*protogen.Message {
+	var out []*protogen.Message
+	for _, m := range msgs {
+		if m.Desc.IsMapEntry() {
+			continue
+		}
+		for _, f := range m.Fields {
+			if f.Desc.HasPresence() {
+				out = append(out, m)
+				break
+			}
+		}
+		out = append(out, optionalMessages(m.Messages)...)
+	}
+	return out
+}
+
+func generateMessage(g *protogen.GeneratedFile, m *protogen.Message) {
+	open := m.APILevel == gofeaturespb.GoFeatures_API_OPEN
+	name := m.GoIdent.GoName
+	for _, f := range m.Fields {
+		if f.Desc.HasPresence() {
+			generateAccessor(g, m, f, open)
+		}
+	}
+
+	mirror := name + "Opt"
+	g.P("// ", mirror, " mirrors ", name, ", with an opt.Option in place of each field")
+	g.P("// that tracks whether it is set.")
+	g.P("type ", mirror, " struct {")
+	for _, f := range m.Fields {
+		g.P(f.GoName, " ", mirrorType(g, f))
+	}
+	g.P("}")
+	g.P()
+
+	g.P("// ToOpt returns the fields of x as a ", mirror, ".")
+	g.P("func (x *", name, ") ToOpt() ", mirror, " {")
+	g.P("return ", mirror, "{")
+	for _, f := range m.Fields {
+		if f.Desc.HasPresence() {
+			g.P(f.GoName, ": x.Opt", f.GoName, "(),")
+		} else {
+			g.P(f.GoName, ": x.", getter(f), "(),")
+		}
+	}
+	g.P("}")
+	g.P("}")
+	g.P()
+
+	g.P("// ToProto returns a new ", name, " with the fields of m.")
+	g.P("func (m ", mirror, ") ToProto() *", name, " {")
+	g.P("x := &", name, "{}")
+	for _, f := range m.Fields {
+		setter, _ := f.MethodName("Set")
+		if !f.Desc.HasPresence() {
+			if open {
+				g.P("x.", f.GoName, " = m.", f.GoName)
+			} else {
+				g.P("x.", setter, "(m.", f.GoName, ")")
+			}
+			continue
+		}
+		v := "v"
+		if w, ok := wrapperOf(f); ok {
+			v = g.QualifiedGoIdent(wrappersPackage.Ident(w.constructor)) + "(v)"
+		}
+		g.P("if v, ok := m.", f.GoName, ".MaybeUnwrap(); ok {")
+		switch {
+		case !open:
+			g.P("x.", setter, "(", v, ")")
+		case inOneof(f):
+			g.P("x.", f.Oneof.GoName, " = &", g.QualifiedGoIdent(f.GoIdent), "{", f.GoName, ": ", v, "}")
+		case isPointer(f):
+			g.P("x.", f.GoName, " = &", v)
+		default:
+			g.P("x.", f.GoName, " = ", v)
+		}
+		g.P("}")
+	}
+	g.P("return x")
+	g.P("}")
+}
+
+func generateAccessor(g *protogen.GeneratedFile, m *protogen.Message, f *protogen.Field, open bool) {
+	typ := valueType(g, f)
+	none := g.QualifiedGoIdent(optPackage.Ident("None")) + "[" + typ + "]()"
+	some := g.QualifiedGoIdent(optPackage.Ident("Some"))
+	value := "v"
+	if _, ok := wrapperOf(f); ok {
+		value = "v.GetValue()"
+	}
+
+	g.P("// Opt", f.GoName, " returns the ", f.Desc.Name(), " field of x, or None if it is not set.")
+	g.P("func (x *", m.GoIdent.GoName, ") Opt", f.GoName, "() ", g.QualifiedGoIdent(optPackage.Ident("Option")), "[", typ, "] {")
+	switch {
+	case f.Message != nil:
+		g.P("if v := x.", getter(f), "(); v != nil {")
+		g.P("return ", some, "(", value, ")")
+		g.P("}")
+	case !open:
+		has, _ := f.MethodName("Has")
+		g.P("if x.", has, "() {")
+		g.P("return ", some, "(x.", getter(f), "())")
+		g.P("}")
+	case inOneof(f):
+		g.P("if v, ok := x.Get", f.Oneof.GoName, "().(*", g.QualifiedGoIdent(f.GoIdent), "); ok {")
+		g.P("return ", some, "(v.", f.GoName, ")")
+		g.P("}")
+	case isPointer(f):
+		g.P("if x != nil && x.", f.GoName, " != nil {")
+		g.P("return ", some, "(*x.", f.GoName, ")")
+		g.P("}")
+	default:
+		g.P("if x != nil && x.", f.GoName, " != nil {")
+		g.P("return ", some, "(x.", f.GoName, ")")
+		g.P("}")
+	}
+	g.P("return ", none)
+	g.P("}")
+	g.P()
+}
+
+func getter(f *protogen.Field) string {
+	name, _ := f.MethodName("Get")
+	return name
+}
+
+// inOneof reports whether f is a member of a oneof, not counting the oneofs
+// protoc makes up for proto3 optional fields.
+func inOneof(f *protogen.Field) bool {
+	return f.Oneof != nil && !f.Oneof.Desc.IsSynthetic()
+}
+
+// isPointer reports whether protoc-gen-go's open API stores f, which tracks
+// whether it is set and is not in a oneof, as a pointer to its value.
+func isPointer(f *protogen.Field) bool {
+	return f.Message == nil && f.Desc.Kind() != protoreflect.BytesKind
+}
+
+// mirrorType returns the type of f in a mirror struct.
+func mirrorType(g *protogen.GeneratedFile, f *protogen.Field) string {
+	if f.Desc.HasPresence() {
+		return g.QualifiedGoIdent(optPackage.Ident("Option")) + "[" + valueType(g, f) + "]"
+	}
+	switch {
+	case f.Desc.IsMap():
+		return "map[" + scalarType(g, f.Message.Fields[0]) + "]" + scalarType(g, f.Message.Fields[1])
+	case f.Desc.IsList():
+		return "[]" + scalarType(g, f)
+	}
+	return scalarType(g, f)
+}
+
+// valueType returns the type of the value of f when it is set.
+func valueType(g *protogen.GeneratedFile, f *protogen.Field) string {
+	if w, ok := wrapperOf(f); ok {
+		return w.typ
+	}
+	return scalarType(g, f)
+}
+
+// scalarType returns the Go type of a single value of f.
+func scalarType(g *protogen.GeneratedFile, f *protogen.Field) string {
+	switch f.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(f.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "*" + g.QualifiedGoIdent(f.Message.GoIdent)
+	}
+	panic(fmt.Sprintf("protoc-gen-opt: unexpected kind %v of field %s", f.Desc.Kind(), f.Desc.FullName()))
+}
+
+type wrapper struct {
+	typ, constructor string
+}
+
+// wrappers are the well-known wrapper types, by full name.
+var wrappers = map[protoreflect.FullName]wrapper{
+	"google.protobuf.DoubleValue": {"float64", "Double"},
+	"google.protobuf.FloatValue":  {"float32", "Float"},
+	"google.protobuf.Int64Value":  {"int64", "Int64"},
+	"google.protobuf.UInt64Value": {"uint64", "UInt64"},
+	"google.protobuf.Int32Value":  {"int32", "Int32"},
+	"google.protobuf.UInt32Value": {"uint32", "UInt32"},
+	"google.protobuf.BoolValue":   {"bool", "Bool"},
+	"google.protobuf.StringValue": {"string", "String"},
+	"google.protobuf.BytesValue":  {"[]byte", "Bytes"},
+}
+
+// wrapperOf reports whether f holds one of the well-known wrapper types.
+func wrapperOf(f *protogen.Field) (wrapper, bool) {
+	if f.Message == nil || f.Desc.IsList() || f.Desc.IsMap() {
+		return wrapper{}, false
+	}
+	w, ok := wrappers[f.Message.Desc.FullName()]
+	return w, ok
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/gofeaturespb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// testProto returns the descriptor of internal/testpb/test.proto, as protoc
+// would pass it to a plugin, for the given proto package and Go package.
+func testProto(pkg, goPackage string) *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, opts ...func(*descriptorpb.FieldDescriptorProto)) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+		for _, o := range opts {
+			o(f)
+		}
+		return f
+	}
+	typeName := func(name string) func(*descriptorpb.FieldDescriptorProto) {
+		return func(f *descriptorpb.FieldDescriptorProto) { f.TypeName = proto.String(name) }
+	}
+	oneof := func(i int32, synthetic bool) func(*descriptorpb.FieldDescriptorProto) {
+		return func(f *descriptorpb.FieldDescriptorProto) {
+			f.OneofIndex = proto.Int32(i)
+			if synthetic {
+				f.Proto3Optional = proto.Bool(true)
+			}
+		}
+	}
+	repeated := func(f *descriptorpb.FieldDescriptorProto) {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	}
+	oneofs := func(names ...string) []*descriptorpb.OneofDescriptorProto {
+		var out []*descriptorpb.OneofDescriptorProto
+		for _, n := range names {
+			out = append(out, &descriptorpb.OneofDescriptorProto{Name: proto.String(n)})
+		}
+		return out
+	}
+	const (
+		tString  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		tInt32   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+		tInt64   = descriptorpb.FieldDescriptorProto_TYPE_INT64
+		tBytes   = descriptorpb.FieldDescriptorProto_TYPE_BYTES
+		tEnum    = descriptorpb.FieldDescriptorProto_TYPE_ENUM
+		tMessage = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+	)
+	ref := func(name string) string { return "." + pkg + "." + name }
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test.proto"),
+		Package:    proto.String(pkg),
+		Dependency: []string{"google/protobuf/wrappers.proto"},
+		Syntax:     proto.String("proto3"),
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String(goPackage)},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+				{Name: proto.String("STATUS_ACTIVE"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, tString),
+					field("name", 2, tString, oneof(1, true)),
+					field("age", 3, tInt32, oneof(2, true)),
+					field("avatar", 4, tBytes, oneof(3, true)),
+					field("status", 5, tEnum, typeName(ref("Status")), oneof(4, true)),
+					field("tags", 6, tString, repeated),
+					field("scores", 7, tMessage, typeName(ref("User.ScoresEntry")), repeated),
+					field("address", 8, tMessage, typeName(ref("User.Address"))),
+					field("nickname", 9, tMessage, typeName(".google.protobuf.StringValue")),
+					field("email", 10, tString, oneof(0, false)),
+					field("phone", 11, tInt64, oneof(0, false)),
+					field("mail", 12, tMessage, typeName(ref("User.Address")), oneof(0, false)),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("Address"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("city", 1, tString, oneof(0, true)),
+							field("street", 2, tString),
+						},
+						OneofDecl: oneofs("_city"),
+					},
+					{
+						Name: proto.String("ScoresEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("key", 1, tString),
+							field("value", 2, tInt64),
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+				},
+				OneofDecl: oneofs("contact", "_name", "_age", "_avatar", "_status"),
+			},
+			{
+				Name: proto.String("Plain"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, tString),
+				},
+			},
+		},
+	}
+}
+
+// generate runs protoc-gen-go and protoc-gen-opt on f, returning the files
+// they write by name.
+func generate(t *testing.T, f *descriptorpb.FileDescriptorProto, level gofeaturespb.GoFeatures_APILevel) map[string]string {
+	t.Helper()
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{f.GetName()},
+		Parameter:      proto.String("paths=source_relative"),
+		ProtoFile: []*descriptorpb.FileDescriptorProto{
+			protodesc.ToFileDescriptorProto(wrapperspb.File_google_protobuf_wrappers_proto),
+			f,
+		},
+	}
+	p, err := protogen.Options{DefaultAPILevel: level}.New(req)
+	require.NoError(t, err)
+	for _, f := range p.Files {
+		if f.Generate {
+			internal_gengo.GenerateFile(p, f)
+			generateFile(p, f)
+		}
+	}
+	resp := p.Response()
+	require.Empty(t, resp.GetError())
+	out := map[string]string{}
+	for _, f := range resp.File {
+		out[f.GetName()] = f.GetContent()
+	}
+	return out
+}
+
+// golden compares each of files to the file of the same name in dir,
+// updating them if the PROTOC_GEN_OPT_UPDATE environment variable is set.
+func golden(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if os.Getenv("PROTOC_GEN_OPT_UPDATE") != "" {
+			require.NoError(t, os.WriteFile(path, []byte(src), 0o644))
+		}
+		want, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, string(want), src, name)
+	}
+}
+
+func TestGenerateOpen(t *testing.T) {
+	files := generate(t, testProto("opt.test", "code.nkcmr.net/opt/cmd/protoc-gen-opt/internal/testpb"), gofeaturespb.GoFeatures_API_OPEN)
+	require.Contains(t, files, "test_opt.pb.go")
+	golden(t, "internal/testpb", files)
+}
+
+func TestGenerateOpaque(t *testing.T) {
+	files := generate(t, testProto("opt.test.opaque", "code.nkcmr.net/opt/cmd/protoc-gen-opt/internal/testpbopaque"), gofeaturespb.GoFeatures_API_OPAQUE)
+	require.Contains(t, files, "test_opt.pb.go")
+	golden(t, "internal/testpbopaque", files)
+}
+
+func TestGenerateNothing(t *testing.T) {
+	f := testProto("opt.test", "example.com/plain")
+	f.MessageType = f.MessageType[1:]
+	files := generate(t, f, gofeaturespb.GoFeatures_API_OPEN)
+	require.NotContains(t, files, "test_opt.pb.go")
+}
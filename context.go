@@ -0,0 +1,141 @@
+package opt
+
+import (
+	"context"
+	"time"
+)
+
+// CtxValue returns the value ctx holds for key if it is a T, and None if ctx
+// holds no value for key or the value is of another type.
+func CtxValue[T any](ctx context.Context, key any) Option[T] {
+	v, ok := ctx.Value(key).(T)
+	return FromMaybe(v, ok)
+}
+
+// WithValue returns a copy of ctx that holds v for key. It is
+// context.WithValue with the type of the value spelled out, so that it can be
+// matched with the CtxValue call that reads it:
+//
+//	ctx = opt.WithValue[Claims](ctx, claimsKey{}, claims)
+//	// ...
+//	claims := opt.CtxValue[Claims](ctx, claimsKey{})
+//
+// If T is an interface type, a nil v is read back as None.
+func WithValue[T any](ctx context.Context, key any, v T) context.Context {
+	return context.WithValue(ctx, key, v)
+}
+
+// Race calls each of fns in its own goroutine and returns the first Some
+// that any of them returns, canceling the context passed to the others. It
+// returns None if they all return None, or if ctx is done first. Race does
+// not wait for the functions it cancels to return.
+func Race[T any](ctx context.Context, fns ...func(context.Context) Option[T]) Option[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan Option[T], len(fns))
+	for _, fn := range fns {
+		go func(fn func(context.Context) Option[T]) {
+			results <- fn(ctx)
+		}(fn)
+	}
+	for range fns {
+		select {
+		case o := <-results:
+			if o.ok {
+				return o
+			}
+		case <-ctx.Done():
+			return None[T]()
+		}
+	}
+	return None[T]()
+}
+
+// Backoff decides how long RetryUntilSome waits after the given number of
+// attempts have returned None, starting at 1. A negative duration stops it
+// from trying again.
+type Backoff func(attempts int) time.Duration
+
+// ConstantBackoff returns a Backoff that always waits for d.
+func ConstantBackoff(d time.Duration) Backoff {
+	return func(int) time.Duration { return d }
+}
+
+// ExponentialBackoff returns a Backoff that waits for initial after the first
+// attempt, and twice as long after each attempt after that, up to limit.
+func ExponentialBackoff(initial, limit time.Duration) Backoff {
+	return func(attempts int) time.Duration {
+		d := initial
+		for i := 1; i < attempts && d < limit; i++ {
+			d *= 2
+		}
+		return min(d, limit)
+	}
+}
+
+// MaxAttempts returns a Backoff that waits as b does, but stops after the
+// given number of attempts.
+func (b Backoff) MaxAttempts(n int) Backoff {
+	return func(attempts int) time.Duration {
+		if attempts >= n {
+			return -1
+		}
+		return b(attempts)
+	}
+}
+
+// RetryUntilSome calls fn until it returns Some, waiting between attempts as
+// policy says, and returns what it returned. It returns None if policy stops
+// it, or if ctx is done before fn returns Some.
+func RetryUntilSome[T any](ctx context.Context, policy Backoff, fn func(context.Context) Option[T]) Option[T] {
+	for attempts := 1; ctx.Err() == nil; attempts++ {
+		if o := fn(ctx); o.ok {
+			return o
+		}
+		wait := policy(attempts)
+		if wait < 0 {
+			break
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+	return None[T]()
+}
+
+// WithTimeout calls fn with a context that is canceled after d and returns
+// Some of its result, or None if fn returns an error or is still running when
+// d passes or ctx is done. It suits best-effort work whose result can be done
+// without:
+//
+//	avatar := opt.WithTimeout(ctx, 50*time.Millisecond, func(ctx context.Context) (string, error) {
+//		return profiles.Avatar(ctx, userID)
+//	})
+//
+// Like Race, WithTimeout does not wait for fn to return once it gives up on
+// it.
+func WithTimeout[T any](ctx context.Context, d time.Duration, fn func(context.Context) (T, error)) Option[T] {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn(ctx)
+		done <- result{v, err}
+	}()
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return None[T]()
+		}
+		return Some(r.v)
+	case <-ctx.Done():
+		return None[T]()
+	}
+}
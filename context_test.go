@@ -0,0 +1,145 @@
+package opt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey string
+
+func TestCtxValue(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, None[string](), CtxValue[string](ctx, ctxKey("request-id")))
+
+	ctx = WithValue(ctx, ctxKey("request-id"), "abc")
+	ctx = WithValue(ctx, ctxKey("count"), 3)
+	require.Equal(t, Some("abc"), CtxValue[string](ctx, ctxKey("request-id")))
+	require.Equal(t, Some(3), CtxValue[int](ctx, ctxKey("count")))
+	require.Equal(t, None[int64](), CtxValue[int64](ctx, ctxKey("count")))
+	require.Equal(t, None[string](), CtxValue[string](ctx, "request-id"))
+
+	err := errors.New("boom")
+	ctx = WithValue[error](ctx, ctxKey("err"), err)
+	require.Equal(t, Some(err), CtxValue[error](ctx, ctxKey("err")))
+	ctx = WithValue[error](ctx, ctxKey("err"), nil)
+	require.Equal(t, None[error](), CtxValue[error](ctx, ctxKey("err")))
+}
+
+func TestRace(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, None[int](), Race[int](ctx))
+
+	none := func(context.Context) Option[int] { return None[int]() }
+	require.Equal(t, None[int](), Race(ctx, none, none))
+
+	canceled := make(chan struct{})
+	slow := func(ctx context.Context) Option[int] {
+		<-ctx.Done()
+		close(canceled)
+		return Some(2)
+	}
+	fast := func(context.Context) Option[int] { return Some(1) }
+	require.Equal(t, Some(1), Race(ctx, slow, none, fast))
+	<-canceled
+
+	release := make(chan struct{})
+	defer close(release)
+	blocked := func(context.Context) Option[int] {
+		<-release
+		return Some(3)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.Equal(t, None[int](), Race(ctx, blocked))
+}
+
+func TestBackoff(t *testing.T) {
+	constant := ConstantBackoff(time.Second)
+	require.Equal(t, time.Second, constant(1))
+	require.Equal(t, time.Second, constant(10))
+
+	exp := ExponentialBackoff(time.Second, 10*time.Second)
+	var waits []time.Duration
+	for i := 1; i <= 6; i++ {
+		waits = append(waits, exp(i))
+	}
+	require.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second,
+	}, waits)
+	require.Equal(t, 10*time.Second, exp(1000))
+
+	limited := constant.MaxAttempts(3)
+	require.Equal(t, time.Second, limited(2))
+	require.Negative(t, limited(3))
+}
+
+func TestRetryUntilSome(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	o := RetryUntilSome(ctx, ConstantBackoff(time.Millisecond), func(context.Context) Option[string] {
+		calls++
+		return FromMaybe("ready", calls == 3)
+	})
+	require.Equal(t, Some("ready"), o)
+	require.Equal(t, 3, calls)
+
+	calls = 0
+	never := func(context.Context) Option[string] {
+		calls++
+		return None[string]()
+	}
+	o = RetryUntilSome(ctx, ConstantBackoff(0).MaxAttempts(4), never)
+	require.Equal(t, None[string](), o)
+	require.Equal(t, 4, calls)
+
+	calls = 0
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	o = RetryUntilSome(ctx, ConstantBackoff(time.Hour), never)
+	require.Equal(t, None[string](), o)
+	require.Equal(t, 1, calls)
+
+	calls = 0
+	o = RetryUntilSome(ctx, ConstantBackoff(0), never)
+	require.Equal(t, None[string](), o)
+	require.Equal(t, 0, calls)
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	o := WithTimeout(ctx, time.Second, func(context.Context) (int, error) { return 1, nil })
+	require.Equal(t, Some(1), o)
+
+	o = WithTimeout(ctx, time.Second, func(context.Context) (int, error) { return 1, errors.New("boom") })
+	require.True(t, o.None())
+
+	o = WithTimeout(ctx, time.Millisecond, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	require.True(t, o.None())
+
+	// fn ignoring its context does not hold WithTimeout up.
+	release := make(chan struct{})
+	defer close(release)
+	start := time.Now()
+	o = WithTimeout(ctx, 10*time.Millisecond, func(context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	require.True(t, o.None())
+	require.Less(t, time.Since(start), time.Second)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	o = WithTimeout(canceled, time.Second, func(context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	require.True(t, o.None())
+}
@@ -0,0 +1,43 @@
+package opt
+
+// csvMarshaler is the TypeMarshaller interface of github.com/gocarina/gocsv.
+type csvMarshaler interface {
+	MarshalCSV() (string, error)
+}
+
+// csvUnmarshaler is the TypeUnmarshaller interface of
+// github.com/gocarina/gocsv.
+type csvUnmarshaler interface {
+	UnmarshalCSV(string) error
+}
+
+// MarshalCSV implements gocsv.TypeMarshaller. A None value is written as an
+// empty cell.
+func (o Option[T]) MarshalCSV() (string, error) {
+	if !o.ok {
+		return "", nil
+	}
+	if m, ok := any(o.v).(csvMarshaler); ok {
+		return m.MarshalCSV()
+	}
+	return formatText(o.v)
+}
+
+// UnmarshalCSV implements gocsv.TypeUnmarshaller. An empty cell is decoded as
+// None, anything else is parsed into a Some.
+func (o *Option[T]) UnmarshalCSV(s string) error {
+	var v T
+	if s == "" {
+		*o = None[T]()
+		return nil
+	}
+	if u, ok := any(&v).(csvUnmarshaler); ok {
+		if err := u.UnmarshalCSV(s); err != nil {
+			return err
+		}
+	} else if err := parseText(&v, s); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
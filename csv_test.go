@@ -0,0 +1,45 @@
+package opt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	type Row struct {
+		Name  string            `csv:"name"`
+		Age   Option[int]       `csv:"age"`
+		Score Option[float64]   `csv:"score"`
+		Seen  Option[time.Time] `csv:"seen"`
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		rows := []Row{
+			{Name: "a"},
+			{Name: "b", Age: Some(0), Score: Some(1.5), Seen: Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))},
+		}
+		out, err := gocsv.MarshalString(rows)
+		require.NoError(t, err)
+		require.Equal(t, "name,age,score,seen\na,,,\nb,0,1.5,2024-03-01T00:00:00Z\n", out)
+	})
+	t.Run("decode", func(t *testing.T) {
+		var rows []Row
+		err := gocsv.UnmarshalString("name,age,score,seen\na,,,\nb,0,1.5,2024-03-01T00:00:00Z\n", &rows)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		require.True(t, rows[0].Age.None())
+		require.True(t, rows[0].Score.None())
+		require.True(t, rows[0].Seen.None())
+		require.Equal(t, int(0), rows[1].Age.Unwrap())
+		require.Equal(t, float64(1.5), rows[1].Score.Unwrap())
+		require.True(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Equal(rows[1].Seen.Unwrap()))
+	})
+	t.Run("decode invalid", func(t *testing.T) {
+		var rows []Row
+		err := gocsv.UnmarshalString("name,age\na,nope\n", &rows)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,69 @@
+package opt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type echoRequest struct {
+	ID     Option[int64]  `param:"id"`
+	Cursor Option[string] `query:"cursor" form:"cursor" json:"cursor"`
+	Limit  Option[int]    `query:"limit" form:"limit" json:"limit"`
+	Tag    Option[string] `query:"tag" form:"tag" json:"tag"`
+	Token  Option[string] `header:"X-Token"`
+	// Untagged Options are left alone, not bound to as if they were structs
+	// of their own.
+	Untagged Option[string]
+}
+
+func echoContext(r *http.Request, id string) echo.Context {
+	c := echo.New().NewContext(r, httptest.NewRecorder())
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	return c
+}
+
+func TestEchoBindQuery(t *testing.T) {
+	c := echoContext(httptest.NewRequest(http.MethodGet, "/items/7?cursor=abc&tag=", nil), "7")
+	var req echoRequest
+	require.NoError(t, c.Bind(&req))
+	require.Equal(t, Some[int64](7), req.ID)
+	require.Equal(t, Some("abc"), req.Cursor)
+	require.Equal(t, None[int](), req.Limit)
+	require.Equal(t, None[string](), req.Tag)
+	require.Equal(t, None[string](), req.Untagged)
+
+	c = echoContext(httptest.NewRequest(http.MethodGet, "/items/x", nil), "x")
+	require.Error(t, c.Bind(&echoRequest{}))
+}
+
+func TestEchoBindForm(t *testing.T) {
+	body := url.Values{"cursor": {"abc"}, "limit": {"10"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/items/7", strings.NewReader(body))
+	r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	r.Header.Set("X-Token", "secret")
+	c := echoContext(r, "7")
+	var req echoRequest
+	require.NoError(t, c.Bind(&req))
+	require.NoError(t, (&echo.DefaultBinder{}).BindHeaders(c, &req))
+	require.Equal(t, Some("abc"), req.Cursor)
+	require.Equal(t, Some(10), req.Limit)
+	require.Equal(t, None[string](), req.Tag)
+	require.Equal(t, Some("secret"), req.Token)
+}
+
+func TestEchoBindJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/items/7", strings.NewReader(`{"cursor":"abc","tag":null}`))
+	r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	var req echoRequest
+	require.NoError(t, echoContext(r, "7").Bind(&req))
+	require.Equal(t, Some[int64](7), req.ID)
+	require.Equal(t, Some("abc"), req.Cursor)
+	require.Equal(t, None[string](), req.Tag)
+}
@@ -0,0 +1,34 @@
+package opt
+
+// FromError returns Some(err) if err is not nil, and None otherwise. It lets
+// results record whether an error occurred without relying on nil:
+//
+//	type JobResult struct {
+//		Output string
+//		Err    opt.Option[error]
+//	}
+//
+//	res.Err = opt.FromError(run(ctx))
+func FromError(err error) Option[error] {
+	if err == nil {
+		return None[error]()
+	}
+	return Some(err)
+}
+
+// AsError is the inverse of FromError: it returns the error o contains, or nil
+// if o is None.
+func AsError(o Option[error]) error {
+	return o.UnwrapOrZero()
+}
+
+// ErrOr returns the first error contained in one of os, or nil if every one of
+// them is None. A Some holding a nil error is skipped like a None.
+func ErrOr(os ...Option[error]) error {
+	for _, o := range os {
+		if err := AsError(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package opt
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromError(t *testing.T) {
+	errBoom := errors.New("boom")
+	require.Equal(t, None[error](), FromError(nil))
+	require.Equal(t, Some(errBoom), FromError(errBoom))
+	require.NoError(t, AsError(FromError(nil)))
+	require.ErrorIs(t, AsError(FromError(errBoom)), errBoom)
+	require.NoError(t, AsError(Some[error](nil)))
+}
+
+func TestErrOr(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	require.NoError(t, ErrOr())
+	require.NoError(t, ErrOr(None[error](), Some[error](nil)))
+	require.ErrorIs(t, ErrOr(None[error](), Some[error](nil), Some(errA), Some(errB)), errA)
+	require.ErrorIs(t, ErrOr(FromError(nil), FromError(errB)), errB)
+}
@@ -0,0 +1,94 @@
+package opt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils/tests"
+)
+
+type gormUser struct {
+	ID     uint
+	Name   Option[string]
+	Age    Option[int32]
+	Score  Option[float64]
+	Active Option[bool]
+	Avatar Option[[]byte]
+	SeenAt Option[time.Time]
+}
+
+func dryRunDB(t testing.TB) *gorm.DB {
+	db, err := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	require.NoError(t, err)
+	return db
+}
+
+func explain(stmt *gorm.Statement) string {
+	return stmt.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+}
+
+func TestGORM(t *testing.T) {
+	t.Run("schema", func(t *testing.T) {
+		s, err := schema.Parse(&gormUser{}, &sync.Map{}, schema.NamingStrategy{})
+		require.NoError(t, err)
+		for name, want := range map[string]struct {
+			dataType schema.DataType
+			size     int
+		}{
+			"Name":   {schema.String, 0},
+			"Age":    {schema.Int, 32},
+			"Score":  {schema.Float, 64},
+			"Active": {schema.Bool, 0},
+			"Avatar": {schema.Bytes, 0},
+			"SeenAt": {schema.Time, 0},
+		} {
+			field := s.LookUpField(name)
+			require.NotNil(t, field, name)
+			require.Equal(t, want.dataType, field.DataType, name)
+			require.Equal(t, want.size, field.Size, name)
+		}
+	})
+	t.Run("create", func(t *testing.T) {
+		stmt := dryRunDB(t).Create(&gormUser{ID: 1, Name: Some("nk"), Age: Some(int32(30))}).Statement
+		require.Equal(t,
+			"INSERT INTO `gorm_users` (`name`,`age`,`score`,`active`,`avatar`,`seen_at`,`id`) VALUES (\"nk\",30,NULL,NULL,NULL,NULL,1) RETURNING `id`",
+			explain(stmt),
+		)
+	})
+	t.Run("updates only Some fields", func(t *testing.T) {
+		stmt := dryRunDB(t).Model(&gormUser{ID: 1}).Updates(gormUser{Name: Some("nk"), Active: Some(false)}).Statement
+		require.Equal(t, "UPDATE `gorm_users` SET `name`=\"nk\",`active`=false WHERE `id` = 1", explain(stmt))
+	})
+	t.Run("scan", func(t *testing.T) {
+		s, err := schema.Parse(&gormUser{}, &sync.Map{}, schema.NamingStrategy{})
+		require.NoError(t, err)
+		u := gormUser{Name: Some("nk")}
+		rv := reflect.ValueOf(&u).Elem()
+		ctx := context.Background()
+		require.NoError(t, s.LookUpField("Name").Set(ctx, rv, nil))
+		require.NoError(t, s.LookUpField("Age").Set(ctx, rv, int64(30)))
+		require.Equal(t, gormUser{Age: Some(int32(30))}, u)
+	})
+}
+
+// Option fields map to nullable columns of the contained type, and because
+// None is a zero value, Updates with a struct only sets the fields that are
+// Some.
+func Example_gorm() {
+	type User struct {
+		ID    uint
+		Name  Option[string]
+		Email Option[string]
+	}
+	db, _ := gorm.Open(tests.DummyDialector{}, &gorm.Config{DryRun: true})
+	stmt := db.Model(&User{ID: 1}).Updates(User{Email: Some("nk@example.com")}).Statement
+	fmt.Println(explain(stmt))
+	// Output: UPDATE `users` SET `email`="nk@example.com" WHERE `id` = 1
+}
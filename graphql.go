@@ -0,0 +1,134 @@
+package opt
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// graphqlUnmarshaler is the Unmarshaler interface of
+// github.com/graph-gophers/graphql-go.
+type graphqlUnmarshaler interface {
+	ImplementsGraphQLType(name string) bool
+	UnmarshalGraphQL(input any) error
+}
+
+// ImplementsGraphQLType implements decode.Unmarshaler from
+// github.com/graph-gophers/graphql-go. An Option implements the same scalar
+// as T: Int for integers, Float for floats, String or ID for strings, Boolean
+// for bools, or whatever T reports if it is a custom scalar itself.
+//
+// graphql-go requires resolvers of nullable fields to return a pointer, so
+// results are returned as *Option[T], where both nil and None stand for null.
+func (o Option[T]) ImplementsGraphQLType(name string) bool {
+	var v T
+	if u, ok := any(&v).(graphqlUnmarshaler); ok {
+		return u.ImplementsGraphQLType(name)
+	}
+	switch reflect.TypeOf(&v).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return name == "Int"
+	case reflect.Float32, reflect.Float64:
+		return name == "Float"
+	case reflect.String:
+		return name == "String" || name == "ID"
+	case reflect.Bool:
+		return name == "Boolean"
+	}
+	return false
+}
+
+// UnmarshalGraphQL implements decode.Unmarshaler from
+// github.com/graph-gophers/graphql-go. null is decoded as None. Arguments
+// that are left out are never decoded, so the Option is left as None.
+func (o *Option[T]) UnmarshalGraphQL(input any) error {
+	var v T
+	if input == nil {
+		*o = None[T]()
+		return nil
+	}
+	if u, ok := any(&v).(graphqlUnmarshaler); ok {
+		if err := u.UnmarshalGraphQL(input); err != nil {
+			return err
+		}
+	} else if err := decodeGraphQL(reflect.ValueOf(&v).Elem(), input); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// Nullable marks Option as able to decode null, which lets graphql-go accept
+// an Option for a nullable argument without it having to be a pointer.
+func (o *Option[T]) Nullable() {}
+
+// decodeGraphQL stores a scalar input value, as produced by graphql-go, into
+// rv.
+func decodeGraphQL(rv reflect.Value, input any) error {
+	in := reflect.ValueOf(input)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = in.Int()
+		case reflect.Float32, reflect.Float64:
+			f := in.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("opt: GraphQL value %v is not an integer", f)
+			}
+			i = int64(f)
+		default:
+			return fmt.Errorf("opt: cannot decode GraphQL value of type %T into %s", input, rv.Type())
+		}
+		if rv.OverflowInt(i) {
+			return fmt.Errorf("opt: GraphQL integer %d overflows %s", i, rv.Type())
+		}
+		rv.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var i int64
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = in.Int()
+		case reflect.Float32, reflect.Float64:
+			f := in.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxInt64 {
+				return fmt.Errorf("opt: GraphQL value %v is not an integer", f)
+			}
+			i = int64(f)
+		default:
+			return fmt.Errorf("opt: cannot decode GraphQL value of type %T into %s", input, rv.Type())
+		}
+		if i < 0 || rv.OverflowUint(uint64(i)) {
+			return fmt.Errorf("opt: GraphQL integer %d overflows %s", i, rv.Type())
+		}
+		rv.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			rv.SetFloat(float64(in.Int()))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(in.Float())
+			return nil
+		}
+	case reflect.String:
+		if in.Kind() == reflect.String {
+			rv.SetString(in.String())
+			return nil
+		}
+	case reflect.Bool:
+		if in.Kind() == reflect.Bool {
+			rv.SetBool(in.Bool())
+			return nil
+		}
+	}
+	if in.Type().AssignableTo(rv.Type()) {
+		rv.Set(in)
+		return nil
+	}
+	return fmt.Errorf("opt: cannot decode GraphQL value of type %T into %s", input, rv.Type())
+}
@@ -0,0 +1,87 @@
+package opt
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/require"
+)
+
+type graphqlResolver struct{}
+
+func (*graphqlResolver) Greet(args struct {
+	Name  Option[string]
+	Times Option[int]
+	ID    Option[graphql.ID]
+}) *Option[string] {
+	name, ok := args.Name.MaybeUnwrap()
+	if !ok {
+		return &Option[string]{}
+	}
+	greeting := Some(fmt.Sprintf("hello %s x%d (%s)", name, args.Times.UnwrapOr(1), args.ID.UnwrapOr("none")))
+	return &greeting
+}
+
+func TestGraphQL(t *testing.T) {
+	schema := graphql.MustParseSchema(`
+		type Query {
+			greet(name: String, times: Int, id: ID): String
+		}
+	`, &graphqlResolver{})
+
+	for query, want := range map[string]string{
+		`{ greet }`:                                `{"greet":null}`,
+		`{ greet(name: null) }`:                    `{"greet":null}`,
+		`{ greet(name: "nk") }`:                    `{"greet":"hello nk x1 (none)"}`,
+		`{ greet(name: "nk", times: 3, id: "7") }`: `{"greet":"hello nk x3 (7)"}`,
+	} {
+		resp := schema.Exec(context.Background(), query, "", nil)
+		require.Empty(t, resp.Errors, query)
+		require.JSONEq(t, want, string(resp.Data), query)
+	}
+
+	resp := schema.Exec(context.Background(), `query($times: Int) { greet(name: "nk", times: $times) }`, "", map[string]any{
+		"times": float64(2),
+	})
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"greet":"hello nk x2 (none)"}`, string(resp.Data))
+
+	_, err := graphql.ParseSchema(`
+		type Query {
+			greet(name: Boolean): String
+		}
+	`, &graphqlResolver{})
+	require.Error(t, err, "Option[string] is not a Boolean")
+}
+
+func TestUnmarshalGraphQL(t *testing.T) {
+	var i Option[int8]
+	require.NoError(t, i.UnmarshalGraphQL(int32(5)))
+	require.Equal(t, Some(int8(5)), i)
+	require.NoError(t, i.UnmarshalGraphQL(float64(6)))
+	require.Equal(t, Some(int8(6)), i)
+	require.Error(t, i.UnmarshalGraphQL(int32(500)))
+	require.Error(t, i.UnmarshalGraphQL(1.5))
+	require.Error(t, i.UnmarshalGraphQL("5"))
+	require.NoError(t, i.UnmarshalGraphQL(nil))
+	require.True(t, i.None())
+
+	var u Option[uint]
+	require.Error(t, u.UnmarshalGraphQL(int32(-1)))
+
+	var f Option[float32]
+	require.NoError(t, f.UnmarshalGraphQL(int32(2)))
+	require.Equal(t, Some(float32(2)), f)
+
+	var id Option[graphql.ID]
+	require.True(t, id.ImplementsGraphQLType("ID"))
+	require.NoError(t, id.UnmarshalGraphQL("x"))
+	require.Equal(t, Some(graphql.ID("x")), id)
+
+	var at Option[graphql.Time]
+	require.True(t, at.ImplementsGraphQLType("Time"))
+	require.NoError(t, at.UnmarshalGraphQL("2024-03-01T00:00:00Z"))
+	require.Equal(t, 2024, at.Unwrap().Year())
+}
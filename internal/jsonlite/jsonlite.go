@@ -0,0 +1,386 @@
+// Package jsonlite encodes and decodes JSON strings, booleans and numbers
+// without reflection, the same way encoding/json does.
+package jsonlite
+
+import (
+	"math"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Append appends the JSON encoding of v to dst if v is a string, boolean,
+// integer or finite floating-point number, and reports whether it did.
+func Append(dst []byte, v any) ([]byte, bool) {
+	switch v := v.(type) {
+	case string:
+		return AppendString(dst, v), true
+	case bool:
+		return strconv.AppendBool(dst, v), true
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10), true
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10), true
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10), true
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10), true
+	case int64:
+		return strconv.AppendInt(dst, v, 10), true
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10), true
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10), true
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10), true
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10), true
+	case uint64:
+		return strconv.AppendUint(dst, v, 10), true
+	case float32:
+		if !math.IsInf(float64(v), 0) && !math.IsNaN(float64(v)) {
+			return AppendFloat(dst, float64(v), 32), true
+		}
+	case float64:
+		if !math.IsInf(v, 0) && !math.IsNaN(v) {
+			return AppendFloat(dst, v, 64), true
+		}
+	}
+	return dst, false
+}
+
+// AppendFloat appends f the way encoding/json does, which uses exponential
+// notation only for very large and very small numbers.
+func AppendFloat(dst []byte, f float64, bits int) []byte {
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// Shorten e-09 to e-9.
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst
+}
+
+const hexDigits = "0123456789abcdef"
+
+// AppendString appends s as a JSON string the way encoding/json does,
+// escaping HTML characters, U+2028 and U+2029, and replacing invalid UTF-8
+// with U+FFFD.
+func AppendString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// Unmarshal decodes data into p if p points to a string, boolean, integer or
+// floating-point number, and data is a value of that type that encoding/json
+// would decode the same way. It reports whether it did. Strings with escape
+// sequences, control characters or invalid UTF-8 are only decoded if
+// unescape is true.
+func Unmarshal(data []byte, p any, unescape bool) bool {
+	switch p := p.(type) {
+	case *string:
+		s, ok := ParseString(data)
+		if !ok && unescape {
+			s, ok = Unquote(data)
+		}
+		if ok {
+			*p = s
+		}
+		return ok
+	case *bool:
+		switch string(data) {
+		case "true":
+			*p = true
+			return true
+		case "false":
+			*p = false
+			return true
+		}
+		return false
+	case *int:
+		return parseInt(data, strconv.IntSize, p)
+	case *int8:
+		return parseInt(data, 8, p)
+	case *int16:
+		return parseInt(data, 16, p)
+	case *int32:
+		return parseInt(data, 32, p)
+	case *int64:
+		return parseInt(data, 64, p)
+	case *uint:
+		return parseUint(data, strconv.IntSize, p)
+	case *uint8:
+		return parseUint(data, 8, p)
+	case *uint16:
+		return parseUint(data, 16, p)
+	case *uint32:
+		return parseUint(data, 32, p)
+	case *uint64:
+		return parseUint(data, 64, p)
+	case *float32:
+		if !isNumber(data) {
+			return false
+		}
+		f, err := strconv.ParseFloat(string(data), 32)
+		if err != nil {
+			return false
+		}
+		*p = float32(f)
+		return true
+	case *float64:
+		if !isNumber(data) {
+			return false
+		}
+		f, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return false
+		}
+		*p = f
+		return true
+	}
+	return false
+}
+
+// ParseString returns the JSON string data holds if it has no escape
+// sequences, control characters or invalid UTF-8.
+func ParseString(data []byte) (string, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", false
+	}
+	body := data[1 : len(data)-1]
+	for _, b := range body {
+		if b < 0x20 || b == '"' || b == '\\' {
+			return "", false
+		}
+	}
+	if !utf8.Valid(body) {
+		return "", false
+	}
+	return string(body), true
+}
+
+// Unquote returns the JSON string data holds, decoding escape sequences and
+// replacing invalid UTF-8 and unpaired surrogates with U+FFFD, as
+// encoding/json does.
+func Unquote(data []byte) (string, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return "", false
+	}
+	body := data[1 : len(data)-1]
+	out := make([]byte, 0, len(body))
+	for i := 0; i < len(body); {
+		switch b := body[i]; {
+		case b == '\\':
+			i++
+			if i == len(body) {
+				return "", false
+			}
+			switch body[i] {
+			case '"', '\\', '/':
+				out = append(out, body[i])
+			case 'b':
+				out = append(out, '\b')
+			case 'f':
+				out = append(out, '\f')
+			case 'n':
+				out = append(out, '\n')
+			case 'r':
+				out = append(out, '\r')
+			case 't':
+				out = append(out, '\t')
+			case 'u':
+				r, ok := parseHex4(body[i+1:])
+				if !ok {
+					return "", false
+				}
+				i += 4
+				if utf16.IsSurrogate(r) {
+					// A surrogate is only valid as the first half of a pair.
+					r2, ok := rune(0), false
+					if i+2 < len(body) && body[i+1] == '\\' && body[i+2] == 'u' {
+						r2, ok = parseHex4(body[i+3:])
+					}
+					if dec := utf16.DecodeRune(r, r2); ok && dec != utf8.RuneError {
+						r = dec
+						i += 6
+					} else {
+						r = utf8.RuneError
+					}
+				}
+				out = utf8.AppendRune(out, r)
+			default:
+				return "", false
+			}
+			i++
+		case b == '"', b < 0x20:
+			return "", false
+		case b < utf8.RuneSelf:
+			out = append(out, b)
+			i++
+		default:
+			r, size := utf8.DecodeRune(body[i:])
+			out = utf8.AppendRune(out, r)
+			i += size
+		}
+	}
+	return string(out), true
+}
+
+// parseHex4 parses the four hexadecimal digits at the start of data.
+func parseHex4(data []byte) (rune, bool) {
+	if len(data) < 4 {
+		return 0, false
+	}
+	var r rune
+	for _, b := range data[:4] {
+		switch {
+		case '0' <= b && b <= '9':
+			b -= '0'
+		case 'a' <= b && b <= 'f':
+			b -= 'a' - 10
+		case 'A' <= b && b <= 'F':
+			b -= 'A' - 10
+		default:
+			return 0, false
+		}
+		r = r<<4 | rune(b)
+	}
+	return r, true
+}
+
+func parseInt[N int | int8 | int16 | int32 | int64](data []byte, bits int, p *N) bool {
+	if !isInteger(data) {
+		return false
+	}
+	n, err := strconv.ParseInt(string(data), 10, bits)
+	if err != nil {
+		return false
+	}
+	*p = N(n)
+	return true
+}
+
+func parseUint[N uint | uint8 | uint16 | uint32 | uint64](data []byte, bits int, p *N) bool {
+	if !isInteger(data) || data[0] == '-' {
+		return false
+	}
+	n, err := strconv.ParseUint(string(data), 10, bits)
+	if err != nil {
+		return false
+	}
+	*p = N(n)
+	return true
+}
+
+// isInteger reports whether data is a JSON number without a fraction or
+// exponent.
+func isInteger(data []byte) bool {
+	n, ok := scanInteger(data)
+	return ok && n == len(data)
+}
+
+// isNumber reports whether data is a JSON number.
+func isNumber(data []byte) bool {
+	i, ok := scanInteger(data)
+	if !ok {
+		return false
+	}
+	if i < len(data) && data[i] == '.' {
+		i++
+		n := scanDigits(data[i:])
+		if n == 0 {
+			return false
+		}
+		i += n
+	}
+	if i < len(data) && (data[i] == 'e' || data[i] == 'E') {
+		i++
+		if i < len(data) && (data[i] == '+' || data[i] == '-') {
+			i++
+		}
+		n := scanDigits(data[i:])
+		if n == 0 {
+			return false
+		}
+		i += n
+	}
+	return i == len(data)
+}
+
+// scanInteger scans the optional minus sign and the integer part of a JSON
+// number at the start of data, returning its length.
+func scanInteger(data []byte) (int, bool) {
+	i := 0
+	if i < len(data) && data[i] == '-' {
+		i++
+	}
+	n := scanDigits(data[i:])
+	if n == 0 || n > 1 && data[i] == '0' {
+		return 0, false
+	}
+	return i + n, true
+}
+
+func scanDigits(data []byte) int {
+	n := 0
+	for n < len(data) && '0' <= data[n] && data[n] <= '9' {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,89 @@
+// Package optreflect gives package opt and its subpackages access to Option
+// values whose type parameter is only known at run time.
+//
+// It works through the methods of opt.AnyOption and opt.AnyOptionSetter, so
+// it also handles Boxed and PtrOption. It cannot import opt to name those
+// interfaces, as opt itself uses it, so it declares their methods again.
+package optreflect
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type anyOption interface {
+	IsSome() bool
+	UnwrapAny() any
+}
+
+type anyOptionSetter interface {
+	anyOption
+	SetAny(v any) error
+}
+
+var (
+	anyOptionType       = reflect.TypeFor[anyOption]()
+	anyOptionSetterType = reflect.TypeFor[anyOptionSetter]()
+
+	elems sync.Map // map[reflect.Type]reflect.Type, nil if not an Option
+)
+
+// Elem reports whether t is an Option type and, if so, the type it contains.
+// An Option type is a struct that implements AnyOption, whose pointer
+// implements AnyOptionSetter, and whose Unwrap method returns the contained
+// type.
+func Elem(t reflect.Type) (reflect.Type, bool) {
+	if e, ok := elems.Load(t); ok {
+		e, _ := e.(reflect.Type)
+		return e, e != nil
+	}
+	e := elem(t)
+	elems.Store(t, e)
+	return e, e != nil
+}
+
+func elem(t reflect.Type) reflect.Type {
+	if t.Kind() != reflect.Struct || !t.Implements(anyOptionType) || !reflect.PointerTo(t).Implements(anyOptionSetterType) {
+		return nil
+	}
+	m, ok := t.MethodByName("Unwrap")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 {
+		return nil
+	}
+	return m.Type.Out(0)
+}
+
+// Is reports whether t is an Option type.
+func Is(t reflect.Type) bool {
+	_, ok := Elem(t)
+	return ok
+}
+
+// Get returns a copy of the value contained by the Option v and whether it is
+// Some. If it is None, the value is the zero value of the contained type.
+func Get(v reflect.Value) (reflect.Value, bool) {
+	e, _ := Elem(v.Type())
+	x := reflect.New(e).Elem()
+	o := v.Interface().(anyOption)
+	if !o.IsSome() {
+		return x, false
+	}
+	if u := o.UnwrapAny(); u != nil {
+		x.Set(reflect.ValueOf(u))
+	}
+	return x, true
+}
+
+// Set replaces the Option pointed to by ptr with Some(x), or with None if x
+// is the zero reflect.Value. x must be assignable to the contained type. A
+// nil x of an interface type also sets None, as SetAny does.
+func Set(ptr reflect.Value, x reflect.Value) {
+	var v any
+	if x.IsValid() {
+		v = x.Interface()
+	}
+	if err := ptr.Interface().(anyOptionSetter).SetAny(v); err != nil {
+		panic(fmt.Sprintf("optreflect: %v", err))
+	}
+}
@@ -0,0 +1,298 @@
+// Package shadow mirrors types that contain opt.Option with types built at run
+// time in which every Option[T] is a *T instead. It lets the subpackages of opt
+// integrate with libraries that only understand pointers as optional values.
+package shadow
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// Mapper builds shadow types and converts values to and from them.
+type Mapper struct {
+	// Tag, if set, rewrites the tag of every struct field holding an Option.
+	Tag func(reflect.StructTag) reflect.StructTag
+
+	types sync.Map // map[reflect.Type]reflect.Type
+}
+
+var needs sync.Map // map[reflect.Type]bool
+
+// Needs reports whether t contains an Option anywhere reflection based
+// encoders would look, and so differs from its shadow type.
+func Needs(t reflect.Type) bool {
+	if n, ok := needs.Load(t); ok {
+		return n.(bool)
+	}
+	n := needsShadow(t, map[reflect.Type]bool{})
+	needs.Store(t, n)
+	return n
+}
+
+// needsShadow is Needs without the cache. seen holds the types already
+// looked at, so that types referring to themselves are only walked once.
+func needsShadow(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if _, ok := optreflect.Elem(t); ok {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return needsShadow(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && needsShadow(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TypeOf returns the shadow type of t. Unexported struct fields are dropped,
+// and embedded fields stay embedded unless their type has methods, which
+// reflect.StructOf cannot embed.
+//
+// reflect can only build unnamed types, so a type that refers to itself, such
+// as a tree whose nodes hold a slice of nodes, cannot be mirrored in full:
+// the reference back to it is left as the original type, and Options below it
+// are not converted.
+func (m *Mapper) TypeOf(t reflect.Type) reflect.Type {
+	if !Needs(t) {
+		return t
+	}
+	if st, ok := m.types.Load(t); ok {
+		return st.(reflect.Type)
+	}
+	actual, _ := m.types.LoadOrStore(t, m.typeOf(t, map[reflect.Type]bool{}))
+	return actual.(reflect.Type)
+}
+
+// typeOf builds the shadow type of t. building holds the types whose shadow
+// is being built further up, which are left as they are.
+func (m *Mapper) typeOf(t reflect.Type, building map[reflect.Type]bool) reflect.Type {
+	if !Needs(t) || building[t] {
+		return t
+	}
+	building[t] = true
+	defer delete(building, t)
+	if elem, ok := optreflect.Elem(t); ok {
+		return reflect.PointerTo(m.typeOf(elem, building))
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return reflect.PointerTo(m.typeOf(t.Elem(), building))
+	case reflect.Slice:
+		return reflect.SliceOf(m.typeOf(t.Elem(), building))
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), m.typeOf(t.Elem(), building))
+	case reflect.Map:
+		return reflect.MapOf(t.Key(), m.typeOf(t.Elem(), building))
+	}
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := reflect.StructField{Name: sf.Name, Type: m.typeOf(sf.Type, building), Tag: sf.Tag}
+		field.Anonymous = sf.Anonymous && embeddable(field.Type)
+		if _, ok := optreflect.Elem(sf.Type); ok && m.Tag != nil {
+			field.Tag = m.Tag(sf.Tag)
+		}
+		fields = append(fields, field)
+	}
+	return reflect.StructOf(fields)
+}
+
+var embeddables sync.Map // map[reflect.Type]bool
+
+// embeddable reports whether reflect.StructOf can embed t in a struct with
+// other fields. It cannot if t has methods, even unexported ones, which
+// reflection does not list, so the only way to know is to try.
+func embeddable(t reflect.Type) (ok bool) {
+	if e, loaded := embeddables.Load(t); loaded {
+		return e.(bool)
+	}
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+		embeddables.Store(t, ok)
+	}()
+	reflect.StructOf([]reflect.StructField{
+		{Name: "X", Type: reflect.TypeOf(0)},
+		{Name: "Y", Type: t, Anonymous: true},
+	})
+	return true
+}
+
+// To converts v into a value of its shadow type.
+func (m *Mapper) To(v reflect.Value) reflect.Value {
+	return m.to(v, m.TypeOf(v.Type()))
+}
+
+// to converts v into a value of st, its shadow type or a part of it.
+func (m *Mapper) to(v reflect.Value, st reflect.Type) reflect.Value {
+	t := v.Type()
+	if st == t {
+		return v
+	}
+	if _, ok := optreflect.Elem(t); ok {
+		inner, some := optreflect.Get(v)
+		if !some {
+			return reflect.Zero(st)
+		}
+		p := reflect.New(st.Elem())
+		p.Elem().Set(m.to(inner, st.Elem()))
+		return p
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(st)
+		}
+		p := reflect.New(st.Elem())
+		p.Elem().Set(m.to(v.Elem(), st.Elem()))
+		return p
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(st)
+		}
+		s := reflect.MakeSlice(st, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s.Index(i).Set(m.to(v.Index(i), st.Elem()))
+		}
+		return s
+	case reflect.Array:
+		a := reflect.New(st).Elem()
+		for i := 0; i < v.Len(); i++ {
+			a.Index(i).Set(m.to(v.Index(i), st.Elem()))
+		}
+		return a
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(st)
+		}
+		mv := reflect.MakeMapWithSize(st, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			mv.SetMapIndex(iter.Key(), m.to(iter.Value(), st.Elem()))
+		}
+		return mv
+	}
+	s := reflect.New(st).Elem()
+	for i, j := 0, 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		s.Field(j).Set(m.to(v.Field(i), st.Field(j).Type))
+		j++
+	}
+	return s
+}
+
+// From stores the shadow value src into dst, which must be settable.
+func (m *Mapper) From(dst, src reflect.Value) {
+	t := dst.Type()
+	if src.Type() == t {
+		dst.Set(src)
+		return
+	}
+	if elem, ok := optreflect.Elem(t); ok {
+		if src.IsNil() {
+			optreflect.Set(dst.Addr(), reflect.Value{})
+			return
+		}
+		inner := reflect.New(elem).Elem()
+		m.From(inner, src.Elem())
+		optreflect.Set(dst.Addr(), inner)
+		return
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		p := reflect.New(t.Elem())
+		m.From(p.Elem(), src.Elem())
+		dst.Set(p)
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		dst.Set(reflect.MakeSlice(t, src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			m.From(dst.Index(i), src.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			m.From(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(t, src.Len()))
+		for iter := src.MapRange(); iter.Next(); {
+			elem := reflect.New(t.Elem()).Elem()
+			m.From(elem, iter.Value())
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+	case reflect.Struct:
+		for i, j := 0, 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			m.From(dst.Field(i), src.Field(j))
+			j++
+		}
+	}
+}
+
+// AddTagOption returns tag with option added to the comma separated options of
+// its key entry, creating the entry if needed.
+func AddTagOption(tag reflect.StructTag, key, option string) reflect.StructTag {
+	value, ok := tag.Lookup(key)
+	if !ok {
+		return reflect.StructTag(strings.TrimSpace(string(tag) + " " + key + `:",` + option + `"`))
+	}
+	name, opts, _ := strings.Cut(value, ",")
+	for _, o := range strings.Split(opts, ",") {
+		if o == option {
+			return tag
+		}
+	}
+	if opts == "" {
+		opts = option
+	} else {
+		opts = option + "," + opts
+	}
+	return reflect.StructTag(strings.Replace(string(tag), key+`:"`+value+`"`, key+`:"`+name+","+opts+`"`, 1))
+}
+
+// Decode calls decode with a pointer to the shadow of the value out points to,
+// then stores the result back into out. The shadow starts out as a copy of
+// *out so that anything decode leaves alone is preserved. If out is not a
+// non-nil pointer to a type that needs a shadow it is passed to decode as is.
+func (m *Mapper) Decode(out any, decode func(out any) error) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || !Needs(rv.Elem().Type()) {
+		return decode(out)
+	}
+	s := reflect.New(m.TypeOf(rv.Elem().Type()))
+	s.Elem().Set(m.To(rv.Elem()))
+	if err := decode(s.Interface()); err != nil {
+		return err
+	}
+	m.From(rv.Elem(), s.Elem())
+	return nil
+}
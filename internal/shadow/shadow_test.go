@@ -0,0 +1,76 @@
+package shadow_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/stretchr/testify/require"
+)
+
+type tree struct {
+	Name     string
+	Children []tree
+}
+
+type node struct {
+	Name opt.Option[string]
+	Next *node
+}
+
+type Base struct {
+	ID   int                `json:"id"`
+	Note opt.Option[string] `json:"note"`
+}
+
+type stamped struct {
+	Base
+	time.Time
+	Label opt.Option[string] `json:"label"`
+}
+
+func TestNeedsRecursive(t *testing.T) {
+	require.False(t, shadow.Needs(reflect.TypeOf(tree{})))
+	require.True(t, shadow.Needs(reflect.TypeOf(node{})))
+	require.True(t, shadow.Needs(reflect.TypeOf([]node{})))
+}
+
+func TestRecursive(t *testing.T) {
+	var m shadow.Mapper
+	in := tree{Name: "root", Children: []tree{{Name: "leaf"}}}
+	require.Equal(t, reflect.TypeOf(in), m.TypeOf(reflect.TypeOf(in)))
+	require.Equal(t, in, m.To(reflect.ValueOf(in)).Interface())
+
+	n := node{Name: opt.Some("a"), Next: &node{Name: opt.Some("b")}}
+	s := m.To(reflect.ValueOf(n))
+	require.Equal(t, "a", *s.Field(0).Interface().(*string))
+	require.Equal(t, reflect.TypeOf(&node{}), s.Field(1).Type())
+
+	var out node
+	m.From(reflect.ValueOf(&out).Elem(), s)
+	require.Equal(t, n, out)
+}
+
+func TestEmbedded(t *testing.T) {
+	var m shadow.Mapper
+	in := stamped{
+		Base:  Base{ID: 1, Note: opt.Some("n")},
+		Time:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Label: opt.Some("l"),
+	}
+	st := m.TypeOf(reflect.TypeOf(in))
+	require.True(t, st.Field(0).Anonymous)
+	require.False(t, st.Field(1).Anonymous)
+
+	s := m.To(reflect.ValueOf(in))
+	b, err := json.Marshal(s.Interface())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":1,"note":"n","Time":"2024-01-02T03:04:05Z","label":"l"}`, string(b))
+
+	var out stamped
+	m.From(reflect.ValueOf(&out).Elem(), s)
+	require.Equal(t, in, out)
+}
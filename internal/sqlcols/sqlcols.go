@@ -0,0 +1,118 @@
+// Package sqlcols lists the columns that the fields of a struct of
+// opt.Option values stand for, for the packages that build SQL from them.
+package sqlcols
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// Column is a field of a struct that takes part in a statement.
+type Column struct {
+	// Field is the Go name of the field.
+	Field string
+	// Name is the name of the column, from the "db" tag of the field, or its
+	// Go name in lower case, as github.com/jmoiron/sqlx names them.
+	Name string
+	// Op is what follows the name in the "db" tag, trimmed and in upper
+	// case.
+	Op string
+	// Value is the value of the field, or of the Option it holds.
+	Value reflect.Value
+	// Option reports whether the field is an Option, rather than a field
+	// that is always included, such as a key.
+	Option bool
+}
+
+// Of returns the columns of the fields of the struct v, or the struct v
+// points to, in order. Options that are None are left out, and fields tagged
+// "-" are skipped. The fields of embedded structs are treated as fields of
+// the outer struct. It reports false if v is not a struct or a pointer to
+// one.
+func Of(v any) ([]Column, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, false
+	}
+	var out []Column
+	appendColumns(&out, rv)
+	return out, true
+}
+
+func appendColumns(out *[]Column, rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("db")
+		name, op, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		if f.Anonymous && !tagged {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && !isOption(fv.Type()) {
+				appendColumns(out, fv)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		isOpt := isOption(fv.Type())
+		if isOpt {
+			x, ok := optreflect.Get(fv)
+			if !ok {
+				continue
+			}
+			fv = x
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		*out = append(*out, Column{
+			Field:  f.Name,
+			Name:   name,
+			Op:     strings.ToUpper(strings.TrimSpace(op)),
+			Value:  fv,
+			Option: isOpt,
+		})
+	}
+}
+
+func isOption(t reflect.Type) bool {
+	_, ok := optreflect.Elem(t)
+	return ok
+}
+
+// IsNull reports whether c would be written to the database as NULL.
+func (c Column) IsNull() bool {
+	switch c.Value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if c.Value.IsNil() {
+			return true
+		}
+	}
+	if v, ok := c.Value.Interface().(driver.Valuer); ok {
+		x, err := v.Value()
+		return err == nil && x == nil
+	}
+	return false
+}
+
+// IsList reports whether c holds a slice that is a list of values, rather
+// than a single value such as a []byte.
+func (c Column) IsList() bool {
+	return c.Value.Kind() == reflect.Slice && c.Value.Type().Elem().Kind() != reflect.Uint8
+}
@@ -0,0 +1,110 @@
+// Package textparse parses text, such as the value of a command-line flag or
+// of an environment variable, into a value of any of the types that flag
+// packages usually support, and formats such values as text. It is the one
+// parser behind Option's MarshalText and UnmarshalText and every subpackage
+// that reads values from text, so they all accept the same input.
+package textparse
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// Parse parses s into a T. Types that implement encoding.TextUnmarshaler are
+// parsed with it, time.Duration with time.ParseDuration, and other types by
+// their kind: strings, booleans, and integers (in any base, like the flag
+// package) and floating-point numbers. Spaces around anything but a string
+// are ignored.
+func Parse[T any](s string) (T, error) {
+	var v T
+	err := Set(reflect.ValueOf(&v).Elem(), s)
+	return v, err
+}
+
+// Set is like Parse, for a value whose type is only known at run time. rv
+// must be addressable.
+func Set(rv reflect.Value, s string) error {
+	if u, ok := rv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	if rv.Kind() != reflect.String {
+		s = strings.TrimSpace(s)
+	}
+	if rv.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 0, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(s, 0, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("opt: cannot parse text into %s", rv.Type())
+	}
+	return nil
+}
+
+// Format is the inverse of Set, formatting rv as text with its MarshalText
+// method if it has one, time.Duration.String for durations, or according to
+// its kind.
+func Format(rv reflect.Value) (string, error) {
+	if m, ok := rv.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+	if rv.Type() == durationType {
+		return time.Duration(rv.Int()).String(), nil
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("opt: cannot format %s as text", rv.Type())
+}
+
+// IsBool reports whether T is a boolean, which flags allow to be passed
+// without a value.
+func IsBool[T any]() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Bool
+}
@@ -0,0 +1,26 @@
+package opt
+
+import (
+	"encoding/json"
+
+	"code.nkcmr.net/opt/internal/jsonlite"
+)
+
+// AppendJSON appends the JSON encoding of o to dst and returns the extended
+// buffer, exactly as MarshalJSON would encode it. Strings, booleans, integers
+// and floating-point numbers are encoded without allocating, so encoders that
+// reuse a buffer can avoid the allocation MarshalJSON makes for each value.
+// Other types, including named ones, are encoded with json.Marshal.
+func (o Option[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if !o.ok {
+		return append(dst, "null"...), nil
+	}
+	if out, ok := jsonlite.Append(dst, o.v); ok {
+		return out, nil
+	}
+	data, err := json.Marshal(o.v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data...), nil
+}
@@ -0,0 +1,241 @@
+package opt
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireAppendJSON checks that AppendJSON encodes o the same way
+// json.Marshal encodes its value, and appends to what is already in dst.
+func requireAppendJSON[T any](t *testing.T, o Option[T]) {
+	t.Helper()
+	want, wantErr := json.Marshal(o)
+	got, err := o.AppendJSON([]byte("prefix:"))
+	if wantErr != nil {
+		require.Error(t, err)
+		return
+	}
+	require.NoError(t, err)
+	require.Equal(t, "prefix:"+string(want), string(got))
+}
+
+func TestAppendJSON(t *testing.T) {
+	requireAppendJSON(t, None[string]())
+	requireAppendJSON(t, None[[]int]())
+	for _, s := range []string{
+		"", "plain", "quote\" backslash\\", "<a href='x'>&amp;</a>",
+		"\b\f\n\r\t\x00\x1f\x7f", "line\u2028para\u2029", "\u65e5\u672c\u8a9e", "bad \xff utf-8 \xc3",
+	} {
+		requireAppendJSON(t, Some(s))
+	}
+	requireAppendJSON(t, Some(true))
+	requireAppendJSON(t, Some(false))
+	requireAppendJSON(t, Some(math.MinInt64))
+	requireAppendJSON(t, Some(int8(-8)))
+	requireAppendJSON(t, Some(int16(-16)))
+	requireAppendJSON(t, Some(int32(-32)))
+	requireAppendJSON(t, Some(int64(-64)))
+	requireAppendJSON(t, Some(uint(math.MaxUint)))
+	requireAppendJSON(t, Some(uint8(8)))
+	requireAppendJSON(t, Some(uint16(16)))
+	requireAppendJSON(t, Some(uint32(32)))
+	requireAppendJSON(t, Some(uint64(math.MaxUint64)))
+	for _, f := range []float64{
+		0, math.Copysign(0, -1), 1, -1.5, 1e-6, 1e-7, 1.5e-9, 1e20, 1e21, 1.234e300,
+		math.MaxFloat64, math.SmallestNonzeroFloat64,
+		math.NaN(), math.Inf(1), math.Inf(-1),
+	} {
+		requireAppendJSON(t, Some(f))
+		requireAppendJSON(t, Some(float32(f)))
+	}
+	requireAppendJSON(t, Some(time.Second))
+	requireAppendJSON(t, Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	requireAppendJSON(t, Some(map[string]int{"a": 1}))
+	requireAppendJSON(t, Some(func() {}))
+
+	require.NoError(t, quick.Check(func(s string, f64 float64, f32 float32, i int64) bool {
+		requireAppendJSON(t, Some(s))
+		requireAppendJSON(t, Some(f64))
+		requireAppendJSON(t, Some(f32))
+		requireAppendJSON(t, Some(i))
+		return true
+	}, nil))
+}
+
+func TestAppendJSONAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	for name, o := range map[string]interface {
+		AppendJSON([]byte) ([]byte, error)
+	}{
+		"string": Some("hello <world>"),
+		"int":    Some(123456),
+		"float":  Some(3.25),
+		"bool":   Some(true),
+		"none":   None[string](),
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf, _ = o.AppendJSON(buf[:0])
+		})
+		require.Zero(t, allocs, name)
+	}
+}
+
+// requireUnmarshalJSON checks that UnmarshalJSON decodes data the same way
+// json.Unmarshal decodes it into a T.
+func requireUnmarshalJSON[T any](t *testing.T, data string) {
+	t.Helper()
+	var want T
+	wantErr := json.Unmarshal([]byte(data), &want)
+	var got Option[T]
+	err := got.UnmarshalJSON([]byte(data))
+	if wantErr != nil {
+		require.Error(t, err, data)
+		require.IsType(t, wantErr, err, data)
+		return
+	}
+	require.NoError(t, err, data)
+	require.Equal(t, Some(want), got, data)
+}
+
+func TestUnmarshalJSONFast(t *testing.T) {
+	for _, data := range []string{
+		`""`, `"plain"`, `"日本語"`, `"esc\"aped"`, `"é"`, "\"bad \xff\"", "\"tab\t\"",
+		`"unterminated`, `1`, `true`, `{}`,
+	} {
+		requireUnmarshalJSON[string](t, data)
+	}
+	for _, data := range []string{`true`, `false`, `"true"`, `1`, `tru`, `truex`} {
+		requireUnmarshalJSON[bool](t, data)
+	}
+	integers := []string{
+		`0`, `-0`, `1`, `-1`, `127`, `128`, `-129`, `255`, `256`, `32768`, `65536`,
+		`2147483648`, `4294967296`, `9223372036854775807`, `9223372036854775808`,
+		`18446744073709551615`, `18446744073709551616`, `-9223372036854775809`,
+		`01`, `+1`, `1.0`, `1e3`, `0x10`, `1_000`, `"1"`, ` 1`, `-`, ``,
+	}
+	for _, data := range integers {
+		requireUnmarshalJSON[int](t, data)
+		requireUnmarshalJSON[int8](t, data)
+		requireUnmarshalJSON[int16](t, data)
+		requireUnmarshalJSON[int32](t, data)
+		requireUnmarshalJSON[int64](t, data)
+		requireUnmarshalJSON[uint](t, data)
+		requireUnmarshalJSON[uint8](t, data)
+		requireUnmarshalJSON[uint16](t, data)
+		requireUnmarshalJSON[uint32](t, data)
+		requireUnmarshalJSON[uint64](t, data)
+	}
+	floats := append(integers,
+		`1.5`, `-0.25`, `1e-7`, `1E+21`, `2.5e-3`, `1e400`, `-1e400`, `1e-400`,
+		`3.4028235e38`, `3.5e38`, `1.`, `.5`, `1e`, `1e+`, `NaN`, `Infinity`, `0x1p-2`,
+	)
+	for _, data := range floats {
+		requireUnmarshalJSON[float32](t, data)
+		requireUnmarshalJSON[float64](t, data)
+	}
+	requireUnmarshalJSON[time.Duration](t, `1000`)
+
+	require.NoError(t, quick.Check(func(s string, f64 float64, f32 float32, i int64, u uint16) bool {
+		for _, v := range []any{s, f64, f32, i, u} {
+			data, err := json.Marshal(v)
+			require.NoError(t, err)
+			switch v.(type) {
+			case string:
+				requireUnmarshalJSON[string](t, string(data))
+			case float64:
+				requireUnmarshalJSON[float64](t, string(data))
+			case float32:
+				requireUnmarshalJSON[float32](t, string(data))
+			case int64:
+				requireUnmarshalJSON[int64](t, string(data))
+				requireUnmarshalJSON[int8](t, string(data))
+			case uint16:
+				requireUnmarshalJSON[uint16](t, string(data))
+			}
+		}
+		return true
+	}, nil))
+}
+
+func TestUnmarshalJSONAllocs(t *testing.T) {
+	var i Option[int]
+	var f Option[float64]
+	var b Option[bool]
+	idata, fdata, bdata := []byte(`123456`), []byte(`-3.25e-2`), []byte(`true`)
+	for name, fn := range map[string]func() error{
+		"int":   func() error { return i.UnmarshalJSON(idata) },
+		"float": func() error { return f.UnmarshalJSON(fdata) },
+		"bool":  func() error { return b.UnmarshalJSON(bdata) },
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			require.NoError(t, fn())
+		})
+		require.Zero(t, allocs, name)
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	b.Run("string", func(b *testing.B) { benchmarkUnmarshalJSON[string](b, `"hello, world"`) })
+	b.Run("int", func(b *testing.B) { benchmarkUnmarshalJSON[int](b, `123456`) })
+	b.Run("float64", func(b *testing.B) { benchmarkUnmarshalJSON[float64](b, `3.25`) })
+	b.Run("bool", func(b *testing.B) { benchmarkUnmarshalJSON[bool](b, `true`) })
+}
+
+// benchmarkUnmarshalJSON compares UnmarshalJSON with decoding the same value
+// with json.Unmarshal, which it used to do.
+func benchmarkUnmarshalJSON[T any](b *testing.B, data string) {
+	raw := []byte(data)
+	b.Run("UnmarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		var o Option[T]
+		for i := 0; i < b.N; i++ {
+			if err := o.UnmarshalJSON(raw); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("json.Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		var v T
+		for i := 0; i < b.N; i++ {
+			if err := json.Unmarshal(raw, &v); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	b.Run("string", func(b *testing.B) { benchmarkMarshalJSON(b, Some("hello, world")) })
+	b.Run("int", func(b *testing.B) { benchmarkMarshalJSON(b, Some(123456)) })
+	b.Run("float64", func(b *testing.B) { benchmarkMarshalJSON(b, Some(3.25)) })
+	b.Run("bool", func(b *testing.B) { benchmarkMarshalJSON(b, Some(true)) })
+	b.Run("None", func(b *testing.B) { benchmarkMarshalJSON(b, None[int]()) })
+}
+
+// benchmarkMarshalJSON compares MarshalJSON with AppendJSON reusing a buffer.
+func benchmarkMarshalJSON[T any](b *testing.B, o Option[T]) {
+	b.Run("MarshalJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := o.MarshalJSON(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, 64)
+		for i := 0; i < b.N; i++ {
+			var err error
+			if buf, err = o.AppendJSON(buf[:0]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
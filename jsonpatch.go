@@ -0,0 +1,121 @@
+package opt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// ToJSONPatch returns a JSON Patch (RFC 6902) that turns the JSON encoding of
+// from into that of to, which must be structs of the same type or pointers to
+// them. Fields are compared as encoding/json would encode them, and:
+//
+//   - an Option field that goes from None to Some is added, one that goes
+//     from Some to None is replaced with null, as encoding/json writes None,
+//     and one that stays Some with a different value is replaced;
+//   - a field that encoding/json leaves out because of its "omitempty" or
+//     "omitzero" tag option, such as an empty string tagged "omitempty" or
+//     a None tagged "omitzero", is added when it stops being left out and
+//     removed when it starts to be;
+//   - a struct field that is not an Option, or an Option of such a struct
+//     that is Some in both, is compared field by field;
+//   - any other field is replaced if its value differs.
+//
+// For example, between two versions of a user:
+//
+//	[{"op":"replace","path":"/name","value":"bob"},{"op":"replace","path":"/nickname","value":null}]
+//
+// Applying the patch to json.Marshal(from) gives a document equal to
+// json.Marshal(to). The patch is empty, [], if nothing changed.
+func ToJSONPatch(from, to any) ([]byte, error) {
+	ov, nv := reflect.ValueOf(from), reflect.ValueOf(to)
+	for ov.Kind() == reflect.Pointer && !ov.IsNil() {
+		ov = ov.Elem()
+	}
+	for nv.Kind() == reflect.Pointer && !nv.IsNil() {
+		nv = nv.Elem()
+	}
+	if ov.Kind() != reflect.Struct || nv.Kind() != reflect.Struct || ov.Type() != nv.Type() {
+		return nil, fmt.Errorf("opt: ToJSONPatch: from and to must be structs of the same type or pointers to them, not %T and %T", from, to)
+	}
+	ops := []jsonPatchOp{}
+	if err := diffJSONPatch(&ops, "", ov, nv); err != nil {
+		return nil, err
+	}
+	return json.Marshal(ops)
+}
+
+type jsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func diffJSONPatch(ops *[]jsonPatchOp, path string, ov, nv reflect.Value) error {
+	for _, f := range jsonFields(ov.Type()) {
+		fpath := path + "/" + jsonPointerEscaper.Replace(f.name)
+		of, nf := ov.FieldByIndex(f.index), nv.FieldByIndex(f.index)
+		switch oOmitted, nOmitted := f.omits(of), f.omits(nf); {
+		case oOmitted && nOmitted:
+			continue
+		case nOmitted:
+			*ops = append(*ops, jsonPatchOp{Op: "remove", Path: fpath})
+			continue
+		case oOmitted:
+			data, err := json.Marshal(nf.Interface())
+			if err != nil {
+				return fmt.Errorf("opt: ToJSONPatch: %s: %w", fpath, err)
+			}
+			*ops = append(*ops, jsonPatchOp{Op: "add", Path: fpath, Value: data})
+			continue
+		}
+		if isPlainStruct(of.Type()) {
+			if err := diffJSONPatch(ops, fpath, of, nf); err != nil {
+				return err
+			}
+			continue
+		}
+		if optreflect.Is(of.Type()) {
+			ox, oSome := optreflect.Get(of)
+			nx, nSome := optreflect.Get(nf)
+			switch {
+			case !oSome && !nSome:
+				continue
+			case oSome && !nSome:
+				*ops = append(*ops, jsonPatchOp{Op: "replace", Path: fpath, Value: json.RawMessage("null")})
+				continue
+			case oSome && nSome && isPlainStruct(ox.Type()):
+				if err := diffJSONPatch(ops, fpath, ox, nx); err != nil {
+					return err
+				}
+				continue
+			case !oSome:
+				data, err := json.Marshal(nx.Interface())
+				if err != nil {
+					return fmt.Errorf("opt: ToJSONPatch: %s: %w", fpath, err)
+				}
+				*ops = append(*ops, jsonPatchOp{Op: "add", Path: fpath, Value: data})
+				continue
+			}
+			of, nf = ox, nx
+		}
+		odata, err := json.Marshal(of.Interface())
+		if err != nil {
+			return fmt.Errorf("opt: ToJSONPatch: %s: %w", fpath, err)
+		}
+		ndata, err := json.Marshal(nf.Interface())
+		if err != nil {
+			return fmt.Errorf("opt: ToJSONPatch: %s: %w", fpath, err)
+		}
+		if !bytes.Equal(odata, ndata) {
+			*ops = append(*ops, jsonPatchOp{Op: "replace", Path: fpath, Value: ndata})
+		}
+	}
+	return nil
+}
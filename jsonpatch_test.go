@@ -0,0 +1,149 @@
+package opt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSONPatch(t *testing.T) {
+	type tags struct {
+		Primary Option[string] `json:"primary"`
+	}
+	type user struct {
+		mpMeta
+		Name     string            `json:"name"`
+		Nickname Option[string]    `json:"nickname"`
+		Email    Option[string]    `json:"email"`
+		Age      Option[int]       `json:"age"`
+		Address  mpAddress         `json:"address"`
+		Billing  Option[mpAddress] `json:"billing"`
+		Tags     []string          `json:"tags"`
+		Slashed  Option[tags]      `json:"a/b~c"`
+		Secret   Option[string]    `json:"-"`
+		Phone    Option[string]    `json:"phone,omitzero"`
+	}
+	old := user{
+		mpMeta:   mpMeta{Version: Some(1)},
+		Name:     "ann",
+		Nickname: Some("annie"),
+		Age:      Some(30),
+		Address:  mpAddress{City: Some("Oslo")},
+		Billing:  Some(mpAddress{City: Some("Oslo")}),
+		Tags:     []string{"a"},
+		Slashed:  Some(tags{}),
+		Phone:    Some("555"),
+	}
+	updated := user{
+		mpMeta:  mpMeta{Version: Some(2)},
+		Name:    "bob",
+		Email:   Some("bob@example.com"),
+		Age:     Some(30),
+		Address: mpAddress{City: Some("Oslo"), Zip: Some("0150")},
+		Billing: Some(mpAddress{City: Some("Bergen")}),
+		Tags:    []string{"a"},
+		Slashed: Some(tags{Primary: Some("x")}),
+		Secret:  Some("s"),
+	}
+	got, err := ToJSONPatch(old, &updated)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"replace","path":"/version","value":2},
+		{"op":"replace","path":"/name","value":"bob"},
+		{"op":"replace","path":"/nickname","value":null},
+		{"op":"add","path":"/email","value":"bob@example.com"},
+		{"op":"add","path":"/address/zip","value":"0150"},
+		{"op":"replace","path":"/billing/city","value":"Bergen"},
+		{"op":"add","path":"/a~1b~0c/primary","value":"x"},
+		{"op":"remove","path":"/phone"}
+	]`, string(got))
+	requireJSONPatchApplies(t, old, updated, got)
+
+	got, err = ToJSONPatch(old, old)
+	require.NoError(t, err)
+	require.Equal(t, "[]", string(got))
+
+	got, err = ToJSONPatch(user{}, user{Billing: Some(mpAddress{})})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"op":"add","path":"/billing","value":{"city":null,"zip":null}}]`, string(got))
+	requireJSONPatchApplies(t, user{}, user{Billing: Some(mpAddress{})}, got)
+
+	got, err = ToJSONPatch(updated, old)
+	require.NoError(t, err)
+	requireJSONPatchApplies(t, updated, old, got)
+
+	// Fields left out by "omitempty" and "omitzero" are added and removed,
+	// since a replace needs the member to be there.
+	type profile struct {
+		Name    string    `json:"name,omitempty"`
+		Count   int       `json:"count,omitzero"`
+		Address mpAddress `json:"address,omitzero"`
+	}
+	empty, full := profile{}, profile{Name: "bob", Count: 2, Address: mpAddress{City: Some("Oslo")}}
+	got, err = ToJSONPatch(empty, full)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"add","path":"/name","value":"bob"},
+		{"op":"add","path":"/count","value":2},
+		{"op":"add","path":"/address","value":{"city":"Oslo","zip":null}}
+	]`, string(got))
+	requireJSONPatchApplies(t, empty, full, got)
+
+	got, err = ToJSONPatch(full, empty)
+	require.NoError(t, err)
+	require.JSONEq(t, `[
+		{"op":"remove","path":"/name"},
+		{"op":"remove","path":"/count"},
+		{"op":"remove","path":"/address"}
+	]`, string(got))
+	requireJSONPatchApplies(t, full, empty, got)
+
+	_, err = ToJSONPatch(old, mpAddress{})
+	require.EqualError(t, err, "opt: ToJSONPatch: from and to must be structs of the same type or pointers to them, not opt.user and opt.mpAddress")
+}
+
+// requireJSONPatchApplies checks that applying patch to the JSON encoding of
+// from gives the JSON encoding of to.
+func requireJSONPatchApplies(t *testing.T, from, to any, patch []byte) {
+	t.Helper()
+	var doc any
+	data, err := json.Marshal(from)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &doc))
+
+	var ops []struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal(patch, &ops))
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for _, op := range ops {
+		keys := strings.Split(op.Path, "/")[1:]
+		parent := doc.(map[string]any)
+		for _, k := range keys[:len(keys)-1] {
+			parent = parent[unescape.Replace(k)].(map[string]any)
+		}
+		last := unescape.Replace(keys[len(keys)-1])
+		switch op.Op {
+		case "add":
+			parent[last] = op.Value
+		case "replace":
+			require.Contains(t, parent, last, "replace of missing %s", op.Path)
+			parent[last] = op.Value
+		case "remove":
+			require.Contains(t, parent, last, "remove of missing %s", op.Path)
+			delete(parent, last)
+		default:
+			t.Fatalf("unexpected op %q", op.Op)
+		}
+	}
+
+	want, err := json.Marshal(to)
+	require.NoError(t, err)
+	got, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.JSONEq(t, string(want), string(got))
+}
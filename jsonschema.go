@@ -0,0 +1,9 @@
+package opt
+
+// JSONSchemaAlias implements the alias hook of github.com/invopop/jsonschema,
+// so an Option is described by the schema of the value it contains rather
+// than as an empty object. The optjsonschema package goes further and also
+// allows Option fields to be null and leaves them out of "required".
+func (o Option[T]) JSONSchemaAlias() any {
+	return new(T)
+}
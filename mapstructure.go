@@ -0,0 +1,78 @@
+package opt
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// MapstructureHook returns a decode hook that lets mapstructure, and the
+// libraries built on it such as viper, decode into Option values:
+//
+//	var cfg Config
+//	err := viper.Unmarshal(&cfg, viper.DecodeHook(opt.MapstructureHook()))
+//
+// A key that is missing from the input leaves the Option as it is, None for a
+// zero value. So does a nil value, unless the decoder is configured with
+// DecodeNil, in which case it is decoded as None. Any other value is decoded
+// as a Some. Values of the contained type are used as they are, other numbers
+// are converted, and strings are parsed like MarshalText would format them.
+//
+// Decoding anything else, such as a map into an Option of a struct, is an
+// error; use the hook of code.nkcmr.net/opt/optmapstructure instead, which
+// decodes the contained value with mapstructure itself.
+//
+// The hook has the signature of mapstructure.DecodeHookFuncValue, so it can
+// be used without opt depending on mapstructure.
+func MapstructureHook() func(from, to reflect.Value) (any, error) {
+	return decodeMapstructure
+}
+
+func decodeMapstructure(from, to reflect.Value) (any, error) {
+	t := to.Type()
+	elem, ok := optreflect.Elem(t)
+	if !ok {
+		return from.Interface(), nil
+	}
+	out := reflect.New(t)
+	if !from.IsValid() || isNilValue(from) {
+		return out.Elem().Interface(), nil
+	}
+	if from.Type() == t {
+		return from.Interface(), nil
+	}
+	x := reflect.New(elem).Elem()
+	if err := convertMapstructure(from, x); err != nil {
+		return nil, err
+	}
+	optreflect.Set(out, x)
+	return out.Elem().Interface(), nil
+}
+
+// convertMapstructure stores from into x, which is addressable.
+func convertMapstructure(from, x reflect.Value) error {
+	switch {
+	case from.Type().AssignableTo(x.Type()):
+		x.Set(from)
+		return nil
+	case isNumberKind(from.Kind()) && isNumberKind(x.Kind()):
+		x.Set(from.Convert(x.Type()))
+		return nil
+	case from.Kind() == reflect.String:
+		return parseText(x.Addr().Interface(), from.String())
+	}
+	return fmt.Errorf("opt: cannot decode %s into %s ; use code.nkcmr.net/opt/optmapstructure", from.Type(), x.Type())
+}
+
+func isNilValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return reflect.Int <= k && k <= reflect.Float64
+}
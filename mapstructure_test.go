@@ -0,0 +1,62 @@
+package opt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapstructure(t *testing.T) {
+	type Config struct {
+		Name    Option[string]        `mapstructure:"name"`
+		Port    Option[int]           `mapstructure:"port"`
+		Ratio   Option[float64]       `mapstructure:"ratio"`
+		Debug   Option[bool]          `mapstructure:"debug"`
+		Token   Option[string]        `mapstructure:"token"`
+		Since   Option[time.Time]     `mapstructure:"since"`
+		Timeout Option[time.Duration] `mapstructure:"timeout"`
+		Missing Option[int]           `mapstructure:"missing"`
+		Plain   int                   `mapstructure:"plain"`
+	}
+	decode := func(input any, out any) error {
+		d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook: MapstructureHook(),
+			DecodeNil:  true,
+			Result:     out,
+		})
+		require.NoError(t, err)
+		return d.Decode(input)
+	}
+
+	var cfg Config
+	err := decode(map[string]any{
+		"name":    "nk",
+		"port":    float64(8080),
+		"ratio":   1,
+		"debug":   "true",
+		"token":   nil,
+		"since":   "2024-03-01T00:00:00Z",
+		"timeout": Some(time.Second),
+		"plain":   3,
+	}, &cfg)
+	require.NoError(t, err)
+	require.Equal(t, Config{
+		Name:    Some("nk"),
+		Port:    Some(8080),
+		Ratio:   Some(1.0),
+		Debug:   Some(true),
+		Since:   Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Timeout: Some(time.Second),
+		Plain:   3,
+	}, cfg)
+
+	cfg = Config{Port: Some(1), Token: Some("x")}
+	require.NoError(t, decode(map[string]any{"token": nil}, &cfg))
+	require.Equal(t, Some(1), cfg.Port)
+	require.True(t, cfg.Token.None())
+
+	require.Error(t, decode(map[string]any{"port": "many"}, &cfg))
+	require.ErrorContains(t, decode(map[string]any{"port": []any{1}}, &cfg), "optmapstructure")
+}
@@ -0,0 +1,37 @@
+package opt
+
+// Number is the set of integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Sum adds up the values of the options that are Some, skipping those that
+// are None. If all of them are None, or there are none at all, the result is
+// None rather than zero.
+func Sum[N Number](os ...Option[N]) Option[N] {
+	return reduce(os, func(a, b N) N { return a + b })
+}
+
+// Product multiplies the values of the options that are Some, skipping those
+// that are None. If all of them are None, or there are none at all, the
+// result is None rather than one.
+func Product[N Number](os ...Option[N]) Option[N] {
+	return reduce(os, func(a, b N) N { return a * b })
+}
+
+func reduce[T any](os []Option[T], fn func(T, T) T) Option[T] {
+	var acc Option[T]
+	for _, o := range os {
+		if !o.ok {
+			continue
+		}
+		if !acc.ok {
+			acc = o
+			continue
+		}
+		acc.v = fn(acc.v, o.v)
+	}
+	return acc
+}
@@ -0,0 +1,30 @@
+package opt
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSum(t *testing.T) {
+	require.Equal(t, None[int](), Sum[int]())
+	require.Equal(t, None[int](), Sum(None[int](), None[int]()))
+	require.Equal(t, Some(0), Sum(Some(0), None[int]()))
+	require.Equal(t, Some(6), Sum(Some(1), None[int](), Some(2), Some(3)))
+	require.Equal(t, Some(2.5), Sum(Some(1.0), Some(1.5)))
+	require.Equal(t, Some(uint8(4)), Sum(Some(uint8(250)), Some(uint8(10))))
+	require.Equal(t, Some(90*time.Second), Sum(Some(time.Minute), Some(30*time.Second)))
+
+	nan := Sum(Some(1.0), Some(math.NaN()))
+	require.True(t, math.IsNaN(nan.Unwrap()))
+}
+
+func TestProduct(t *testing.T) {
+	require.Equal(t, None[float64](), Product[float64]())
+	require.Equal(t, None[float64](), Product(None[float64]()))
+	require.Equal(t, Some(0.0), Product(Some(0.0), Some(5.0)))
+	require.Equal(t, Some(24), Product(Some(2), None[int](), Some(3), Some(4)))
+	require.Equal(t, Some(7), Product(None[int](), Some(7)))
+}
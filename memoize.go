@@ -0,0 +1,42 @@
+package opt
+
+import "sync"
+
+// Memoize returns a function that calls fn until it succeeds and from then on
+// returns what it returned, without calling it again. Each call that fails
+// returns None, and the next call tries again. It is safe to call the
+// returned function concurrently; fn is never called by two goroutines at
+// once.
+func Memoize[T any](fn func() (T, error)) func() Option[T] {
+	return MemoizeRetry(fn, nil)
+}
+
+// MemoizeRetry is like Memoize, but only tries fn again after it fails if
+// retry returns true when called with the error and the number of times fn
+// has failed so far. Once retry returns false, the returned function always
+// returns None. A nil retry always tries again.
+func MemoizeRetry[T any](fn func() (T, error), retry func(err error, failures int) bool) func() Option[T] {
+	var (
+		mu       sync.Mutex
+		result   Option[T]
+		done     bool
+		failures int
+	)
+	return func() Option[T] {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return result
+		}
+		v, err := fn()
+		if err == nil {
+			result, done = Some(v), true
+			return result
+		}
+		failures++
+		if retry != nil && !retry(err, failures) {
+			done = true
+		}
+		return result
+	}
+}
@@ -0,0 +1,75 @@
+package opt
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	key := Memoize(func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errors.New("unavailable")
+		}
+		return "key", nil
+	})
+	require.Equal(t, None[string](), key())
+	require.Equal(t, None[string](), key())
+	require.Equal(t, Some("key"), key())
+	require.Equal(t, Some("key"), key())
+	require.Equal(t, 3, calls)
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int32
+	client := Memoize(func() (int, error) {
+		calls.Add(1)
+		return 42, nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, Some(42), client())
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestMemoizeRetry(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	var (
+		calls    int
+		failures []int
+	)
+	flag := MemoizeRetry(func() (bool, error) {
+		calls++
+		if calls == 1 {
+			return false, errors.New("temporary")
+		}
+		return false, errPermanent
+	}, func(err error, n int) bool {
+		failures = append(failures, n)
+		return !errors.Is(err, errPermanent)
+	})
+	for i := 0; i < 4; i++ {
+		require.Equal(t, None[bool](), flag())
+	}
+	require.Equal(t, 2, calls)
+	require.Equal(t, []int{1, 2}, failures)
+
+	calls = 0
+	succeeds := MemoizeRetry(func() (int, error) {
+		calls++
+		return calls, nil
+	}, func(error, int) bool { panic("called") })
+	require.Equal(t, Some(1), succeeds())
+	require.Equal(t, Some(1), succeeds())
+}
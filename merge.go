@@ -0,0 +1,64 @@
+package opt
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// Merge fills in the fields of the struct dst points to that are None with the
+// same fields of overlay, which must be a struct of the same type or a pointer
+// to one. Fields of dst that are already Some are kept. Struct fields, embedded
+// or not, are merged the same way, field by field; any other fields, and
+// unexported ones, are left as they are.
+//
+// Applying Merge to each layer in order of priority layers configuration:
+//
+//	cfg := flagConfig
+//	for _, layer := range []Config{envConfig, fileConfig, defaultConfig} {
+//		if err := opt.Merge(&cfg, layer); err != nil {
+//			return err
+//		}
+//	}
+//
+// MergeStruct does the same with the types checked at compile time.
+func Merge(dst, overlay any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("opt: Merge: dst must be a non-nil pointer to a struct, not %T", dst)
+	}
+	dv = dv.Elem()
+	ov := reflect.ValueOf(overlay)
+	if ov.Kind() == reflect.Pointer && !ov.IsNil() {
+		ov = ov.Elem()
+	}
+	if !ov.IsValid() || ov.Type() != dv.Type() {
+		return fmt.Errorf("opt: Merge: overlay must be a %s or a pointer to one, not %T", dv.Type(), overlay)
+	}
+	mergeStruct(dv, ov)
+	return nil
+}
+
+// MergeStruct is Merge for when the type of the struct is known.
+func MergeStruct[T any](dst *T, overlay T) error {
+	return Merge(dst, &overlay)
+}
+
+func mergeStruct(dst, overlay reflect.Value) {
+	for i := 0; i < dst.NumField(); i++ {
+		df, of := dst.Field(i), overlay.Field(i)
+		switch {
+		case df.Kind() != reflect.Struct:
+		case optreflect.Is(df.Type()):
+			if !df.CanSet() {
+				continue
+			}
+			if _, some := optreflect.Get(df); !some {
+				df.Set(of)
+			}
+		case dst.Type().Field(i).IsExported() || dst.Type().Field(i).Anonymous:
+			mergeStruct(df, of)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package opt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mergeTLS struct {
+	Cert Option[string]
+	Key  Option[string]
+}
+
+type mergeBase struct {
+	Debug Option[bool]
+}
+
+type mergeConfig struct {
+	mergeBase
+	Addr    Option[string]
+	Timeout Option[time.Duration]
+	TLS     mergeTLS
+	Name    string
+	secret  Option[string]
+}
+
+func TestMerge(t *testing.T) {
+	dst := mergeConfig{
+		Addr: Some(":8080"),
+		TLS:  mergeTLS{Cert: Some("a.pem")},
+		Name: "dst",
+	}
+	overlay := mergeConfig{
+		mergeBase: mergeBase{Debug: Some(true)},
+		Addr:      Some(":9090"),
+		Timeout:   Some(time.Second),
+		TLS:       mergeTLS{Cert: Some("b.pem"), Key: Some("b.key")},
+		Name:      "overlay",
+		secret:    Some("x"),
+	}
+	require.NoError(t, Merge(&dst, overlay))
+	require.Equal(t, mergeConfig{
+		mergeBase: mergeBase{Debug: Some(true)},
+		Addr:      Some(":8080"),
+		Timeout:   Some(time.Second),
+		TLS:       mergeTLS{Cert: Some("a.pem"), Key: Some("b.key")},
+		Name:      "dst",
+	}, dst)
+
+	// A None in the overlay never replaces anything.
+	require.NoError(t, Merge(&dst, &mergeConfig{}))
+	require.Equal(t, Some(":8080"), dst.Addr)
+	require.Equal(t, Some(time.Second), dst.Timeout)
+}
+
+func TestMergeStruct(t *testing.T) {
+	dst := mergeTLS{Key: Some("k")}
+	require.NoError(t, MergeStruct(&dst, mergeTLS{Cert: Some("c"), Key: Some("other")}))
+	require.Equal(t, mergeTLS{Cert: Some("c"), Key: Some("k")}, dst)
+}
+
+func TestMergeErrors(t *testing.T) {
+	var cfg mergeConfig
+	require.ErrorContains(t, Merge(cfg, cfg), "opt: Merge: dst must be a non-nil pointer to a struct, not opt.mergeConfig")
+	require.ErrorContains(t, Merge((*mergeConfig)(nil), cfg), "dst must be a non-nil pointer")
+	require.ErrorContains(t, Merge(new(int), 1), "dst must be a non-nil pointer")
+	require.ErrorContains(t, Merge(&cfg, mergeTLS{}), "opt: Merge: overlay must be a opt.mergeConfig or a pointer to one, not opt.mergeTLS")
+	require.ErrorContains(t, Merge(&cfg, nil), "overlay must be")
+	require.ErrorContains(t, Merge(&cfg, (*mergeConfig)(nil)), "overlay must be")
+}
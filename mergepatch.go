@@ -0,0 +1,267 @@
+package opt
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// ToMergePatch encodes patch, a struct or a pointer to one, as a JSON Merge
+// Patch (RFC 7396). Fields are named as encoding/json would name them, and:
+//
+//   - an Option field is left out if it is None, and set to its value if it
+//     is Some;
+//   - an Option[Option[T]] field is left out if it is None, set to null if it
+//     is Some(None), which removes the member it names, and set to the value
+//     if it is Some(Some(v));
+//   - a struct field that is not an Option is encoded as a nested patch, and
+//     left out if the nested patch is empty;
+//   - any other field is always set to its value.
+//
+// For example:
+//
+//	type UserPatch struct {
+//		Name     opt.Option[string]             `json:"name"`
+//		Nickname opt.Option[opt.Option[string]] `json:"nickname"`
+//	}
+//
+//	opt.ToMergePatch(UserPatch{Nickname: opt.Some(opt.None[string]())})
+//	// {"nickname":null}
+func ToMergePatch(patch any) ([]byte, error) {
+	rv := reflect.ValueOf(patch)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("opt: ToMergePatch: patch must be a struct or a pointer to one, not %T", patch)
+	}
+	var buf bytes.Buffer
+	if err := writeMergePatch(&buf, rv); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeMergePatch(buf *bytes.Buffer, rv reflect.Value) error {
+	buf.WriteByte('{')
+	n := 0
+	for _, f := range jsonFields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		start := buf.Len()
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.name)
+		buf.Write(name)
+		buf.WriteByte(':')
+
+		var v any
+		switch {
+		case optreflect.Is(fv.Type()):
+			x, some := optreflect.Get(fv)
+			if !some {
+				buf.Truncate(start)
+				continue
+			}
+			if optreflect.Is(x.Type()) {
+				if x, some = optreflect.Get(x); !some {
+					buf.WriteString("null")
+					n++
+					continue
+				}
+			}
+			v = x.Interface()
+		case isPlainStruct(fv.Type()):
+			before := buf.Len()
+			if err := writeMergePatch(buf, fv); err != nil {
+				return err
+			}
+			if buf.Len()-before == 2 {
+				buf.Truncate(start)
+				continue
+			}
+			n++
+			continue
+		default:
+			v = fv.Interface()
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("opt: ToMergePatch: %s: %w", f.name, err)
+		}
+		buf.Write(data)
+		n++
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// ApplyMergePatch applies the JSON Merge Patch (RFC 7396) in patch to the
+// struct dst points to. Members of patch are matched to fields the way
+// encoding/json matches them, and:
+//
+//   - null sets the field to its zero value, which for an Option is None,
+//     except that it sets an Option[Option[T]] to Some(None), so that patches
+//     can be decoded into the same structs ToMergePatch encodes;
+//   - an object is merged into a struct field that is not an Option, or
+//     into the value of an Option of such a struct, field by field;
+//   - any other value replaces the field, as json.Unmarshal would decode it.
+//
+// Members that match no field are ignored.
+func ApplyMergePatch(dst any, patch []byte) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("opt: ApplyMergePatch: dst must be a non-nil pointer to a struct, not %T", dst)
+	}
+	return applyMergePatch(rv.Elem(), patch, "")
+}
+
+func applyMergePatch(rv reflect.Value, patch []byte, path string) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil || members == nil {
+		return fmt.Errorf("opt: ApplyMergePatch: %s: patch is not a JSON object", pathOrRoot(path))
+	}
+	for _, f := range jsonFields(rv.Type()) {
+		raw, ok := members[f.name]
+		if !ok {
+			for k, v := range members {
+				if strings.EqualFold(k, f.name) {
+					raw, ok = v, true
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+		}
+		fv := rv.FieldByIndex(f.index)
+		fpath := path + "/" + f.name
+		raw = bytes.TrimSpace(raw)
+		switch {
+		case bytes.Equal(raw, []byte("null")) && isOptionOfOption(fv.Type()):
+			elem, _ := optreflect.Elem(fv.Type())
+			optreflect.Set(fv.Addr(), reflect.Zero(elem))
+		case bytes.Equal(raw, []byte("null")):
+			fv.SetZero()
+		case raw[0] == '{' && isPlainStruct(fv.Type()):
+			if err := applyMergePatch(fv, raw, fpath); err != nil {
+				return err
+			}
+		case raw[0] == '{' && isOptionOfPlainStruct(fv.Type()):
+			x, _ := optreflect.Get(fv)
+			if err := applyMergePatch(x, raw, fpath); err != nil {
+				return err
+			}
+			optreflect.Set(fv.Addr(), x)
+		default:
+			if err := json.Unmarshal(raw, fv.Addr().Interface()); err != nil {
+				return fmt.Errorf("opt: ApplyMergePatch: %s: %w", fpath, err)
+			}
+		}
+	}
+	return nil
+}
+
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// isPlainStruct reports whether t is a struct that is neither an Option nor
+// encoded by methods of its own, and so is encoded as a JSON object of its
+// fields.
+func isPlainStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || optreflect.Is(t) {
+		return false
+	}
+	p := reflect.PointerTo(t)
+	return !p.Implements(jsonMarshalerType) && !p.Implements(jsonUnmarshalerType) &&
+		!p.Implements(textMarshalerType) && !p.Implements(textUnmarshalerType)
+}
+
+// isOptionOfOption reports whether t is an Option of an Option.
+func isOptionOfOption(t reflect.Type) bool {
+	elem, ok := optreflect.Elem(t)
+	return ok && optreflect.Is(elem)
+}
+
+// isOptionOfPlainStruct reports whether t is an Option of a plain struct.
+func isOptionOfPlainStruct(t reflect.Type) bool {
+	elem, ok := optreflect.Elem(t)
+	return ok && isPlainStruct(elem)
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// jsonField is a field of a struct as encoding/json sees it.
+type jsonField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+	omitZero  bool
+}
+
+// jsonFields returns the fields encoding/json would encode for the struct
+// type t, in order. Embedded structs without a name in their tag have their
+// fields listed in their place, as encoding/json does; unlike encoding/json,
+// conflicting names are not resolved.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" && isPlainStruct(sf.Type) {
+			for _, f := range jsonFields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				fields = append(fields, f)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		omitEmpty, omitZero := false, false
+		for opts != "" {
+			var o string
+			o, opts, _ = strings.Cut(opts, ",")
+			omitEmpty = omitEmpty || o == "omitempty"
+			omitZero = omitZero || o == "omitzero"
+		}
+		fields = append(fields, jsonField{name, []int{i}, omitEmpty, omitZero})
+	}
+	return fields
+}
+
+// omits reports whether encoding/json leaves the field out when its value is
+// v, because of its "omitempty" or "omitzero" tag option.
+func (f jsonField) omits(v reflect.Value) bool {
+	if f.omitEmpty && isEmptyJSONValue(v) {
+		return true
+	}
+	if !f.omitZero {
+		return false
+	}
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return (v.Kind() == reflect.Pointer && v.IsNil()) || z.IsZero()
+	}
+	return v.IsZero()
+}
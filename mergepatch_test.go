@@ -0,0 +1,122 @@
+package opt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mpAddress struct {
+	City Option[string] `json:"city"`
+	Zip  Option[string] `json:"zip"`
+}
+
+type mpMeta struct {
+	Version Option[int] `json:"version"`
+}
+
+type mpUser struct {
+	mpMeta
+	Name     Option[string]         `json:"name"`
+	Nickname Option[Option[string]] `json:"nickname"`
+	Address  mpAddress              `json:"address"`
+	Billing  Option[mpAddress]      `json:"billing"`
+	Since    Option[time.Time]      `json:"since"`
+	Kind     string                 `json:"kind,omitempty"`
+	Ignored  Option[string]         `json:"-"`
+	internal Option[string]
+}
+
+func TestToMergePatch(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"empty", mpUser{}, `{"kind":""}`},
+		{
+			name: "set",
+			in: &mpUser{
+				mpMeta:   mpMeta{Version: Some(2)},
+				Name:     Some("ann"),
+				Nickname: Some(Some("annie")),
+				Address:  mpAddress{City: Some("Oslo")},
+				Since:    Some(since),
+				Kind:     "admin",
+				Ignored:  Some("x"),
+				internal: Some("x"),
+			},
+			want: `{"version":2,"name":"ann","nickname":"annie","address":{"city":"Oslo"},"since":"2024-03-01T00:00:00Z","kind":"admin"}`,
+		},
+		{
+			name: "null",
+			in:   mpUser{Nickname: Some(None[string]()), Billing: Some(mpAddress{Zip: Some("0150")})},
+			want: `{"nickname":null,"billing":{"city":null,"zip":"0150"},"kind":""}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToMergePatch(tt.in)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.want, string(got))
+		})
+	}
+
+	_, err := ToMergePatch(1)
+	require.EqualError(t, err, "opt: ToMergePatch: patch must be a struct or a pointer to one, not int")
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	type profile struct {
+		Name    string            `json:"name"`
+		Age     Option[int]       `json:"age"`
+		Email   Option[string]    `json:"email"`
+		Address mpAddress         `json:"address"`
+		Billing Option[mpAddress] `json:"billing"`
+	}
+	p := profile{
+		Name:    "ann",
+		Age:     Some(30),
+		Email:   Some("ann@example.com"),
+		Address: mpAddress{City: Some("Oslo"), Zip: Some("0150")},
+	}
+	err := ApplyMergePatch(&p, []byte(`{
+		"age": 31,
+		"email": null,
+		"address": {"zip": null},
+		"billing": {"city": "Bergen", "zip": null},
+		"unknown": true
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, profile{
+		Name:    "ann",
+		Age:     Some(31),
+		Address: mpAddress{City: Some("Oslo")},
+		Billing: Some(mpAddress{City: Some("Bergen")}),
+	}, p)
+
+	require.NoError(t, ApplyMergePatch(&p, []byte(`{"billing": {"zip": "5003"}, "NAME": "bob"}`)))
+	require.Equal(t, Some(mpAddress{City: Some("Bergen"), Zip: Some("5003")}), p.Billing)
+	require.Equal(t, "bob", p.Name)
+
+	require.EqualError(t, ApplyMergePatch(p, []byte(`{}`)), "opt: ApplyMergePatch: dst must be a non-nil pointer to a struct, not opt.profile")
+	require.EqualError(t, ApplyMergePatch(&p, []byte(`[]`)), "opt: ApplyMergePatch: /: patch is not a JSON object")
+	require.EqualError(t, ApplyMergePatch(&p, []byte(`{"address": {"city": 1}}`)),
+		"opt: ApplyMergePatch: /address/city: json: cannot unmarshal number into Go value of type string")
+}
+
+func TestMergePatchRoundTrip(t *testing.T) {
+	want := mpUser{
+		Name:     Some("ann"),
+		Nickname: Some(None[string]()),
+		Address:  mpAddress{Zip: Some("0150")},
+		Kind:     "admin",
+	}
+	data, err := ToMergePatch(want)
+	require.NoError(t, err)
+	var got mpUser
+	require.NoError(t, ApplyMergePatch(&got, data))
+	require.Equal(t, want, got)
+}
@@ -0,0 +1,36 @@
+package opt
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// EncodeMsgpack implements msgpack.CustomEncoder from
+// github.com/vmihailenco/msgpack/v5. None is encoded as nil, and a Some value
+// is encoded with enc, so it follows the settings enc was configured with.
+// Fields tagged with "omitempty" leave None values out entirely.
+func (o Option[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !o.ok {
+		return enc.EncodeNil()
+	}
+	return enc.Encode(o.v)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder. nil is decoded as None, and
+// anything else is decoded with dec into a Some.
+func (o *Option[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if code == msgpcode.Nil {
+		*o = None[T]()
+		return dec.DecodeNil()
+	}
+	var v T
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
@@ -0,0 +1,83 @@
+package opt
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpack(t *testing.T) {
+	type Inner struct {
+		Host string
+	}
+	type TestStruct struct {
+		Foo   string
+		Bar   Option[int]
+		Baz   Option[Inner]
+		Empty Option[string] `msgpack:",omitempty"`
+	}
+
+	t.Run("None encodes as nil", func(t *testing.T) {
+		data, err := msgpack.Marshal(None[int]())
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xc0}, data)
+	})
+	t.Run("omitempty", func(t *testing.T) {
+		data, err := msgpack.Marshal(TestStruct{})
+		require.NoError(t, err)
+
+		var m map[string]any
+		require.NoError(t, msgpack.Unmarshal(data, &m))
+		require.Equal(t, map[string]any{"Foo": "", "Bar": nil, "Baz": nil}, m)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		in := TestStruct{
+			Foo:   "beep",
+			Bar:   Some(5),
+			Baz:   Some(Inner{Host: "localhost"}),
+			Empty: Some(""),
+		}
+		data, err := msgpack.Marshal(in)
+		require.NoError(t, err)
+
+		var out TestStruct
+		require.NoError(t, msgpack.Unmarshal(data, &out))
+		require.Equal(t, in, out)
+
+		data, err = msgpack.Marshal(TestStruct{Foo: "bap"})
+		require.NoError(t, err)
+		out = TestStruct{Bar: Some(1)}
+		require.NoError(t, msgpack.Unmarshal(data, &out))
+		require.Equal(t, "bap", out.Foo)
+		require.True(t, out.Bar.None())
+		require.True(t, out.Baz.None())
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		data, err := msgpack.Marshal(map[string]any{"Bar": "nope"})
+		require.NoError(t, err)
+		var out TestStruct
+		require.Error(t, msgpack.Unmarshal(data, &out))
+	})
+}
+
+func TestMsgpackUsesEncoder(t *testing.T) {
+	type Inner struct {
+		Host string `json:"host"`
+	}
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	require.NoError(t, enc.Encode(Some(Inner{Host: "localhost"})))
+
+	var m map[string]any
+	require.NoError(t, msgpack.Unmarshal(buf.Bytes(), &m))
+	require.Equal(t, map[string]any{"host": "localhost"}, m)
+
+	var o Option[Inner]
+	dec := msgpack.NewDecoder(&buf)
+	dec.SetCustomStructTag("json")
+	require.NoError(t, dec.Decode(&o))
+	require.Equal(t, Some(Inner{Host: "localhost"}), o)
+}
@@ -0,0 +1,51 @@
+package opt
+
+import "net/netip"
+
+// FromAddr returns Some(a), or None if a is the zero Addr, which is not a
+// valid address.
+func FromAddr(a netip.Addr) Option[netip.Addr] {
+	return FromMaybe(a, a.IsValid())
+}
+
+// FromPrefix returns Some(p), or None if p is not a valid prefix, such as the
+// zero Prefix.
+func FromPrefix(p netip.Prefix) Option[netip.Prefix] {
+	return FromMaybe(p, p.IsValid())
+}
+
+// FromAddrPort returns Some(ap), or None if the address of ap is not valid,
+// as for the zero AddrPort.
+func FromAddrPort(ap netip.AddrPort) Option[netip.AddrPort] {
+	return FromMaybe(ap, ap.IsValid())
+}
+
+// ParseAddrOpt parses s with netip.ParseAddr. An empty s is None, and so is
+// an invalid one; use netip.ParseAddr directly to find out what is wrong
+// with it:
+//
+//	dns := opt.ParseAddrOpt(os.Getenv("DNS_SERVER"))
+func ParseAddrOpt(s string) Option[netip.Addr] {
+	return parseOpt(s, netip.ParseAddr)
+}
+
+// ParsePrefixOpt is like ParseAddrOpt, for netip.ParsePrefix.
+func ParsePrefixOpt(s string) Option[netip.Prefix] {
+	return parseOpt(s, netip.ParsePrefix)
+}
+
+// ParseAddrPortOpt is like ParseAddrOpt, for netip.ParseAddrPort.
+func ParseAddrPortOpt(s string) Option[netip.AddrPort] {
+	return parseOpt(s, netip.ParseAddrPort)
+}
+
+func parseOpt[T any](s string, parse func(string) (T, error)) Option[T] {
+	if s == "" {
+		return None[T]()
+	}
+	v, err := parse(s)
+	if err != nil {
+		return None[T]()
+	}
+	return Some(v)
+}
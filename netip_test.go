@@ -0,0 +1,55 @@
+package opt
+
+import (
+	"encoding/json"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromNetip(t *testing.T) {
+	addr := netip.MustParseAddr("10.0.0.1")
+	require.Equal(t, Some(addr), FromAddr(addr))
+	require.Equal(t, None[netip.Addr](), FromAddr(netip.Addr{}))
+
+	prefix := netip.MustParsePrefix("10.0.0.0/8")
+	require.Equal(t, Some(prefix), FromPrefix(prefix))
+	require.Equal(t, None[netip.Prefix](), FromPrefix(netip.Prefix{}))
+	require.Equal(t, None[netip.Prefix](), FromPrefix(netip.PrefixFrom(addr, 33)))
+
+	ap := netip.MustParseAddrPort("[::1]:53")
+	require.Equal(t, Some(ap), FromAddrPort(ap))
+	require.Equal(t, None[netip.AddrPort](), FromAddrPort(netip.AddrPort{}))
+}
+
+func TestParseNetipOpt(t *testing.T) {
+	require.Equal(t, Some(netip.MustParseAddr("::1")), ParseAddrOpt("::1"))
+	require.Equal(t, None[netip.Addr](), ParseAddrOpt(""))
+	bad := ParseAddrOpt("10.0.0.256")
+	require.True(t, bad.None())
+
+	require.Equal(t, Some(netip.MustParsePrefix("fd00::/8")), ParsePrefixOpt("fd00::/8"))
+	require.Equal(t, None[netip.Prefix](), ParsePrefixOpt(""))
+	require.True(t, ParsePrefixOpt("10.0.0.0/33").None())
+
+	require.Equal(t, Some(netip.MustParseAddrPort("1.1.1.1:853")), ParseAddrPortOpt("1.1.1.1:853"))
+	require.Equal(t, None[netip.AddrPort](), ParseAddrPortOpt(""))
+	require.True(t, ParseAddrPortOpt("1.1.1.1").None())
+}
+
+func TestNetipJSON(t *testing.T) {
+	type config struct {
+		DNS     Option[netip.Addr]     `json:"dns"`
+		Subnet  Option[netip.Prefix]   `json:"subnet"`
+		Gateway Option[netip.AddrPort] `json:"gateway"`
+	}
+	in := config{DNS: ParseAddrOpt("1.1.1.1"), Subnet: ParsePrefixOpt("10.0.0.0/24")}
+	data, err := json.Marshal(in)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"dns":"1.1.1.1","subnet":"10.0.0.0/24","gateway":null}`, string(data))
+
+	var out config
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Equal(t, in, out)
+}
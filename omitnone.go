@@ -0,0 +1,175 @@
+package opt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// MarshalOmitNone is json.Marshal, except that struct fields holding an
+// Option that is None are left out rather than encoded as null, wherever they
+// are in v. It is for APIs that tell a null member apart from a missing one,
+// and does what the "omitzero" tag option does since Go 1.24 without needing
+// the tag, or Go 1.24:
+//
+//	data, err := opt.MarshalOmitNone(UpdateUserRequest{Name: opt.Some("ann")})
+//	// {"name":"ann"}
+//
+// Types that do not contain an Option, and types that implement
+// json.Marshaler or encoding.TextMarshaler, are encoded by json.Marshal as
+// they are. Struct fields are named and left out with "omitempty" as
+// encoding/json does, but the ",string" tag option is not supported.
+func MarshalOmitNone(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeOmitNone(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, fmt.Errorf("opt: MarshalOmitNone: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeOmitNone(buf *bytes.Buffer, rv reflect.Value) error {
+	if !rv.IsValid() || !containsOption(rv.Type(), nil) {
+		return writeJSON(buf, rv)
+	}
+	if optreflect.Is(rv.Type()) {
+		x, some := optreflect.Get(rv)
+		if !some {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeOmitNone(buf, x)
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeOmitNone(buf, rv.Elem())
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOmitNone(buf, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case reflect.Map:
+		if rv.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		// Let encoding/json encode and sort the keys.
+		m := reflect.MakeMapWithSize(reflect.MapOf(rv.Type().Key(), reflect.TypeOf(json.RawMessage(nil))), rv.Len())
+		for it := rv.MapRange(); it.Next(); {
+			var elem bytes.Buffer
+			if err := writeOmitNone(&elem, it.Value()); err != nil {
+				return err
+			}
+			m.SetMapIndex(it.Key(), reflect.ValueOf(json.RawMessage(elem.Bytes())))
+		}
+		return writeJSON(buf, m)
+	case reflect.Struct:
+		buf.WriteByte('{')
+		n := 0
+		for _, f := range jsonFields(rv.Type()) {
+			fv := rv.FieldByIndex(f.index)
+			if optreflect.Is(fv.Type()) {
+				if _, some := optreflect.Get(fv); !some {
+					continue
+				}
+			} else if f.omitEmpty && isEmptyJSONValue(fv) {
+				continue
+			}
+			if n > 0 {
+				buf.WriteByte(',')
+			}
+			name, _ := json.Marshal(f.name)
+			buf.Write(name)
+			buf.WriteByte(':')
+			if err := writeOmitNone(buf, fv); err != nil {
+				return err
+			}
+			n++
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+	return writeJSON(buf, rv)
+}
+
+func writeJSON(buf *bytes.Buffer, rv reflect.Value) error {
+	var v any
+	if rv.IsValid() {
+		v = rv.Interface()
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// containsOption reports whether values of type t can hold an Option that
+// MarshalOmitNone has to treat differently from json.Marshal. Interfaces
+// might, so they are taken to.
+func containsOption(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if optreflect.Is(t) {
+		return true
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return false
+	}
+	if seen[t] {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		if seen == nil {
+			seen = map[reflect.Type]bool{}
+		}
+		seen[t] = true
+		return containsOption(t.Elem(), seen)
+	case reflect.Struct:
+		if seen == nil {
+			seen = map[reflect.Type]bool{}
+		}
+		seen[t] = true
+		for _, f := range jsonFields(t) {
+			if containsOption(t.FieldByIndex(f.index).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isEmptyJSONValue reports whether encoding/json considers v empty for the
+// "omitempty" tag option.
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
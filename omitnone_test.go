@@ -0,0 +1,80 @@
+package opt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalOmitNone(t *testing.T) {
+	type item struct {
+		SKU   string         `json:"sku"`
+		Note  Option[string] `json:"note"`
+		Count int            `json:"count,omitempty"`
+	}
+	type node struct {
+		Name     Option[string] `json:"name"`
+		Children []node         `json:"children,omitempty"`
+	}
+	type order struct {
+		mpMeta
+		ID       string                 `json:"id"`
+		Coupon   Option[string]         `json:"coupon"`
+		Shipped  Option[time.Time]      `json:"shipped"`
+		Items    []item                 `json:"items"`
+		ByKey    map[string]item        `json:"by_key"`
+		Gift     *item                  `json:"gift"`
+		Extra    any                    `json:"extra"`
+		Nested   Option[item]           `json:"nested"`
+		Nullable Option[Option[string]] `json:"nullable"`
+		Tree     node                   `json:"tree"`
+		Skipped  Option[string]         `json:"-"`
+	}
+
+	shipped := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"empty", order{}, `{"id":"","items":null,"by_key":null,"gift":null,"extra":null,"tree":{}}`},
+		{
+			name: "full",
+			in: &order{
+				mpMeta:   mpMeta{Version: Some(1)},
+				ID:       "o1",
+				Shipped:  Some(shipped),
+				Items:    []item{{SKU: "a"}, {SKU: "b", Note: Some("fragile"), Count: 2}},
+				ByKey:    map[string]item{"b": {SKU: "b"}, "a": {SKU: "a", Note: Some("x")}},
+				Gift:     &item{SKU: "g"},
+				Extra:    item{SKU: "e"},
+				Nested:   Some(item{SKU: "n"}),
+				Nullable: Some(None[string]()),
+				Tree:     node{Children: []node{{Name: Some("leaf")}}},
+				Skipped:  Some("x"),
+			},
+			want: `{"version":1,"id":"o1","shipped":"2024-03-01T00:00:00Z",` +
+				`"items":[{"sku":"a"},{"sku":"b","note":"fragile","count":2}],` +
+				`"by_key":{"a":{"sku":"a","note":"x"},"b":{"sku":"b"}},` +
+				`"gift":{"sku":"g"},"extra":{"sku":"e"},"nested":{"sku":"n"},"nullable":null,` +
+				`"tree":{"children":[{"name":"leaf"}]}}`,
+		},
+		{"no options", map[string]int{"a": 1}, `{"a":1}`},
+		{"bare none", None[int](), `null`},
+		{"bare some", Some(item{SKU: "s"}), `{"sku":"s"}`},
+		{"nil", nil, `null`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalOmitNone(tt.in)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+			require.True(t, json.Valid(got))
+		})
+	}
+
+	_, err := MarshalOmitNone(struct{ C Option[chan int] }{Some(make(chan int))})
+	require.ErrorContains(t, err, "opt: MarshalOmitNone: json: unsupported type: chan int")
+}
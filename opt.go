@@ -3,7 +3,9 @@ package opt
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/jsonlite"
 )
 
 // Join allows for two Options to be used to create a new value if they are both
@@ -24,6 +26,62 @@ func Map[I, O any](in Option[I], mapfn func(I) Option[O]) Option[O] {
 	return None[O]()
 }
 
+// Apply calls the function in f with the value in a if both are present, and
+// returns None otherwise. With a curried function it combines any number of
+// Options without nesting Join calls:
+//
+//	newUser := func(name string) func(int) User {
+//		return func(age int) User { return User{Name: name, Age: age} }
+//	}
+//	user := opt.Apply(opt.Apply(opt.Some(newUser), name), age)
+func Apply[A, B any](f Option[func(A) B], a Option[A]) Option[B] {
+	return Join(f, a, func(f func(A) B, a A) B { return f(a) })
+}
+
+// Lift turns fn into a function over Options: the result calls fn with the
+// value of its argument if there is one, and returns None otherwise. It lets
+// existing functions be reused in Option pipelines without a Map closure at
+// every call site:
+//
+//	upper := opt.Lift(strings.ToUpper)
+//	nick := upper(user.Nickname)
+func Lift[A, B any](fn func(A) B) func(Option[A]) Option[B] {
+	return func(a Option[A]) Option[B] {
+		return Map(a, func(a A) Option[B] { return Some(fn(a)) })
+	}
+}
+
+// Lift2 is Lift for functions of two arguments: the result calls fn only if
+// both of its arguments are Some.
+//
+//	area := opt.Lift2(func(w, h float64) float64 { return w * h })
+//	a := area(width, height)
+func Lift2[A, B, R any](fn func(A, B) R) func(Option[A], Option[B]) Option[R] {
+	return func(a Option[A], b Option[B]) Option[R] {
+		return Join(a, b, fn)
+	}
+}
+
+// Lift3 is Lift for functions of three arguments: the result calls fn only if
+// all of its arguments are Some.
+func Lift3[A, B, C, R any](fn func(A, B, C) R) func(Option[A], Option[B], Option[C]) Option[R] {
+	return func(a Option[A], b Option[B], c Option[C]) Option[R] {
+		return Map(a, func(a A) Option[R] {
+			return Join(b, c, func(b B, c C) R { return fn(a, b, c) })
+		})
+	}
+}
+
+// Fold calls foldfn with init and the present value of an option if there is
+// one, and returns init as it is otherwise. This lets an Option take part in
+// an accumulation the same way a slice of zero or one values would.
+func Fold[T, R any](o Option[T], init R, foldfn func(R, T) R) R {
+	if o.Some() {
+		return foldfn(init, o.Unwrap())
+	}
+	return init
+}
+
 // Coalesce will take 0 or more Option and will return the first one that is
 // Some value.
 func Coalesce[T any](os ...Option[T]) Option[T] {
@@ -35,6 +93,23 @@ func Coalesce[T any](os ...Option[T]) Option[T] {
 	return None[T]()
 }
 
+// CoalesceFunc is like Coalesce, but calls fns in order only until one of
+// them returns Some, so fallbacks that are expensive to compute are not
+// computed unless they are needed:
+//
+//	addr := opt.CoalesceFunc(
+//		func() opt.Option[string] { return cfg.Addr },
+//		func() opt.Option[string] { return lookupAddr(ctx) },
+//	)
+func CoalesceFunc[T any](fns ...func() Option[T]) Option[T] {
+	for _, fn := range fns {
+		if o := fn(); o.Some() {
+			return o
+		}
+	}
+	return None[T]()
+}
+
 // Equal will compare the value in two options and check if their equal. If both
 // are none, that is interpretted as "equal."
 func Equal[T comparable](a, b Option[T]) bool {
@@ -47,6 +122,19 @@ func Equal[T comparable](a, b Option[T]) bool {
 	return a.Unwrap() == b.Unwrap()
 }
 
+// EqualFunc is like Equal, but compares the values in two options with eq,
+// which makes it usable with values that are not comparable, or that need a
+// looser comparison than ==.
+func EqualFunc[A, B any](a Option[A], b Option[B], eq func(A, B) bool) bool {
+	if a.None() && b.None() {
+		return true
+	}
+	if a.None() || b.None() {
+		return false
+	}
+	return eq(a.Unwrap(), b.Unwrap())
+}
+
 // FromPointer will take in a pointer to a value and dereference it if it is
 // not nil and return a Some[T](), if it is nil it will return None[T]().
 func FromPointer[T any](v *T) Option[T] {
@@ -56,6 +144,17 @@ func FromPointer[T any](v *T) Option[T] {
 	return Some(*v)
 }
 
+// ToPointer is the inverse of FromPointer: it returns a pointer to a copy of
+// the contained value if there is one, and nil otherwise. It bridges Options
+// with code (generated or not) that models optional values as pointers.
+func ToPointer[T any](o Option[T]) *T {
+	if !o.ok {
+		return nil
+	}
+	v := o.v
+	return &v
+}
+
 // FromMaybe will take a tuple of a value and a bool representing the value's
 // status and convert it to an Option. If true, a Some[T] is returned, otherwise
 // None[T] is returned.
@@ -76,6 +175,24 @@ func Some[T any](v T) Option[T] {
 	return Option[T]{ok: true, v: v}
 }
 
+// SomeNonNil is like Some, but returns None if v is nil: a nil interface, or a
+// nil pointer, map, slice, func or channel. Some would wrap such a value just
+// the same, leaving it to panic wherever it is eventually used, so
+// SomeNonNil is better suited to values coming from outside:
+//
+//	cfg := opt.SomeNonNil(loadConfig())
+func SomeNonNil[T any](v T) Option[T] {
+	switch rv := reflect.ValueOf(any(v)); rv.Kind() {
+	case reflect.Invalid:
+		return None[T]()
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if rv.IsNil() {
+			return None[T]()
+		}
+	}
+	return Some(v)
+}
+
 // Option represents an optional value. Every Option has either has something or
 // has none.
 // If None() reports true, then calls to Unwrap() will panic. To prevent this,
@@ -91,8 +208,10 @@ func Some[T any](v T) Option[T] {
 //
 // Inspired by Rust's Option<T>: https://doc.rust-lang.org/std/option/index.html
 type Option[T any] struct {
-	ok bool
+	// v comes first because ORMs such as GORM infer the column type of a
+	// driver.Valuer from its first field, as they do for sql.NullString.
 	v  T
+	ok bool
 }
 
 // Some reports whether there is a value contained or not. A returned
@@ -110,13 +229,19 @@ func (o Option[T]) None() bool {
 	return !o.ok
 }
 
+// IsZero reports whether the Option is None. Encoders that honor an
+// "omitzero" struct tag option use this to leave None values out entirely.
+func (o Option[T]) IsZero() bool {
+	return !o.ok
+}
+
 // Unwrap retrieves the underlying value if there is one. Unwrap WILL PANIC
 // if there is no value.
 func (o Option[T]) Unwrap() T {
 	if o.ok {
 		return o.v
 	}
-	panic(fmt.Sprintf("%T.Unwrap: no value to unwrap", o))
+	panic(unwrapPanic(o))
 }
 
 // UnwrapOr is a safer version of Unwrap() that will return the provided
@@ -151,15 +276,34 @@ func (o Option[T]) UnwrapOrZero() T {
 	return zv
 }
 
+// Ptr returns a pointer to a copy of the contained value, or nil if there is
+// none, the same as ToPointer. Templates can use it to check for presence
+// without treating a Some zero value as absent:
+//
+//	{{ with .Nickname.Ptr }}Hi {{ . }},{{ else }}Hi there,{{ end }}
+func (o Option[T]) Ptr() *T {
+	return ToPointer(o)
+}
+
+// ToSlice returns a slice holding the contained value, or nil if there is
+// none. It makes Options easy to append and to pass to variadic functions:
+//
+//	tags = append(tags, extra.ToSlice()...)
+func (o Option[T]) ToSlice() []T {
+	if !o.ok {
+		return nil
+	}
+	return []T{o.v}
+}
+
 // MarshalJSON implements json.Marshaler
 func (o Option[T]) MarshalJSON() ([]byte, error) {
-	if o.ok {
-		return json.Marshal(o.v)
-	}
-	return json.RawMessage("null"), nil
+	return o.AppendJSON(nil)
 }
 
-// UnmarshalJSON implements json.Unmarshaler
+// UnmarshalJSON implements json.Unmarshaler. Plain strings, booleans,
+// integers and floating-point numbers are decoded without going through
+// json.Unmarshal, which is much faster; anything else is left to it.
 func (o *Option[T]) UnmarshalJSON(data []byte) error {
 	var v T
 	o.v = v
@@ -168,5 +312,8 @@ func (o *Option[T]) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 	o.ok = true
+	if jsonlite.Unmarshal(data, &o.v, false) {
+		return nil
+	}
 	return json.Unmarshal(data, &o.v)
 }
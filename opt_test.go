@@ -2,6 +2,10 @@ package opt
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -92,6 +96,70 @@ func TestMap(t *testing.T) {
 	})
 }
 
+func TestApply(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+	newUser := func(name string) func(int) user {
+		return func(age int) user { return user{Name: name, Age: age} }
+	}
+	f := Some(newUser)
+
+	require.Equal(t, Some(user{"ann", 30}), Apply(Apply(f, Some("ann")), Some(30)))
+	require.True(t, Apply(Apply(f, None[string]()), Some(30)).None())
+	require.True(t, Apply(Apply(f, Some("ann")), None[int]()).None())
+	require.True(t, Apply(None[func(int) int](), Some(1)).None())
+}
+
+func TestLift(t *testing.T) {
+	upper := Lift(strings.ToUpper)
+	require.Equal(t, Some("ANN"), upper(Some("ann")))
+	require.True(t, upper(None[string]()).None())
+
+	length := Lift(func(s string) int { return len(s) })
+	require.Equal(t, Some(0), length(Some("")))
+}
+
+func TestLift2(t *testing.T) {
+	area := Lift2(func(w, h float64) float64 { return w * h })
+	require.Equal(t, Some(6.0), area(Some(2.0), Some(3.0)))
+	require.True(t, area(None[float64](), Some(3.0)).None())
+	require.True(t, area(Some(2.0), None[float64]()).None())
+}
+
+func TestLift3(t *testing.T) {
+	date := Lift3(func(y, m, d int) string { return fmt.Sprintf("%04d-%02d-%02d", y, m, d) })
+	require.Equal(t, Some("2024-03-01"), date(Some(2024), Some(3), Some(1)))
+	require.True(t, date(None[int](), Some(3), Some(1)).None())
+	require.True(t, date(Some(2024), None[int](), Some(1)).None())
+	require.True(t, date(Some(2024), Some(3), None[int]()).None())
+}
+
+func TestFold(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		var x Option[int]
+		y := Fold(x, "total:", func(acc string, in int) string {
+			panic("should not be called")
+		})
+		require.Equal(t, "total:", y)
+	})
+	t.Run("Some", func(t *testing.T) {
+		x := Some(5)
+		y := Fold(x, "total:", func(acc string, in int) string {
+			return acc + strconv.Itoa(in)
+		})
+		require.Equal(t, "total:5", y)
+	})
+	t.Run("Accumulate", func(t *testing.T) {
+		total := 0
+		for _, o := range []Option[int]{Some(1), None[int](), Some(2)} {
+			total = Fold(o, total, func(acc, in int) int { return acc + in })
+		}
+		require.Equal(t, 3, total)
+	})
+}
+
 func TestEqual(t *testing.T) {
 	t.Run(`Some(1) == Some(1)`, func(t *testing.T) {
 		a := Some(1)
@@ -120,6 +188,19 @@ func TestEqual(t *testing.T) {
 	})
 }
 
+func TestEqualFunc(t *testing.T) {
+	eq := slices.Equal[[]int]
+	require.True(t, EqualFunc(Some([]int{1}), Some([]int{1}), eq))
+	require.False(t, EqualFunc(Some([]int{1}), Some([]int{2}), eq))
+	require.False(t, EqualFunc(None[[]int](), Some([]int(nil)), eq))
+	require.False(t, EqualFunc(Some([]int(nil)), None[[]int](), eq))
+	require.True(t, EqualFunc(None[[]int](), None[[]int](), eq))
+
+	require.True(t, EqualFunc(Some(1), Some("1"), func(a int, b string) bool {
+		return strconv.Itoa(a) == b
+	}))
+}
+
 func TestFromPointer(t *testing.T) {
 	x := new(int64)
 	*x = 5
@@ -136,6 +217,50 @@ func TestFromPointer(t *testing.T) {
 	})
 }
 
+func TestToPointer(t *testing.T) {
+	x := Some(int64(5))
+	p := ToPointer(x)
+	require.NotNil(t, p)
+	require.Equal(t, int64(5), *p)
+	require.Equal(t, x, FromPointer(p))
+
+	require.Nil(t, ToPointer(None[string]()))
+}
+
+func TestSomeNonNil(t *testing.T) {
+	n := 1
+	var err error
+	var nilErr *json.SyntaxError
+	tests := []struct {
+		name string
+		some bool
+		got  bool
+	}{
+		{"nil pointer", false, SomeNonNil[*int](nil).Some()},
+		{"pointer", true, SomeNonNil(&n).Some()},
+		{"nil interface", false, SomeNonNil(err).Some()},
+		{"typed nil in interface", false, SomeNonNil[error](nilErr).Some()},
+		{"nil map", false, SomeNonNil[map[string]int](nil).Some()},
+		{"empty map", true, SomeNonNil(map[string]int{}).Some()},
+		{"nil slice", false, SomeNonNil[[]int](nil).Some()},
+		{"nil func", false, SomeNonNil[func()](nil).Some()},
+		{"nil chan", false, SomeNonNil[chan int](nil).Some()},
+		{"zero int", true, SomeNonNil(0).Some()},
+		{"empty string", true, SomeNonNil("").Some()},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.some, tt.got, tt.name)
+	}
+	require.Equal(t, 1, *SomeNonNil(&n).Unwrap())
+}
+
+func TestToSlice(t *testing.T) {
+	require.Nil(t, None[int]().ToSlice())
+	require.Equal(t, []int{0}, Some(0).ToSlice())
+	require.Equal(t, []string{"a", "b"}, append([]string{"a"}, Some("b").ToSlice()...))
+	require.Equal(t, []string{"a"}, append([]string{"a"}, None[string]().ToSlice()...))
+}
+
 func TestOption(t *testing.T) {
 	t.Run("zero value is valid", func(t *testing.T) {
 		var ov Option[int]
@@ -192,6 +317,27 @@ func TestCoalesce(t *testing.T) {
 	require.Equal(t, int(5), d.UnwrapOr(5))
 }
 
+func TestCoalesceFunc(t *testing.T) {
+	var calls []int
+	fn := func(i int, o Option[int]) func() Option[int] {
+		return func() Option[int] {
+			calls = append(calls, i)
+			return o
+		}
+	}
+
+	a := CoalesceFunc(fn(0, None[int]()), fn(1, Some(1)), fn(2, Some(2)))
+	require.Equal(t, Some(1), a)
+	require.Equal(t, []int{0, 1}, calls)
+
+	calls = nil
+	b := CoalesceFunc(fn(0, None[int]()), fn(1, None[int]()))
+	require.True(t, b.None())
+	require.Equal(t, []int{0, 1}, calls)
+
+	require.True(t, CoalesceFunc[int]().None())
+}
+
 func TestJSON(t *testing.T) {
 	type TestStruct struct {
 		Foo string
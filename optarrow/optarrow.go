@@ -0,0 +1,193 @@
+// Package optarrow converts between slices of opt.Option and Apache Arrow
+// arrays, using github.com/apache/arrow-go. None is stored as a null slot in
+// the validity bitmap of an array, and null slots are read back as None:
+//
+//	arr := optarrow.NewArray(memory.DefaultAllocator, ages)
+//	defer arr.Release()
+//	...
+//	ages, err := optarrow.FromArray[int64](arr)
+package optarrow
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"code.nkcmr.net/opt"
+)
+
+// Value is the set of types that can be converted to and from Arrow arrays.
+// time.Time values are stored as timestamps with microsecond precision in
+// UTC.
+type Value interface {
+	bool |
+		int8 | int16 | int32 | int64 |
+		uint8 | uint16 | uint32 | uint64 |
+		float32 | float64 |
+		string | []byte | time.Time
+}
+
+// DataType returns the Arrow data type NewArray builds arrays of T with.
+func DataType[T Value]() arrow.DataType {
+	var v T
+	switch any(v).(type) {
+	case bool:
+		return arrow.FixedWidthTypes.Boolean
+	case int8:
+		return arrow.PrimitiveTypes.Int8
+	case int16:
+		return arrow.PrimitiveTypes.Int16
+	case int32:
+		return arrow.PrimitiveTypes.Int32
+	case int64:
+		return arrow.PrimitiveTypes.Int64
+	case uint8:
+		return arrow.PrimitiveTypes.Uint8
+	case uint16:
+		return arrow.PrimitiveTypes.Uint16
+	case uint32:
+		return arrow.PrimitiveTypes.Uint32
+	case uint64:
+		return arrow.PrimitiveTypes.Uint64
+	case float32:
+		return arrow.PrimitiveTypes.Float32
+	case float64:
+		return arrow.PrimitiveTypes.Float64
+	case string:
+		return arrow.BinaryTypes.String
+	case []byte:
+		return arrow.BinaryTypes.Binary
+	case time.Time:
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	}
+	panic("unreachable")
+}
+
+// NewArray returns an array of the values of os, with a null in place of each
+// None. The caller must release the array.
+func NewArray[T Value](mem memory.Allocator, os []opt.Option[T]) arrow.Array {
+	b := array.NewBuilder(mem, DataType[T]())
+	defer b.Release()
+	b.Reserve(len(os))
+	appendValue := appender[T](b)
+	for _, o := range os {
+		if v, ok := o.MaybeUnwrap(); ok {
+			appendValue(v)
+		} else {
+			b.AppendNull()
+		}
+	}
+	return b.NewArray()
+}
+
+func appender[T Value](b array.Builder) func(T) {
+	var f any
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		f = b.Append
+	case *array.Int8Builder:
+		f = b.Append
+	case *array.Int16Builder:
+		f = b.Append
+	case *array.Int32Builder:
+		f = b.Append
+	case *array.Int64Builder:
+		f = b.Append
+	case *array.Uint8Builder:
+		f = b.Append
+	case *array.Uint16Builder:
+		f = b.Append
+	case *array.Uint32Builder:
+		f = b.Append
+	case *array.Uint64Builder:
+		f = b.Append
+	case *array.Float32Builder:
+		f = b.Append
+	case *array.Float64Builder:
+		f = b.Append
+	case *array.StringBuilder:
+		f = b.Append
+	case *array.BinaryBuilder:
+		f = b.Append
+	case *array.TimestampBuilder:
+		f = func(v time.Time) { b.Append(arrow.Timestamp(v.UnixMicro())) }
+	}
+	return f.(func(T))
+}
+
+// FromArray returns the values of arr, with None in place of each null. arr
+// must hold values of type T: an array of the type DataType returns, or for
+// time.Time, timestamps of any unit, which are returned in UTC.
+func FromArray[T Value](arr arrow.Array) ([]opt.Option[T], error) {
+	get := getter[T](arr)
+	if get == nil {
+		var v T
+		return nil, fmt.Errorf("optarrow: cannot read %s array as %T", arr.DataType(), v)
+	}
+	out := make([]opt.Option[T], arr.Len())
+	for i := range out {
+		if arr.IsValid(i) {
+			out[i] = opt.Some(get(i))
+		}
+	}
+	return out, nil
+}
+
+// FromChunked is FromArray for a chunked column, such as a column of an
+// arrow.Table, returning the values of all its chunks in order.
+func FromChunked[T Value](c *arrow.Chunked) ([]opt.Option[T], error) {
+	out := make([]opt.Option[T], 0, c.Len())
+	for _, chunk := range c.Chunks() {
+		os, err := FromArray[T](chunk)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, os...)
+	}
+	return out, nil
+}
+
+// getter returns a function returning the value at an index of arr, or nil if
+// arr does not hold values of type T.
+func getter[T Value](arr arrow.Array) func(int) T {
+	var f any
+	switch a := arr.(type) {
+	case *array.Boolean:
+		f = a.Value
+	case *array.Int8:
+		f = a.Value
+	case *array.Int16:
+		f = a.Value
+	case *array.Int32:
+		f = a.Value
+	case *array.Int64:
+		f = a.Value
+	case *array.Uint8:
+		f = a.Value
+	case *array.Uint16:
+		f = a.Value
+	case *array.Uint32:
+		f = a.Value
+	case *array.Uint64:
+		f = a.Value
+	case *array.Float32:
+		f = a.Value
+	case *array.Float64:
+		f = a.Value
+	case *array.String:
+		f = a.Value
+	case *array.Binary:
+		// Value returns a slice of the array's buffer, which is only valid
+		// until the array is released.
+		f = func(i int) []byte { return bytes.Clone(a.Value(i)) }
+	case *array.Timestamp:
+		unit := a.DataType().(*arrow.TimestampType).Unit
+		f = func(i int) time.Time { return a.Value(i).ToTime(unit) }
+	}
+	get, _ := f.(func(int) T)
+	return get
+}
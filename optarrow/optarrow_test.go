@@ -0,0 +1,91 @@
+package optarrow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+func roundTrip[T Value](t *testing.T, os []opt.Option[T]) {
+	t.Helper()
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	arr := NewArray(mem, os)
+	defer arr.Release()
+	require.True(t, arrow.TypeEqual(DataType[T](), arr.DataType()))
+	require.Equal(t, len(os), arr.Len())
+	for i, o := range os {
+		require.Equal(t, o.IsSome(), arr.IsValid(i), i)
+	}
+	got, err := FromArray[T](arr)
+	require.NoError(t, err)
+	require.Equal(t, os, got)
+}
+
+func TestRoundTrip(t *testing.T) {
+	roundTrip(t, []opt.Option[bool]{opt.Some(true), opt.None[bool](), opt.Some(false)})
+	roundTrip(t, []opt.Option[int8]{opt.Some[int8](-1), opt.None[int8]()})
+	roundTrip(t, []opt.Option[int16]{opt.None[int16](), opt.Some[int16](2)})
+	roundTrip(t, []opt.Option[int32]{opt.Some[int32](3)})
+	roundTrip(t, []opt.Option[int64]{opt.Some[int64](4), opt.None[int64](), opt.Some[int64](0)})
+	roundTrip(t, []opt.Option[uint8]{opt.Some[uint8](5)})
+	roundTrip(t, []opt.Option[uint16]{opt.Some[uint16](6)})
+	roundTrip(t, []opt.Option[uint32]{opt.Some[uint32](7)})
+	roundTrip(t, []opt.Option[uint64]{opt.None[uint64](), opt.Some[uint64](8)})
+	roundTrip(t, []opt.Option[float32]{opt.Some[float32](1.5)})
+	roundTrip(t, []opt.Option[float64]{opt.Some(2.5), opt.None[float64]()})
+	roundTrip(t, []opt.Option[string]{opt.Some("a"), opt.None[string](), opt.Some("")})
+	roundTrip(t, []opt.Option[[]byte]{opt.Some([]byte("a")), opt.None[[]byte]()})
+	roundTrip(t, []opt.Option[time.Time]{opt.Some(time.Date(2024, 5, 1, 12, 0, 0, 1000, time.UTC)), opt.None[time.Time]()})
+	roundTrip(t, []opt.Option[int64]{})
+}
+
+func TestFromArrayBinaryOutlivesArray(t *testing.T) {
+	arr := NewArray(memory.DefaultAllocator, []opt.Option[[]byte]{opt.Some([]byte("abc"))})
+	got, err := FromArray[[]byte](arr)
+	require.NoError(t, err)
+	arr.Release()
+	require.Equal(t, opt.Some([]byte("abc")), got[0])
+}
+
+func TestFromArrayTimestampUnits(t *testing.T) {
+	b := array.NewTimestampBuilder(memory.DefaultAllocator, &arrow.TimestampType{Unit: arrow.Second})
+	defer b.Release()
+	b.Append(60)
+	b.AppendNull()
+	arr := b.NewArray()
+	defer arr.Release()
+	got, err := FromArray[time.Time](arr)
+	require.NoError(t, err)
+	require.Equal(t, []opt.Option[time.Time]{opt.Some(time.Unix(60, 0).UTC()), opt.None[time.Time]()}, got)
+}
+
+func TestFromArrayMismatch(t *testing.T) {
+	arr := NewArray(memory.DefaultAllocator, []opt.Option[int64]{opt.Some[int64](1)})
+	defer arr.Release()
+	_, err := FromArray[int32](arr)
+	require.EqualError(t, err, "optarrow: cannot read int64 array as int32")
+}
+
+func TestFromChunked(t *testing.T) {
+	a := NewArray(memory.DefaultAllocator, []opt.Option[string]{opt.Some("a"), opt.None[string]()})
+	defer a.Release()
+	b := NewArray(memory.DefaultAllocator, []opt.Option[string]{opt.Some("b")})
+	defer b.Release()
+	c := arrow.NewChunked(arrow.BinaryTypes.String, []arrow.Array{a, b})
+	defer c.Release()
+
+	got, err := FromChunked[string](c)
+	require.NoError(t, err)
+	require.Equal(t, []opt.Option[string]{opt.Some("a"), opt.None[string](), opt.Some("b")}, got)
+
+	_, err = FromChunked[bool](c)
+	require.Error(t, err)
+}
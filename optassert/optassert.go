@@ -0,0 +1,66 @@
+// Package optassert provides testify-style assertions about opt.Option
+// values.
+//
+// Like the functions of github.com/stretchr/testify/assert, each one reports a
+// failure through t and returns whether it passed, so it can be combined with
+// the rest of a testify test:
+//
+//	optassert.SomeEqual(t, 42, cfg.Port)
+//	optassert.None(t, cfg.Proxy)
+//
+// Failure messages show Options as Some(x) or None.
+package optassert
+
+import (
+	"fmt"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/assert"
+)
+
+type tHelper interface {
+	Helper()
+}
+
+// Some asserts that o is Some.
+func Some[T any](t assert.TestingT, o opt.Option[T], msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if o.Some() {
+		return true
+	}
+	return assert.Fail(t, "Expected Some, but got None", msgAndArgs...)
+}
+
+// None asserts that o is None.
+func None[T any](t assert.TestingT, o opt.Option[T], msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if o.None() {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("Expected None, but got %s", format(o)), msgAndArgs...)
+}
+
+// SomeEqual asserts that o is Some of a value equal to want, as determined by
+// assert.ObjectsAreEqual.
+func SomeEqual[T any](t assert.TestingT, want T, o opt.Option[T], msgAndArgs ...any) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+	if got, ok := o.MaybeUnwrap(); ok && assert.ObjectsAreEqual(want, got) {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("Not equal: \n"+
+		"expected: %s\n"+
+		"actual  : %s", format(opt.Some(want)), format(o)), msgAndArgs...)
+}
+
+func format[T any](o opt.Option[T]) string {
+	if v, ok := o.MaybeUnwrap(); ok {
+		return fmt.Sprintf("Some(%#v)", v)
+	}
+	return "None"
+}
@@ -0,0 +1,51 @@
+package optassert
+
+import (
+	"fmt"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestSome(t *testing.T) {
+	r := &recorder{}
+	require.True(t, Some(r, opt.Some(0)))
+	require.Empty(t, r.errors)
+
+	require.False(t, Some(r, opt.None[int](), "port for %s", "api"))
+	require.Len(t, r.errors, 1)
+	require.Contains(t, r.errors[0], "Expected Some, but got None")
+	require.Contains(t, r.errors[0], "port for api")
+}
+
+func TestNone(t *testing.T) {
+	r := &recorder{}
+	require.True(t, None(r, opt.None[string]()))
+	require.Empty(t, r.errors)
+
+	require.False(t, None(r, opt.Some("proxy")))
+	require.Len(t, r.errors, 1)
+	require.Contains(t, r.errors[0], `Expected None, but got Some("proxy")`)
+}
+
+func TestSomeEqual(t *testing.T) {
+	r := &recorder{}
+	require.True(t, SomeEqual(r, []int{1}, opt.Some([]int{1})))
+	require.Empty(t, r.errors)
+
+	require.False(t, SomeEqual(r, 42, opt.Some(41)))
+	require.False(t, SomeEqual(r, 42, opt.None[int]()))
+	require.Len(t, r.errors, 2)
+	require.Contains(t, r.errors[0], "expected: Some(42)\n")
+	require.Contains(t, r.errors[0], "actual  : Some(41)")
+	require.Contains(t, r.errors[1], "actual  : None")
+}
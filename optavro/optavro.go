@@ -0,0 +1,116 @@
+// Package optavro maps opt.Option to Avro's nullable union, ["null", T], for
+// use with github.com/hamba/avro/v2.
+//
+// Marshal, Unmarshal, Encode and Decode encode and decode values, such as
+// structs, with Option fields. hamba/avro reads and writes a pointer as a
+// nullable union, so they work on a mirror type in which every Option[T] is
+// a *T, with None as null:
+//
+//	type User struct {
+//		Name     string             `avro:"name"`
+//		Nickname opt.Option[string] `avro:"nickname"`
+//	}
+//
+//	// {"type": "record", "name": "User", "fields": [
+//	//	{"name": "name", "type": "string"},
+//	//	{"name": "nickname", "type": ["null", "string"], "default": null}
+//	// ]}
+//	data, err := optavro.Marshal(schema, user)
+//
+// Nullable builds the union schema for an optional field. Native and
+// FromNative convert between an Option and the generic value hamba/avro reads
+// and writes for such a union, which is nil when None.
+package optavro
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/hamba/avro/v2"
+)
+
+var mapper = &shadow.Mapper{}
+
+// Marshal is like avro.Marshal but also accepts Options.
+func Marshal(schema avro.Schema, v any) ([]byte, error) {
+	return avro.Marshal(schema, mirror(v))
+}
+
+// Unmarshal is like avro.Unmarshal but also accepts Options.
+func Unmarshal(schema avro.Schema, data []byte, v any) error {
+	return mapper.Decode(v, func(out any) error {
+		return avro.Unmarshal(schema, data, out)
+	})
+}
+
+// Encode is like enc.Encode but also accepts Options, so that an Encoder
+// made from a configured avro.API can be used.
+func Encode(enc *avro.Encoder, v any) error {
+	return enc.Encode(mirror(v))
+}
+
+// Decode is like dec.Decode but also accepts Options.
+func Decode(dec *avro.Decoder, v any) error {
+	return mapper.Decode(v, func(out any) error {
+		return dec.Decode(out)
+	})
+}
+
+func mirror(v any) any {
+	if v == nil || !shadow.Needs(reflect.TypeOf(v)) {
+		return v
+	}
+	return mapper.To(reflect.ValueOf(v)).Interface()
+}
+
+// Nullable returns the schema of an optional value of the given schema: the
+// union ["null", schema]. If schema is already a nullable union it is returned
+// as is.
+func Nullable(schema avro.Schema) (*avro.UnionSchema, error) {
+	if u, ok := schema.(*avro.UnionSchema); ok && u.Nullable() {
+		return u, nil
+	}
+	return avro.NewUnionSchema([]avro.Schema{&avro.NullSchema{}, schema})
+}
+
+// MustNullable is like Nullable but panics if the union cannot be built.
+func MustNullable(schema avro.Schema) *avro.UnionSchema {
+	u, err := Nullable(schema)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Native returns the value of o to be used for a nullable union when encoding
+// generic Avro data: nil when None and the contained value when Some.
+func Native[T any](o opt.Option[T]) any {
+	if v, ok := o.MaybeUnwrap(); ok {
+		return v
+	}
+	return nil
+}
+
+// FromNative converts a decoded nullable union back into an Option. nil
+// becomes None. A Some value may either be given directly or as the
+// single-entry map naming its union branch, e.g. {"string": "x"}.
+func FromNative[T any](v any) (opt.Option[T], error) {
+	if v == nil {
+		return opt.None[T](), nil
+	}
+	if m, ok := v.(map[string]any); ok && len(m) == 1 {
+		if _, isT := v.(T); !isT {
+			for _, inner := range m {
+				return FromNative[T](inner)
+			}
+		}
+	}
+	t, ok := v.(T)
+	if !ok {
+		var zv T
+		return opt.None[T](), fmt.Errorf("optavro: cannot convert %T to %T", v, zv)
+	}
+	return opt.Some(t), nil
+}
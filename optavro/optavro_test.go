@@ -0,0 +1,125 @@
+package optavro
+
+import (
+	"bytes"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullable(t *testing.T) {
+	u, err := Nullable(avro.NewPrimitiveSchema(avro.String, nil))
+	require.NoError(t, err)
+	require.True(t, u.Nullable())
+	require.Equal(t, `["null","string"]`, u.String())
+
+	again, err := Nullable(u)
+	require.NoError(t, err)
+	require.Same(t, u, again)
+}
+
+func TestRoundTrip(t *testing.T) {
+	field, err := avro.NewField("nickname", MustNullable(avro.NewPrimitiveSchema(avro.String, nil)), avro.WithDefault(nil))
+	require.NoError(t, err)
+	schema, err := avro.NewRecordSchema("User", "", []*avro.Field{field})
+	require.NoError(t, err)
+
+	for _, in := range []opt.Option[string]{opt.Some("nk"), opt.None[string]()} {
+		data, err := avro.Marshal(schema, map[string]any{"nickname": Native(in)})
+		require.NoError(t, err)
+
+		var out map[string]any
+		require.NoError(t, avro.Unmarshal(schema, data, &out))
+		got, err := FromNative[string](out["nickname"])
+		require.NoError(t, err)
+		require.Equal(t, in, got)
+	}
+}
+
+func TestFromNative(t *testing.T) {
+	o, err := FromNative[int64](map[string]any{"long": int64(4)})
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(int64(4)), o)
+
+	o, err = FromNative[int64](nil)
+	require.NoError(t, err)
+	require.True(t, o.None())
+
+	_, err = FromNative[int64]("nope")
+	require.Error(t, err)
+}
+
+type address struct {
+	City string             `avro:"city"`
+	Zip  opt.Option[string] `avro:"zip"`
+}
+
+type user struct {
+	Name     string               `avro:"name"`
+	Nickname opt.Option[string]   `avro:"nickname"`
+	Age      opt.Option[int]      `avro:"age"`
+	Tags     opt.Option[[]string] `avro:"tags"`
+	Address  opt.Option[address]  `avro:"address"`
+}
+
+var userSchema = avro.MustParse(`{
+	"type": "record",
+	"name": "User",
+	"fields": [
+		{"name": "name", "type": "string"},
+		{"name": "nickname", "type": ["null", "string"], "default": null},
+		{"name": "age", "type": ["null", "int"], "default": null},
+		{"name": "tags", "type": ["null", {"type": "array", "items": "string"}], "default": null},
+		{"name": "address", "type": ["null", {
+			"type": "record",
+			"name": "Address",
+			"fields": [
+				{"name": "city", "type": "string"},
+				{"name": "zip", "type": ["null", "string"], "default": null}
+			]
+		}], "default": null}
+	]
+}`)
+
+func TestStructRoundTrip(t *testing.T) {
+	for _, in := range []user{
+		{Name: "a"},
+		{
+			Name:     "b",
+			Nickname: opt.Some(""),
+			Age:      opt.Some(0),
+			Tags:     opt.Some([]string{"x"}),
+			Address:  opt.Some(address{City: "c", Zip: opt.Some("z")}),
+		},
+	} {
+		data, err := Marshal(userSchema, in)
+		require.NoError(t, err)
+
+		var out user
+		require.NoError(t, Unmarshal(userSchema, data, &out))
+		require.Equal(t, in, out)
+	}
+
+	// None is encoded as null.
+	data, err := Marshal(userSchema, user{Name: "a"})
+	require.NoError(t, err)
+	want, err := avro.Marshal(userSchema, map[string]any{
+		"name": "a", "nickname": nil, "age": nil, "tags": nil, "address": nil,
+	})
+	require.NoError(t, err)
+	require.Equal(t, want, data)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	in := user{Name: "a", Age: opt.Some(3)}
+	var buf bytes.Buffer
+	enc := avro.NewEncoderForSchema(userSchema, &buf)
+	require.NoError(t, Encode(enc, in))
+
+	out := user{Nickname: opt.Some("stale")}
+	dec := avro.NewDecoderForSchema(userSchema, &buf)
+	require.NoError(t, Decode(dec, &out))
+	require.Equal(t, in, out)
+}
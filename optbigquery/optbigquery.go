@@ -0,0 +1,255 @@
+// Package optbigquery converts between opt.Option and the nullable types of
+// cloud.google.com/go/bigquery, and writes structs with Option fields to
+// BigQuery tables.
+//
+// Rows read with the Null types, which is how the BigQuery client reads
+// columns that may be NULL, convert to Options with the From functions. Rows
+// are written with a Saver, which stores None as NULL:
+//
+//	ins := table.Inserter()
+//	err := ins.Put(ctx, &optbigquery.Saver{Struct: event})
+package optbigquery
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// FromInt64 returns Some(n.Int64) if n is valid, otherwise None.
+func FromInt64(n bigquery.NullInt64) opt.Option[int64] {
+	return opt.FromMaybe(n.Int64, n.Valid)
+}
+
+// ToInt64 returns a bigquery.NullInt64 holding the same value as o.
+func ToInt64(o opt.Option[int64]) bigquery.NullInt64 {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullInt64{Int64: v, Valid: ok}
+}
+
+// FromFloat64 returns Some(n.Float64) if n is valid, otherwise None.
+func FromFloat64(n bigquery.NullFloat64) opt.Option[float64] {
+	return opt.FromMaybe(n.Float64, n.Valid)
+}
+
+// ToFloat64 returns a bigquery.NullFloat64 holding the same value as o.
+func ToFloat64(o opt.Option[float64]) bigquery.NullFloat64 {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullFloat64{Float64: v, Valid: ok}
+}
+
+// FromBool returns Some(n.Bool) if n is valid, otherwise None.
+func FromBool(n bigquery.NullBool) opt.Option[bool] {
+	return opt.FromMaybe(n.Bool, n.Valid)
+}
+
+// ToBool returns a bigquery.NullBool holding the same value as o.
+func ToBool(o opt.Option[bool]) bigquery.NullBool {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullBool{Bool: v, Valid: ok}
+}
+
+// FromString returns Some(n.StringVal) if n is valid, otherwise None.
+func FromString(n bigquery.NullString) opt.Option[string] {
+	return opt.FromMaybe(n.StringVal, n.Valid)
+}
+
+// ToString returns a bigquery.NullString holding the same value as o.
+func ToString(o opt.Option[string]) bigquery.NullString {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullString{StringVal: v, Valid: ok}
+}
+
+// FromGeography returns Some(n.GeographyVal) if n is valid, otherwise None.
+func FromGeography(n bigquery.NullGeography) opt.Option[string] {
+	return opt.FromMaybe(n.GeographyVal, n.Valid)
+}
+
+// ToGeography returns a bigquery.NullGeography holding the same value as o,
+// which is in WKT format.
+func ToGeography(o opt.Option[string]) bigquery.NullGeography {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullGeography{GeographyVal: v, Valid: ok}
+}
+
+// FromJSON returns Some(n.JSONVal) if n is valid, otherwise None.
+func FromJSON(n bigquery.NullJSON) opt.Option[string] {
+	return opt.FromMaybe(n.JSONVal, n.Valid)
+}
+
+// ToJSON returns a bigquery.NullJSON holding the same value as o.
+func ToJSON(o opt.Option[string]) bigquery.NullJSON {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullJSON{JSONVal: v, Valid: ok}
+}
+
+// FromTimestamp returns Some(n.Timestamp) if n is valid, otherwise None.
+func FromTimestamp(n bigquery.NullTimestamp) opt.Option[time.Time] {
+	return opt.FromMaybe(n.Timestamp, n.Valid)
+}
+
+// ToTimestamp returns a bigquery.NullTimestamp holding the same value as o.
+func ToTimestamp(o opt.Option[time.Time]) bigquery.NullTimestamp {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullTimestamp{Timestamp: v, Valid: ok}
+}
+
+// FromDate returns Some(n.Date) if n is valid, otherwise None.
+func FromDate(n bigquery.NullDate) opt.Option[civil.Date] {
+	return opt.FromMaybe(n.Date, n.Valid)
+}
+
+// ToDate returns a bigquery.NullDate holding the same value as o.
+func ToDate(o opt.Option[civil.Date]) bigquery.NullDate {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullDate{Date: v, Valid: ok}
+}
+
+// FromTime returns Some(n.Time) if n is valid, otherwise None.
+func FromTime(n bigquery.NullTime) opt.Option[civil.Time] {
+	return opt.FromMaybe(n.Time, n.Valid)
+}
+
+// ToTime returns a bigquery.NullTime holding the same value as o.
+func ToTime(o opt.Option[civil.Time]) bigquery.NullTime {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullTime{Time: v, Valid: ok}
+}
+
+// FromDateTime returns Some(n.DateTime) if n is valid, otherwise None.
+func FromDateTime(n bigquery.NullDateTime) opt.Option[civil.DateTime] {
+	return opt.FromMaybe(n.DateTime, n.Valid)
+}
+
+// ToDateTime returns a bigquery.NullDateTime holding the same value as o.
+func ToDateTime(o opt.Option[civil.DateTime]) bigquery.NullDateTime {
+	v, ok := o.MaybeUnwrap()
+	return bigquery.NullDateTime{DateTime: v, Valid: ok}
+}
+
+// Saver is a bigquery.ValueSaver for a struct that may have Option fields,
+// which bigquery.StructSaver cannot save. Each exported field is saved under
+// the name given by its "bigquery" tag, or its own name, and fields tagged
+// "-" are skipped, as with StructSaver. None is saved as NULL, and Some as
+// the value it holds. Fields of embedded structs are saved as if they were
+// fields of the outer struct, and other struct fields as RECORD columns.
+//
+// Values of types the BigQuery client formats specially, such as civil.Time,
+// civil.DateTime and *big.Rat, are formatted as it formats them.
+type Saver struct {
+	// Struct is the struct or pointer to a struct to save.
+	Struct any
+	// InsertID is used for deduplication, as with bigquery.StructSaver.
+	InsertID string
+}
+
+var _ bigquery.ValueSaver = (*Saver)(nil)
+
+// Save implements bigquery.ValueSaver.
+func (s *Saver) Save() (map[string]bigquery.Value, string, error) {
+	rv := reflect.ValueOf(s.Struct)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, "", fmt.Errorf("optbigquery: Saver.Struct must be a struct or a pointer to one, not %T", s.Struct)
+	}
+	row := map[string]bigquery.Value{}
+	saveStruct(row, rv)
+	return row, s.InsertID, nil
+}
+
+func saveStruct(row map[string]bigquery.Value, rv reflect.Value) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("bigquery")
+		if name == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		fv := rv.Field(i)
+		if f.Anonymous && name == "" {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				saveStruct(row, fv)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		row[name] = saveValue(fv)
+	}
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	dateType      = reflect.TypeOf(civil.Date{})
+	civilTimeType = reflect.TypeOf(civil.Time{})
+	dateTimeType  = reflect.TypeOf(civil.DateTime{})
+	ratType       = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// saveValue returns rv as a value the BigQuery client uploads correctly.
+func saveValue(rv reflect.Value) bigquery.Value {
+	if _, ok := optreflect.Elem(rv.Type()); ok {
+		x, some := optreflect.Get(rv)
+		if !some {
+			return nil
+		}
+		return saveValue(x)
+	}
+	switch rv.Type() {
+	case civilTimeType:
+		return bigquery.CivilTimeString(rv.Interface().(civil.Time))
+	case dateTimeType:
+		return bigquery.CivilDateTimeString(rv.Interface().(civil.DateTime))
+	case ratType:
+		if rv.IsNil() {
+			return nil
+		}
+		return bigquery.NumericString(rv.Interface().(*big.Rat))
+	case timeType, dateType:
+		return rv.Interface()
+	}
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		return saveValue(rv.Elem())
+	case reflect.Struct:
+		if _, ok := rv.Interface().(json.Marshaler); ok {
+			break // such as the Null types
+		}
+		row := map[string]bigquery.Value{}
+		saveStruct(row, rv)
+		return row
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			break // BYTES
+		}
+		out := make([]bigquery.Value, rv.Len())
+		for i := range out {
+			out[i] = saveValue(rv.Index(i))
+		}
+		return out
+	}
+	return rv.Interface()
+}
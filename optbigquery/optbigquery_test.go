@@ -0,0 +1,108 @@
+package optbigquery
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+func TestConverters(t *testing.T) {
+	require.Equal(t, opt.Some[int64](7), FromInt64(bigquery.NullInt64{Int64: 7, Valid: true}))
+	require.Equal(t, opt.None[int64](), FromInt64(bigquery.NullInt64{}))
+	require.Equal(t, bigquery.NullInt64{Int64: 0, Valid: true}, ToInt64(opt.Some[int64](0)))
+	require.Equal(t, bigquery.NullInt64{}, ToInt64(opt.None[int64]()))
+
+	require.Equal(t, opt.Some(1.5), FromFloat64(ToFloat64(opt.Some(1.5))))
+	require.Equal(t, opt.Some(false), FromBool(ToBool(opt.Some(false))))
+	require.Equal(t, opt.Some(""), FromString(ToString(opt.Some(""))))
+	require.Equal(t, opt.None[string](), FromString(bigquery.NullString{}))
+	require.Equal(t, opt.Some("POINT(1 2)"), FromGeography(ToGeography(opt.Some("POINT(1 2)"))))
+	require.Equal(t, opt.Some(`{"a":1}`), FromJSON(ToJSON(opt.Some(`{"a":1}`))))
+	require.Equal(t, bigquery.NullJSON{}, ToJSON(opt.None[string]()))
+
+	ts := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, opt.Some(ts), FromTimestamp(ToTimestamp(opt.Some(ts))))
+	require.Equal(t, bigquery.NullTimestamp{}, ToTimestamp(opt.None[time.Time]()))
+
+	d := civil.Date{Year: 2024, Month: 5, Day: 1}
+	tm := civil.Time{Hour: 12, Minute: 30}
+	require.Equal(t, opt.Some(d), FromDate(bigquery.NullDate{Date: d, Valid: true}))
+	require.Equal(t, bigquery.NullDate{}, ToDate(opt.None[civil.Date]()))
+	require.Equal(t, opt.Some(tm), FromTime(ToTime(opt.Some(tm))))
+	require.Equal(t, opt.None[civil.Time](), FromTime(bigquery.NullTime{}))
+	dt := civil.DateTime{Date: d, Time: tm}
+	require.Equal(t, opt.Some(dt), FromDateTime(ToDateTime(opt.Some(dt))))
+	require.Equal(t, bigquery.NullDateTime{}, ToDateTime(opt.None[civil.DateTime]()))
+}
+
+type Audit struct {
+	CreatedBy opt.Option[string] `bigquery:"created_by"`
+}
+
+type address struct {
+	City opt.Option[string] `bigquery:"city"`
+}
+
+type Event struct {
+	Audit
+	ID       string                 `bigquery:"id"`
+	Count    opt.Option[int64]      `bigquery:"count"`
+	Note     opt.Option[string]     `bigquery:"note"`
+	Day      opt.Option[civil.Date] `bigquery:"day"`
+	At       opt.Option[civil.Time] `bigquery:"at"`
+	Local    opt.Option[civil.DateTime]
+	Amount   opt.Option[*big.Rat] `bigquery:"amount"`
+	Address  opt.Option[address]  `bigquery:"address"`
+	Tags     []opt.Option[string] `bigquery:"tags"`
+	Legacy   bigquery.NullString  `bigquery:"legacy"`
+	Raw      []byte               `bigquery:"raw"`
+	Ignored  string               `bigquery:"-"`
+	internal string
+}
+
+func TestSaver(t *testing.T) {
+	d := civil.Date{Year: 2024, Month: 5, Day: 1}
+	tm := civil.Time{Hour: 12, Minute: 30}
+	row, id, err := (&Saver{
+		Struct: &Event{
+			Audit:   Audit{CreatedBy: opt.Some("nk")},
+			ID:      "e1",
+			Count:   opt.Some[int64](3),
+			Day:     opt.Some(d),
+			At:      opt.Some(tm),
+			Local:   opt.Some(civil.DateTime{Date: d, Time: tm}),
+			Amount:  opt.Some(big.NewRat(3, 2)),
+			Address: opt.Some(address{}),
+			Tags:    []opt.Option[string]{opt.Some("a"), opt.None[string]()},
+			Legacy:  bigquery.NullString{StringVal: "x", Valid: true},
+			Raw:     []byte("hi"),
+			Ignored: "no",
+		},
+		InsertID: "id1",
+	}).Save()
+	require.NoError(t, err)
+	require.Equal(t, "id1", id)
+	require.Equal(t, map[string]bigquery.Value{
+		"created_by": "nk",
+		"id":         "e1",
+		"count":      int64(3),
+		"note":       nil,
+		"day":        d,
+		"at":         "12:30:00",
+		"Local":      "2024-05-01 12:30:00",
+		"amount":     "1.500000000",
+		"address":    map[string]bigquery.Value{"city": nil},
+		"tags":       []bigquery.Value{"a", nil},
+		"legacy":     bigquery.NullString{StringVal: "x", Valid: true},
+		"raw":        []byte("hi"),
+	}, row)
+
+	_, _, err = (&Saver{Struct: 1}).Save()
+	require.EqualError(t, err, "optbigquery: Saver.Struct must be a struct or a pointer to one, not int")
+}
@@ -0,0 +1,89 @@
+// Package optbson enables BSON support for opt.Option using the MongoDB Go
+// driver (go.mongodb.org/mongo-driver/v2/bson). Option has no BSON methods of
+// its own; instead, Register adds codecs for Options to a registry, which is
+// then used to encode and decode documents:
+//
+//	reg := bson.NewRegistry()
+//	optbson.Register(reg)
+//	client, err := mongo.Connect(options.Client().SetRegistry(reg))
+//
+// With such a registry, Option values encode as their contained value when
+// Some and as BSON null when None. Null, undefined and missing fields all
+// decode as None. Fields tagged with "omitempty" leave None values out of the
+// document.
+package optbson
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Register adds codecs for Options, as well as opt.Boxed and opt.PtrOption, to
+// reg. The values they hold are encoded and decoded with the codecs of reg
+// itself, so encoders registered for custom types apply to them as well.
+// Register must be called before reg is used:
+//
+//	reg := bson.NewRegistry()
+//	reg.RegisterTypeEncoder(reflect.TypeFor[Money](), moneyCodec)
+//	optbson.Register(reg)
+func Register(reg *bson.Registry) {
+	reg.RegisterInterfaceEncoder(reflect.TypeFor[opt.AnyOption](), bson.ValueEncoderFunc(
+		func(ec bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+			if val.Kind() == reflect.Pointer && isOption(val.Type().Elem()) {
+				if val.IsNil() {
+					return vw.WriteNull()
+				}
+				val = val.Elem()
+			}
+			inner, some := optreflect.Get(val)
+			if !some {
+				return vw.WriteNull()
+			}
+			// Copy the value so that encoders of types whose methods have
+			// pointer receivers can take its address.
+			v := reflect.New(inner.Type()).Elem()
+			v.Set(inner)
+			e, err := ec.LookupEncoder(v.Type())
+			if err != nil {
+				return err
+			}
+			return e.EncodeValue(ec, vw, v)
+		}))
+	reg.RegisterInterfaceDecoder(reflect.TypeFor[opt.AnyOptionSetter](), bson.ValueDecoderFunc(
+		func(dc bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+			elem, ok := optreflect.Elem(val.Type())
+			if !ok {
+				return fmt.Errorf("opt: cannot decode BSON into %s", val.Type())
+			}
+			if !val.CanAddr() {
+				return fmt.Errorf("opt: cannot decode BSON into unaddressable %s", val.Type())
+			}
+			switch vr.Type() {
+			case bson.TypeNull:
+				optreflect.Set(val.Addr(), reflect.Value{})
+				return vr.ReadNull()
+			case bson.TypeUndefined:
+				optreflect.Set(val.Addr(), reflect.Value{})
+				return vr.ReadUndefined()
+			}
+			d, err := dc.LookupDecoder(elem)
+			if err != nil {
+				return err
+			}
+			v := reflect.New(elem).Elem()
+			if err := d.DecodeValue(dc, vr, v); err != nil {
+				return err
+			}
+			optreflect.Set(val.Addr(), v)
+			return nil
+		}))
+}
+
+func isOption(t reflect.Type) bool {
+	_, ok := optreflect.Elem(t)
+	return ok
+}
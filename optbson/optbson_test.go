@@ -0,0 +1,171 @@
+package optbson
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// newRegistry returns a default registry with Register applied.
+func newRegistry() *bson.Registry {
+	reg := bson.NewRegistry()
+	Register(reg)
+	return reg
+}
+
+func marshal(reg *bson.Registry, v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := bson.NewEncoder(bson.NewDocumentWriter(&buf))
+	enc.SetRegistry(reg)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshal(reg *bson.Registry, data []byte, v any) error {
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(data)))
+	dec.SetRegistry(reg)
+	return dec.Decode(v)
+}
+
+func TestBSON(t *testing.T) {
+	type Address struct {
+		City string `bson:"city"`
+	}
+	type User struct {
+		Name     string                `bson:"name"`
+		Nickname opt.Option[string]    `bson:"nickname"`
+		Age      opt.Option[int32]     `bson:"age,omitempty"`
+		Address  opt.Option[Address]   `bson:"address,omitempty"`
+		Seen     opt.Option[time.Time] `bson:"seen,omitempty"`
+	}
+	reg := newRegistry()
+
+	t.Run("encode", func(t *testing.T) {
+		data, err := marshal(reg, User{Name: "a"})
+		require.NoError(t, err)
+
+		var doc bson.D
+		require.NoError(t, bson.Unmarshal(data, &doc))
+		require.Equal(t, bson.D{{Key: "name", Value: "a"}, {Key: "nickname", Value: nil}}, doc)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		in := User{
+			Name:     "a",
+			Nickname: opt.Some("b"),
+			Age:      opt.Some(int32(0)),
+			Address:  opt.Some(Address{City: "c"}),
+			Seen:     opt.Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		data, err := marshal(reg, in)
+		require.NoError(t, err)
+
+		var out User
+		require.NoError(t, unmarshal(reg, data, &out))
+		require.Equal(t, in.Nickname, out.Nickname)
+		require.Equal(t, in.Age, out.Age)
+		require.Equal(t, in.Address, out.Address)
+		require.True(t, in.Seen.Unwrap().Equal(out.Seen.Unwrap()))
+	})
+	t.Run("decode null and missing", func(t *testing.T) {
+		data, err := bson.Marshal(bson.D{{Key: "name", Value: "a"}, {Key: "nickname", Value: nil}})
+		require.NoError(t, err)
+
+		out := User{Nickname: opt.Some("stale")}
+		require.NoError(t, unmarshal(reg, data, &out))
+		require.True(t, out.Nickname.None())
+		require.True(t, out.Age.None())
+		require.True(t, out.Address.None())
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		data, err := bson.Marshal(bson.D{{Key: "age", Value: "nope"}})
+		require.NoError(t, err)
+
+		var out User
+		require.Error(t, unmarshal(reg, data, &out))
+	})
+}
+
+type celsius float64
+
+// label implements bson.ValueMarshaler and bson.ValueUnmarshaler itself, so it
+// checks that Register leaves such types alone.
+type label string
+
+func (l label) MarshalBSONValue() (byte, []byte, error) {
+	typ, data, err := bson.MarshalValue("label:" + string(l))
+	return byte(typ), data, err
+}
+
+func (l *label) UnmarshalBSONValue(typ byte, data []byte) error {
+	var s string
+	if err := bson.UnmarshalValue(bson.Type(typ), data, &s); err != nil {
+		return err
+	}
+	*l = label(strings.TrimPrefix(s, "label:"))
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	type Reading struct {
+		Temp  opt.Option[celsius] `bson:"temp"`
+		Extra opt.Option[celsius] `bson:"extra"`
+		Label label               `bson:"label"`
+	}
+	reg := bson.NewRegistry()
+	reg.RegisterTypeEncoder(reflect.TypeFor[celsius](), bson.ValueEncoderFunc(
+		func(_ bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+			return vw.WriteString(fmt.Sprintf("%.1fC", val.Float()))
+		}))
+	reg.RegisterTypeDecoder(reflect.TypeFor[celsius](), bson.ValueDecoderFunc(
+		func(_ bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+			s, err := vr.ReadString()
+			if err != nil {
+				return err
+			}
+			var c float64
+			if _, err := fmt.Sscanf(s, "%gC", &c); err != nil {
+				return err
+			}
+			val.SetFloat(c)
+			return nil
+		}))
+	Register(reg)
+
+	in := Reading{Temp: opt.Some[celsius](21.5), Label: "a"}
+	data, err := marshal(reg, in)
+	require.NoError(t, err)
+
+	var doc bson.D
+	require.NoError(t, bson.Unmarshal(data, &doc))
+	require.Equal(t, bson.D{{Key: "temp", Value: "21.5C"}, {Key: "extra", Value: nil}, {Key: "label", Value: "label:a"}}, doc)
+
+	var out Reading
+	require.NoError(t, unmarshal(reg, data, &out))
+	require.Equal(t, in, out)
+}
+
+func TestRegisterBoxedAndPtrOption(t *testing.T) {
+	type Doc struct {
+		Boxed opt.Boxed[string]     `bson:"boxed"`
+		Ptr   opt.PtrOption[string] `bson:"ptr"`
+	}
+	reg := newRegistry()
+
+	for _, in := range []Doc{{}, {Boxed: opt.NewBoxed("a"), Ptr: opt.SomePtr("b")}} {
+		data, err := marshal(reg, in)
+		require.NoError(t, err)
+
+		var out Doc
+		require.NoError(t, unmarshal(reg, data, &out))
+		require.Equal(t, in, out)
+	}
+}
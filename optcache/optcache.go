@@ -0,0 +1,181 @@
+// Package optcache provides an in-memory cache of opt.Option values that
+// remembers both values and their absence, each for its own time to live:
+//
+//	users := optcache.New[int64, User](10*time.Minute, time.Minute)
+//
+//	u, err := users.GetOrLoad(ctx, id, func(ctx context.Context, id int64) (opt.Option[User], error) {
+//		return db.FindUser(ctx, id)
+//	})
+//
+// Looking up a user that does not exist calls the loader at most once a
+// minute, while one that does is kept for ten.
+package optcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"code.nkcmr.net/opt"
+)
+
+var errLoadPanicked = errors.New("optcache: load panicked")
+
+// Cache maps keys to Options, each of which expires after the time to live
+// for Some or for None values given to New. It is safe for concurrent use.
+// Expired entries are dropped when they are looked up, or by Prune.
+type Cache[K comparable, V any] struct {
+	ttl, negativeTTL time.Duration
+	now              func() time.Time
+
+	mu       sync.Mutex
+	entries  map[K]entry[V]
+	inflight map[K]*call[V]
+}
+
+type entry[V any] struct {
+	value   opt.Option[V]
+	expires time.Time // zero if it never expires
+}
+
+// call is a load in progress, which concurrent calls to GetOrLoad for the
+// same key wait for instead of loading it again.
+type call[V any] struct {
+	done  chan struct{}
+	value opt.Option[V]
+	err   error
+}
+
+// New returns an empty Cache that keeps Some values for ttl and None values
+// for negativeTTL. A time to live of zero keeps values until they are
+// replaced or deleted, and a negative one does not cache them at all.
+func New[K comparable, V any](ttl, negativeTTL time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     map[K]entry[V]{},
+		inflight:    map[K]*call[V]{},
+	}
+}
+
+// Get returns the value cached for key, or None if it is not cached, has
+// expired, or is cached as None. Lookup tells these apart.
+func (c *Cache[K, V]) Get(key K) opt.Option[V] {
+	v, _ := c.Lookup(key)
+	return v
+}
+
+// Lookup returns the Option cached for key, and whether there is one that has
+// not expired.
+func (c *Cache[K, V]) Lookup(key K) (opt.Option[V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookup(key)
+}
+
+func (c *Cache[K, V]) lookup(key K) (opt.Option[V], bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return opt.None[V](), false
+	}
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return opt.None[V](), false
+	}
+	return e.value, true
+}
+
+// Set caches v for key, for the time to live of Some values if v is Some and
+// of None values if it is None.
+func (c *Cache[K, V]) Set(key K, v opt.Option[V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, v)
+}
+
+func (c *Cache[K, V]) set(key K, v opt.Option[V]) {
+	ttl := c.ttl
+	if v.None() {
+		ttl = c.negativeTTL
+	}
+	switch {
+	case ttl < 0:
+		delete(c.entries, key)
+	case ttl == 0:
+		c.entries[key] = entry[V]{value: v}
+	default:
+		c.entries[key] = entry[V]{value: v, expires: c.now().Add(ttl)}
+	}
+}
+
+// Delete removes whatever is cached for key.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// Len returns the number of entries in the cache, including expired ones that
+// have not been dropped yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Prune drops the entries that have expired.
+func (c *Cache[K, V]) Prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !e.expires.IsZero() && !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// GetOrLoad returns the Option cached for key, even if it is None. If there
+// is none, it calls load, caches what it returns and returns it. Errors are
+// returned without being cached. Concurrent calls for a key that is being
+// loaded wait for that load instead of starting another, until ctx is done.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load func(context.Context, K) (opt.Option[V], error)) (opt.Option[V], error) {
+	c.mu.Lock()
+	if v, ok := c.lookup(key); ok {
+		c.mu.Unlock()
+		return v, nil
+	}
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-cl.done:
+			return cl.value, cl.err
+		case <-ctx.Done():
+			return opt.None[V](), ctx.Err()
+		}
+	}
+	cl := &call[V]{done: make(chan struct{})}
+	c.inflight[key] = cl
+	c.mu.Unlock()
+
+	returned := false
+	defer func() {
+		if !returned {
+			// load panicked, so there is nothing to cache, and the callers
+			// waiting for it get an error instead.
+			cl.err = errLoadPanicked
+		}
+		c.mu.Lock()
+		delete(c.inflight, key)
+		if cl.err == nil {
+			c.set(key, cl.value)
+		}
+		c.mu.Unlock()
+		close(cl.done)
+	}()
+	cl.value, cl.err = load(ctx, key)
+	returned = true
+	return cl.value, cl.err
+}
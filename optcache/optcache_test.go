@@ -0,0 +1,171 @@
+package optcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newCache[K comparable, V any](ttl, negativeTTL time.Duration) (*Cache[K, V], *clock) {
+	c := New[K, V](ttl, negativeTTL)
+	clk := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.now = func() time.Time { return clk.now }
+	return c, clk
+}
+
+func TestCache(t *testing.T) {
+	c, clk := newCache[string, int](time.Minute, time.Second)
+
+	require.Equal(t, opt.None[int](), c.Get("a"))
+	_, ok := c.Lookup("a")
+	require.False(t, ok)
+
+	c.Set("a", opt.Some(1))
+	c.Set("b", opt.None[int]())
+	require.Equal(t, opt.Some(1), c.Get("a"))
+	v, ok := c.Lookup("b")
+	require.True(t, ok)
+	require.Equal(t, opt.None[int](), v)
+	require.Equal(t, 2, c.Len())
+
+	clk.advance(time.Second)
+	_, ok = c.Lookup("b")
+	require.False(t, ok)
+	require.Equal(t, opt.Some(1), c.Get("a"))
+	require.Equal(t, 1, c.Len())
+
+	clk.advance(time.Minute)
+	require.Equal(t, opt.None[int](), c.Get("a"))
+	require.Zero(t, c.Len())
+
+	c.Set("a", opt.Some(1))
+	c.Delete("a")
+	require.Equal(t, opt.None[int](), c.Get("a"))
+}
+
+func TestTTLs(t *testing.T) {
+	c, clk := newCache[string, int](0, -1)
+	c.Set("a", opt.Some(1))
+	c.Set("b", opt.None[int]())
+	clk.advance(24 * time.Hour)
+	require.Equal(t, opt.Some(1), c.Get("a"))
+	_, ok := c.Lookup("b")
+	require.False(t, ok)
+
+	c.Set("a", opt.None[int]())
+	_, ok = c.Lookup("a")
+	require.False(t, ok)
+}
+
+func TestPrune(t *testing.T) {
+	c, clk := newCache[int, string](time.Minute, 0)
+	c.Set(1, opt.Some("one"))
+	c.Set(2, opt.None[string]())
+	clk.advance(time.Minute)
+	c.Set(3, opt.Some("three"))
+	require.Equal(t, 3, c.Len())
+	c.Prune()
+	require.Equal(t, 2, c.Len())
+	_, ok := c.Lookup(2)
+	require.True(t, ok)
+}
+
+func TestGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	c, clk := newCache[int, string](time.Minute, time.Second)
+	loads := 0
+	load := func(_ context.Context, id int) (opt.Option[string], error) {
+		loads++
+		switch id {
+		case 1:
+			return opt.Some("one"), nil
+		case 2:
+			return opt.None[string](), nil
+		}
+		return opt.None[string](), errors.New("unavailable")
+	}
+
+	for i := 0; i < 2; i++ {
+		v, err := c.GetOrLoad(ctx, 1, load)
+		require.NoError(t, err)
+		require.Equal(t, opt.Some("one"), v)
+		v, err = c.GetOrLoad(ctx, 2, load)
+		require.NoError(t, err)
+		require.Equal(t, opt.None[string](), v)
+	}
+	require.Equal(t, 2, loads)
+
+	clk.advance(time.Second)
+	_, err := c.GetOrLoad(ctx, 2, load)
+	require.NoError(t, err)
+	_, err = c.GetOrLoad(ctx, 1, load)
+	require.NoError(t, err)
+	require.Equal(t, 3, loads)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.GetOrLoad(ctx, 3, load)
+		require.EqualError(t, err, "unavailable")
+	}
+	require.Equal(t, 5, loads)
+}
+
+func TestGetOrLoadConcurrent(t *testing.T) {
+	c := New[string, int](time.Minute, time.Minute)
+	release := make(chan struct{})
+	var loads atomic.Int32
+	load := func(context.Context, string) (opt.Option[int], error) {
+		loads.Add(1)
+		<-release
+		return opt.Some(42), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), "k", load)
+			require.NoError(t, err)
+			require.Equal(t, opt.Some(42), v)
+		}()
+	}
+	require.Eventually(t, func() bool { return loads.Load() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetOrLoad(ctx, "k", load)
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), loads.Load())
+}
+
+func TestGetOrLoadPanic(t *testing.T) {
+	c := New[string, int](time.Minute, time.Minute)
+	require.Panics(t, func() {
+		_, _ = c.GetOrLoad(context.Background(), "k", func(context.Context, string) (opt.Option[int], error) {
+			panic("boom")
+		})
+	})
+	_, ok := c.Lookup("k")
+	require.False(t, ok)
+
+	v, err := c.GetOrLoad(context.Background(), "k", func(context.Context, string) (opt.Option[int], error) {
+		return opt.Some(1), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(1), v)
+}
@@ -0,0 +1,59 @@
+// Package optcbor encodes and decodes values with opt.Option fields as CBOR
+// using github.com/fxamacker/cbor/v2.
+//
+// Option implements cbor.Marshaler and cbor.Unmarshaler, so cbor.Marshal and
+// cbor.Unmarshal accept Options as they are, but cbor has no way to pass the
+// EncMode or DecMode in use on to those methods, and the values Options hold
+// are always encoded with the default options. The functions here instead
+// encode and decode a mirror type in which every Option[T] is a *T, so that
+// the caller's modes apply to the values Options hold as they do to
+// everything else:
+//
+//	em, err := cbor.CoreDetEncOptions().EncMode()
+//	// ...
+//	err = optcbor.Encode(em.NewEncoder(w), reading)
+//
+// Option values encode as their contained value when Some and as CBOR null
+// when None. Both null and undefined decode as None. Fields tagged with
+// "omitempty" or "omitzero" leave None values out entirely.
+package optcbor
+
+import (
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var mapper = &shadow.Mapper{}
+
+// Marshal is like cbor.Marshal but also accepts Options.
+func Marshal(v any) ([]byte, error) {
+	return cbor.Marshal(mirror(v))
+}
+
+// Unmarshal is like cbor.Unmarshal but also accepts Options.
+func Unmarshal(data []byte, v any) error {
+	return mapper.Decode(v, func(out any) error {
+		return cbor.Unmarshal(data, out)
+	})
+}
+
+// Encode is like enc.Encode but also accepts Options.
+func Encode(enc *cbor.Encoder, v any) error {
+	return enc.Encode(mirror(v))
+}
+
+// Decode is like dec.Decode but also accepts Options.
+func Decode(dec *cbor.Decoder, v any) error {
+	return mapper.Decode(v, func(out any) error {
+		return dec.Decode(out)
+	})
+}
+
+func mirror(v any) any {
+	if v == nil || !shadow.Needs(reflect.TypeOf(v)) {
+		return v
+	}
+	return mapper.To(reflect.ValueOf(v)).Interface()
+}
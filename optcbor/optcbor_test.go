@@ -0,0 +1,92 @@
+package optcbor
+
+import (
+	"bytes"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBOR(t *testing.T) {
+	type Reading struct {
+		Sensor string               `cbor:"1,keyasint"`
+		Temp   opt.Option[float64]  `cbor:"2,keyasint"`
+		Labels opt.Option[[]string] `cbor:"3,keyasint,omitzero"`
+	}
+
+	t.Run("None encodes as null", func(t *testing.T) {
+		data, err := Marshal(opt.None[int]())
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xf6}, data)
+	})
+	t.Run("Some encodes as the value", func(t *testing.T) {
+		data, err := Marshal(opt.Some(uint8(10)))
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x0a}, data)
+	})
+	t.Run("omitzero", func(t *testing.T) {
+		data, err := Marshal(Reading{Sensor: "a"})
+		require.NoError(t, err)
+
+		var m map[int]any
+		require.NoError(t, cbor.Unmarshal(data, &m))
+		require.Equal(t, map[int]any{1: "a", 2: nil}, m)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		in := Reading{
+			Sensor: "a",
+			Temp:   opt.Some(21.5),
+			Labels: opt.Some([]string{"x"}),
+		}
+		data, err := Marshal(in)
+		require.NoError(t, err)
+
+		var out Reading
+		require.NoError(t, Unmarshal(data, &out))
+		require.Equal(t, in, out)
+	})
+	t.Run("decode null and undefined", func(t *testing.T) {
+		for _, data := range [][]byte{{0xf6}, {0xf7}} {
+			v := opt.Some(1)
+			require.NoError(t, Unmarshal(data, &v))
+			require.True(t, v.None())
+		}
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		data, err := cbor.Marshal("nope")
+		require.NoError(t, err)
+		var v opt.Option[int]
+		require.Error(t, Unmarshal(data, &v))
+	})
+	t.Run("plain values", func(t *testing.T) {
+		data, err := Marshal(nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xf6}, data)
+
+		var s string
+		require.NoError(t, Unmarshal([]byte{0x61, 'a'}, &s))
+		require.Equal(t, "a", s)
+	})
+}
+
+func TestModes(t *testing.T) {
+	em, err := cbor.EncOptions{Sort: cbor.SortLengthFirst}.EncMode()
+	require.NoError(t, err)
+	dm, err := cbor.DecOptions{DupMapKey: cbor.DupMapKeyEnforcedAPF}.DecMode()
+	require.NoError(t, err)
+
+	m := map[string]int{"bb": 1, "a": 2}
+	want, err := em.Marshal(m)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, Encode(em.NewEncoder(&buf), opt.Some(m)))
+	require.Equal(t, want, buf.Bytes())
+
+	// {"a": 1, "a": 2}
+	dup := []byte{0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02}
+	var o opt.Option[map[string]int]
+	require.Error(t, Decode(dm.NewDecoder(bytes.NewReader(dup)), &o))
+	require.NoError(t, Unmarshal(dup, &o))
+}
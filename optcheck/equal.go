@@ -0,0 +1,82 @@
+package optcheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// EqualAnalyzer reports comparisons of Options with == and !=.
+var EqualAnalyzer = &analysis.Analyzer{
+	Name: "optequal",
+	Doc: `report comparisons of Options with == and !=
+
+Options of comparable types can be compared with == and !=, which compares
+both whether they are Some and what they contain, but it is easy to mistake
+for a comparison of the contained values alone. opt.Equal makes the handling
+of None explicit, and opt.EqualFunc allows a custom comparison, such as one
+that treats NaN as equal to itself. A suggested fix rewrites the comparison to
+use opt.Equal.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runEqual,
+}
+
+func runEqual(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	in.Preorder([]ast.Node{(*ast.BinaryExpr)(nil)}, func(n ast.Node) {
+		e := n.(*ast.BinaryExpr)
+		if e.Op != token.EQL && e.Op != token.NEQ {
+			return
+		}
+		if !isOption(pass.TypesInfo.TypeOf(e.X)) && !isOption(pass.TypesInfo.TypeOf(e.Y)) {
+			return
+		}
+		d := analysis.Diagnostic{
+			Message: fmt.Sprintf("Options compared with %s: use opt.Equal or opt.EqualFunc", e.Op),
+		}
+		if fix, ok := equalFix(pass, e); ok {
+			d.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+		report(pass, d, e)
+	})
+	return nil, nil
+}
+
+// equalFix rewrites e to call opt.Equal, if the file it is in imports opt.
+func equalFix(pass *analysis.Pass, e *ast.BinaryExpr) (analysis.SuggestedFix, bool) {
+	f := fileOf(pass, e.Pos())
+	if f == nil {
+		return analysis.SuggestedFix{}, false
+	}
+	name := importName(f)
+	if name == "" {
+		return analysis.SuggestedFix{}, false
+	}
+	var buf bytes.Buffer
+	if e.Op == token.NEQ {
+		buf.WriteString("!")
+	}
+	fmt.Fprintf(&buf, "%s.Equal(", name)
+	if err := format.Node(&buf, pass.Fset, e.X); err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	buf.WriteString(", ")
+	if err := format.Node(&buf, pass.Fset, e.Y); err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	buf.WriteString(")")
+	return analysis.SuggestedFix{
+		Message: fmt.Sprintf("Use %s.Equal", name),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     e.Pos(),
+			End:     e.End(),
+			NewText: buf.Bytes(),
+		}},
+	}, true
+}
@@ -0,0 +1,121 @@
+// Package optcheck defines go/analysis analyzers that catch misuse of
+// opt.Option.
+//
+// The cmd/optcheck command runs them on its own or as a vet tool:
+//
+//	go install code.nkcmr.net/opt/cmd/optcheck@latest
+//	go vet -vettool=$(which optcheck) ./...
+//
+// They can also be added to any driver that accepts analyzers, such as a
+// golangci-lint plugin, through Analyzers.
+//
+// A diagnostic is suppressed by an //optcheck:ignore comment at the end of the
+// line it is reported on, or on the line above it.
+package optcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzers are all the analyzers in this package.
+var Analyzers = []*analysis.Analyzer{
+	UnwrapAnalyzer,
+	EqualAnalyzer,
+}
+
+const ignoreDirective = "//optcheck:ignore"
+
+// ignored reports whether node is on, or directly below, a line with an
+// //optcheck:ignore comment.
+func ignored(pass *analysis.Pass, node ast.Node) bool {
+	f := fileOf(pass, node.Pos())
+	if f == nil {
+		return false
+	}
+	line := pass.Fset.Position(node.Pos()).Line
+	for _, group := range f.Comments {
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, ignoreDirective) {
+				continue
+			}
+			if l := pass.Fset.Position(c.Slash).Line; l == line || l == line-1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// report reports a diagnostic about node unless it is ignored.
+func report(pass *analysis.Pass, d analysis.Diagnostic, node ast.Node) {
+	if ignored(pass, node) {
+		return
+	}
+	d.Pos, d.End = node.Pos(), node.End()
+	pass.Report(d)
+}
+
+// importName returns the name file refers to package opt by, or "" if it
+// does not import it.
+func importName(file *ast.File) string {
+	for _, spec := range file.Imports {
+		if strings.Trim(spec.Path.Value, "`\"") != optPath {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return ""
+			}
+			return spec.Name.Name
+		}
+		return "opt"
+	}
+	return ""
+}
+
+// fileOf returns the file of pass containing pos.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.FileStart <= pos && pos < f.FileEnd {
+			return f
+		}
+	}
+	return nil
+}
+
+const optPath = "code.nkcmr.net/opt"
+
+// optionMethod reports whether call is a call to the method called name of an
+// Option and, if so, returns the expression it is called on.
+func optionMethod(info *types.Info, call *ast.CallExpr, name string) (ast.Expr, bool) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return nil, false
+	}
+	s, ok := info.Selections[sel]
+	if !ok || s.Kind() != types.MethodVal {
+		return nil, false
+	}
+	if !isOption(s.Recv()) {
+		return nil, false
+	}
+	return sel.X, true
+}
+
+// isOption reports whether t is an Option, or a pointer to one.
+func isOption(t types.Type) bool {
+	if p, ok := t.Underlying().(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Origin().Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == optPath && obj.Name() == "Option"
+}
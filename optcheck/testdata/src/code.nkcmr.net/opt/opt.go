@@ -0,0 +1,17 @@
+// Package opt is a stand-in for code.nkcmr.net/opt with just enough of its
+// API for the analyzers to be tested against.
+package opt
+
+type Option[T any] struct {
+	v  T
+	ok bool
+}
+
+func Some[T any](v T) Option[T] { return Option[T]{v: v, ok: true} }
+
+func None[T any]() Option[T] { return Option[T]{} }
+
+func (o Option[T]) Some() bool   { return o.ok }
+func (o Option[T]) None() bool   { return !o.ok }
+func (o Option[T]) IsZero() bool { return !o.ok }
+func (o Option[T]) Unwrap() T    { return o.v }
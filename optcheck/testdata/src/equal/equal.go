@@ -0,0 +1,28 @@
+package equal
+
+import (
+	"math"
+
+	o "code.nkcmr.net/opt"
+)
+
+type config struct {
+	port o.Option[int]
+}
+
+func compare(a, b o.Option[int], c config) bool {
+	if a == b { // want `Options compared with ==: use opt.Equal or opt.EqualFunc`
+		return true
+	}
+	if c.port != o.Some(80) { // want `Options compared with !=`
+		return false
+	}
+	if a == (o.Option[int]{}) { // want `Options compared with ==`
+		return false
+	}
+	nan := o.Some(math.NaN())
+	_ = nan == nan //optcheck:ignore NaN is never equal to itself
+	//optcheck:ignore
+	_ = nan != nan
+	return false
+}
@@ -0,0 +1,113 @@
+package unwrap
+
+import "code.nkcmr.net/opt"
+
+type config struct {
+	port opt.Option[int]
+}
+
+func unguarded(o opt.Option[int], c *config) int {
+	_ = o.Unwrap()         // want `o.Unwrap may panic: it is not guarded by a check that o is Some`
+	return c.port.Unwrap() // want `c.port.Unwrap may panic`
+}
+
+func ignored(o opt.Option[int]) int {
+	//optcheck:ignore o is always Some here
+	return o.Unwrap()
+}
+
+func ifSome(o opt.Option[int], c config) int {
+	if o.Some() {
+		return o.Unwrap()
+	}
+	if c.port.Some() && o.Some() {
+		return c.port.Unwrap() + o.Unwrap()
+	}
+	if !o.None() {
+		return o.Unwrap()
+	}
+	if c.port.None() {
+		return 0
+	} else {
+		return c.port.Unwrap()
+	}
+}
+
+func wrongOption(a, b opt.Option[int], c, d config) int {
+	if a.Some() {
+		return b.Unwrap() // want `b.Unwrap may panic`
+	}
+	if c.port.Some() {
+		return d.port.Unwrap() // want `d.port.Unwrap may panic`
+	}
+	if a.Some() || b.Some() {
+		return a.Unwrap() // want `a.Unwrap may panic`
+	}
+	if a.None() {
+		return a.Unwrap() // want `a.Unwrap may panic`
+	}
+	return 0
+}
+
+func earlyReturn(o opt.Option[string], p *opt.Option[string]) string {
+	if o.None() {
+		return ""
+	}
+	if p.IsZero() || len(o.Unwrap()) > 3 {
+		panic("no")
+	}
+	return o.Unwrap() + p.Unwrap()
+}
+
+func earlyReturnTooLate(o opt.Option[string]) string {
+	s := o.Unwrap() // want `o.Unwrap may panic`
+	if o.None() {
+		return ""
+	}
+	return s
+}
+
+func notTerminating(o opt.Option[string]) string {
+	if o.None() {
+		println("none")
+	}
+	return o.Unwrap() // want `o.Unwrap may panic`
+}
+
+func shortCircuit(o opt.Option[int]) bool {
+	return o.Some() && o.Unwrap() > 0 || o.None() || o.Unwrap() < 0
+}
+
+func loops(os []opt.Option[int]) int {
+	n := 0
+	for _, o := range os {
+		if !o.Some() {
+			continue
+		}
+		n += o.Unwrap()
+	}
+	var o opt.Option[int]
+	for o.Some() {
+		n += o.Unwrap()
+	}
+	return n
+}
+
+func switches(o opt.Option[int]) int {
+	switch {
+	case o.Some():
+		return o.Unwrap()
+	case o.None():
+		return o.Unwrap() // want `o.Unwrap may panic`
+	}
+	switch o.Unwrap() { // want `o.Unwrap may panic`
+	}
+	return 0
+}
+
+func closures(o opt.Option[int]) func() int {
+	if o.Some() {
+		return func() int { return o.Unwrap() }
+	}
+	return func() int { return o.Unwrap() } // want `o.Unwrap may panic`
+}
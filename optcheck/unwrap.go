@@ -0,0 +1,203 @@
+package optcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// UnwrapAnalyzer reports calls to Option.Unwrap that are not guarded by a
+// check that the Option is Some, since Unwrap panics if it is None.
+var UnwrapAnalyzer = &analysis.Analyzer{
+	Name: "optunwrap",
+	Doc: `report calls to Option.Unwrap that may panic
+
+Unwrap panics if the Option is None. A call is considered safe if it is only
+reached when a Some, None or IsZero check on the same Option has shown it to
+be Some: inside an if, for or switch case that checks it, on the right of &&
+or || that check it, or after an if that checks it and returns, panics,
+breaks or continues. The Option is not tracked through assignments made
+between the check and the call.`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runUnwrap,
+}
+
+func runUnwrap(pass *analysis.Pass) (any, error) {
+	in := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	in.WithStack([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		x, ok := optionMethod(pass.TypesInfo, call, "Unwrap")
+		if !ok {
+			return true
+		}
+		g := guard{info: pass.TypesInfo, x: x}
+		if !g.guarded(stack) {
+			report(pass, analysis.Diagnostic{
+				Message: fmt.Sprintf("%s.Unwrap may panic: it is not guarded by a check that %s is Some",
+					types.ExprString(x), types.ExprString(x)),
+			}, call)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// guard looks for checks that the Option x is Some.
+type guard struct {
+	info *types.Info
+	x    ast.Expr
+}
+
+// guarded reports whether the last node of stack is only reached when x is
+// Some.
+func (g guard) guarded(stack []ast.Node) bool {
+	for i := len(stack) - 1; i > 0; i-- {
+		parent, child := stack[i-1], stack[i]
+		switch parent := parent.(type) {
+		case *ast.IfStmt:
+			if child == parent.Body && g.someIf(parent.Cond, true) ||
+				child == parent.Else && g.someIf(parent.Cond, false) {
+				return true
+			}
+		case *ast.ForStmt:
+			if child == parent.Body && parent.Cond != nil && g.someIf(parent.Cond, true) {
+				return true
+			}
+		case *ast.BinaryExpr:
+			if child == parent.Y && (parent.Op == token.LAND && g.someIf(parent.X, true) ||
+				parent.Op == token.LOR && g.someIf(parent.X, false)) {
+				return true
+			}
+		case *ast.CaseClause:
+			if g.someCase(stack[i-3], parent, child) || g.someBefore(parent.Body, child) {
+				return true
+			}
+		case *ast.CommClause:
+			if g.someBefore(parent.Body, child) {
+				return true
+			}
+		case *ast.BlockStmt:
+			if g.someBefore(parent.List, child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// someCase reports whether child, a statement in the body of clause, is only
+// reached when x is Some because the clause is a case of a switch without a
+// tag that checks it.
+func (g guard) someCase(sw ast.Node, clause *ast.CaseClause, child ast.Node) bool {
+	s, ok := sw.(*ast.SwitchStmt)
+	if !ok || s.Tag != nil || len(clause.List) == 0 {
+		return false
+	}
+	for _, stmt := range clause.Body {
+		if stmt == child {
+			for _, e := range clause.List {
+				if !g.someIf(e, true) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// someBefore reports whether a statement before child in list is an if
+// statement that leaves the block unless x is Some.
+func (g guard) someBefore(list []ast.Stmt, child ast.Node) bool {
+	for _, stmt := range list {
+		if stmt == child {
+			return false
+		}
+		if s, ok := stmt.(*ast.IfStmt); ok && s.Else == nil && terminates(g.info, s.Body) && g.someIf(s.Cond, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// someIf reports whether x is Some whenever cond evaluates to want.
+func (g guard) someIf(cond ast.Expr, want bool) bool {
+	switch cond := ast.Unparen(cond).(type) {
+	case *ast.CallExpr:
+		if y, ok := optionMethod(g.info, cond, "Some"); ok {
+			return want && g.same(y)
+		}
+		for _, name := range []string{"None", "IsZero"} {
+			if y, ok := optionMethod(g.info, cond, name); ok {
+				return !want && g.same(y)
+			}
+		}
+	case *ast.UnaryExpr:
+		if cond.Op == token.NOT {
+			return g.someIf(cond.X, !want)
+		}
+	case *ast.BinaryExpr:
+		switch {
+		case cond.Op == token.LAND && want, cond.Op == token.LOR && !want:
+			return g.someIf(cond.X, want) || g.someIf(cond.Y, want)
+		case cond.Op == token.LAND, cond.Op == token.LOR:
+			return g.someIf(cond.X, want) && g.someIf(cond.Y, want)
+		}
+	}
+	return false
+}
+
+// same reports whether y refers to the same Option as x.
+func (g guard) same(y ast.Expr) bool {
+	return sameExpr(g.info, g.x, y)
+}
+
+func sameExpr(info *types.Info, a, b ast.Expr) bool {
+	a, b = ast.Unparen(a), ast.Unparen(b)
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && info.ObjectOf(a) != nil && info.ObjectOf(a) == info.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && sameExpr(info, a.Sel, b.Sel) && sameExpr(info, a.X, b.X)
+	case *ast.StarExpr:
+		b, ok := b.(*ast.StarExpr)
+		return ok && sameExpr(info, a.X, b.X)
+	}
+	return false
+}
+
+// terminates reports whether the last statement of body leaves it, by
+// returning, panicking, or branching.
+func terminates(info *types.Info, body *ast.BlockStmt) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+	switch s := body.List[len(body.List)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return s.Tok != token.FALLTHROUGH
+	case *ast.ExprStmt:
+		call, ok := s.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		b, ok := info.Uses[id].(*types.Builtin)
+		return ok && b.Name() == "panic"
+	}
+	return false
+}
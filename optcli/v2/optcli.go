@@ -0,0 +1,240 @@
+// Package optcli defines github.com/urfave/cli/v2 flags whose values are
+// opt.Option values.
+//
+// A flag that is not given on the command line, nor by any of its EnvVars or
+// its FilePath, is None, and one that is given is Some, even if set to its
+// zero value. That tells a command whether the user chose a value before it
+// falls back to a configuration file:
+//
+//	var region opt.Option[string]
+//	app := &cli.App{
+//		Flags: []cli.Flag{
+//			&optcli.StringFlag{
+//				Name:        "region",
+//				EnvVars:     []string{"REGION"},
+//				Destination: &region,
+//			},
+//		},
+//	}
+//
+// Flags of other types are declared as a Flag of that type, such as
+// optcli.Flag[netip.Addr].
+package optcli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/textparse"
+	"github.com/urfave/cli/v2"
+)
+
+// Option flags of the types the cli package has flags for.
+type (
+	StringFlag   = Flag[string]
+	BoolFlag     = Flag[bool]
+	IntFlag      = Flag[int]
+	Int64Flag    = Flag[int64]
+	UintFlag     = Flag[uint]
+	Uint64Flag   = Flag[uint64]
+	Float64Flag  = Flag[float64]
+	DurationFlag = Flag[time.Duration]
+)
+
+// Flag is a flag whose value is an Option[T]. Its fields mean the same as
+// those of the flags of the cli package.
+type Flag[T any] struct {
+	Name string
+
+	Category    string
+	DefaultText string
+	FilePath    string
+	Usage       string
+
+	Required   bool
+	Hidden     bool
+	HasBeenSet bool
+
+	// Value is the value of the flag when it is not set, None by default.
+	Value       opt.Option[T]
+	Destination *opt.Option[T]
+
+	// Parse parses the value of the flag. If nil, values are parsed with
+	// their UnmarshalText method if they have one, or according to their kind
+	// like the flags of the cli package.
+	Parse func(string) (T, error)
+
+	Aliases []string
+	EnvVars []string
+
+	Action func(*cli.Context, opt.Option[T]) error
+}
+
+// Apply implements cli.Flag, defining the flag on set.
+func (f *Flag[T]) Apply(set *flag.FlagSet) error {
+	dest := f.Destination
+	if dest == nil {
+		dest = new(opt.Option[T])
+	}
+	*dest = f.Value
+	parse := f.Parse
+	if parse == nil {
+		parse = textparse.Parse[T]
+	}
+	v := &value[T]{dest: dest, parse: parse}
+
+	if val, source, found := f.lookup(); found {
+		// As with the flags of the cli package, only string flags can be set
+		// to an empty value this way.
+		if val != "" || reflect.TypeFor[T]().Kind() == reflect.String {
+			if err := v.Set(val); err != nil {
+				return fmt.Errorf("could not parse %q from %s as value for flag %s: %s", val, source, f.Name, err)
+			}
+			f.HasBeenSet = true
+		}
+	}
+
+	for _, name := range f.Names() {
+		set.Var(v, name, f.Usage)
+	}
+	return nil
+}
+
+// lookup finds the value of the flag in its environment variables or files.
+func (f *Flag[T]) lookup() (val, source string, found bool) {
+	for _, env := range f.EnvVars {
+		env = strings.TrimSpace(env)
+		if val, ok := os.LookupEnv(env); ok {
+			return val, fmt.Sprintf("environment variable %q", env), true
+		}
+	}
+	for _, path := range strings.Split(f.FilePath, ",") {
+		if path == "" {
+			continue
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			return string(data), fmt.Sprintf("file %q", f.FilePath), true
+		}
+	}
+	return "", "", false
+}
+
+// Names implements cli.Flag.
+func (f *Flag[T]) Names() []string {
+	return cli.FlagNames(f.Name, f.Aliases)
+}
+
+// IsSet implements cli.Flag, reporting whether the flag was set from its
+// environment variables or files.
+func (f *Flag[T]) IsSet() bool {
+	return f.HasBeenSet
+}
+
+// String implements cli.Flag.
+func (f *Flag[T]) String() string {
+	return cli.FlagStringer(f)
+}
+
+// IsRequired implements cli.RequiredFlag.
+func (f *Flag[T]) IsRequired() bool {
+	return f.Required
+}
+
+// IsVisible implements cli.VisibleFlag.
+func (f *Flag[T]) IsVisible() bool {
+	return !f.Hidden
+}
+
+// GetCategory implements cli.CategorizableFlag.
+func (f *Flag[T]) GetCategory() string {
+	return f.Category
+}
+
+// TakesValue implements cli.DocGenerationFlag. Boolean flags do not.
+func (f *Flag[T]) TakesValue() bool {
+	return !textparse.IsBool[T]()
+}
+
+// GetUsage implements cli.DocGenerationFlag.
+func (f *Flag[T]) GetUsage() string {
+	return f.Usage
+}
+
+// GetValue implements cli.DocGenerationFlag.
+func (f *Flag[T]) GetValue() string {
+	if !f.TakesValue() {
+		return ""
+	}
+	return format(f.Value)
+}
+
+// GetDefaultText implements cli.DocGenerationFlag. A flag that is None
+// unless set has no default.
+func (f *Flag[T]) GetDefaultText() string {
+	if f.DefaultText != "" {
+		return f.DefaultText
+	}
+	return format(f.Value)
+}
+
+// GetEnvVars implements cli.DocGenerationFlag.
+func (f *Flag[T]) GetEnvVars() []string {
+	return f.EnvVars
+}
+
+// Get returns the value of the flag in the given Context.
+func (f *Flag[T]) Get(ctx *cli.Context) opt.Option[T] {
+	o, _ := ctx.Value(f.Name).(opt.Option[T])
+	return o
+}
+
+// RunAction implements cli.ActionableFlag.
+func (f *Flag[T]) RunAction(ctx *cli.Context) error {
+	if f.Action != nil {
+		return f.Action(ctx, f.Get(ctx))
+	}
+	return nil
+}
+
+func format[T any](o opt.Option[T]) string {
+	if v, ok := o.MaybeUnwrap(); ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+type value[T any] struct {
+	dest  *opt.Option[T]
+	parse func(string) (T, error)
+}
+
+func (v *value[T]) Set(s string) error {
+	x, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	*v.dest = opt.Some(x)
+	return nil
+}
+
+func (v *value[T]) Get() any {
+	return *v.dest
+}
+
+func (v *value[T]) String() string {
+	// The flag package calls String on a zero value to find out whether a
+	// flag has a default, so dest may be nil.
+	if v.dest == nil {
+		return ""
+	}
+	return format(*v.dest)
+}
+
+func (v *value[T]) IsBoolFlag() bool {
+	return textparse.IsBool[T]()
+}
@@ -0,0 +1,123 @@
+package optcli
+
+import (
+	"bytes"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+type flags struct {
+	name    opt.Option[string]
+	verbose opt.Option[bool]
+	count   opt.Option[int]
+	size    opt.Option[int64]
+	workers opt.Option[uint]
+	limit   opt.Option[uint64]
+	ratio   opt.Option[float64]
+	timeout opt.Option[time.Duration]
+	addr    opt.Option[netip.Addr]
+	port    opt.Option[int]
+	token   opt.Option[string]
+}
+
+func run(t *testing.T, tokenFile string, args ...string) (*flags, string) {
+	t.Helper()
+	var f flags
+	var out bytes.Buffer
+	app := &cli.App{
+		Name:   "test",
+		Writer: &out,
+		Flags: []cli.Flag{
+			&StringFlag{Name: "name", EnvVars: []string{"OPTCLI_TEST_NAME"}, Destination: &f.name},
+			&BoolFlag{Name: "verbose", Aliases: []string{"v"}, Destination: &f.verbose},
+			&IntFlag{Name: "count", EnvVars: []string{"OPTCLI_TEST_COUNT"}, Destination: &f.count},
+			&Int64Flag{Name: "size", Destination: &f.size},
+			&UintFlag{Name: "workers", Destination: &f.workers},
+			&Uint64Flag{Name: "limit", Destination: &f.limit},
+			&Float64Flag{Name: "ratio", Destination: &f.ratio},
+			&DurationFlag{Name: "timeout", Usage: "how long to wait", Value: opt.Some(time.Minute), Destination: &f.timeout},
+			&Flag[netip.Addr]{Name: "addr", Destination: &f.addr},
+			&Flag[int]{Name: "port", Parse: strconv.Atoi, Destination: &f.port},
+			&StringFlag{Name: "token", FilePath: tokenFile, Destination: &f.token},
+		},
+		Action: func(*cli.Context) error {
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(append([]string{"test"}, args...)))
+	return &f, out.String()
+}
+
+func TestFlags(t *testing.T) {
+	f, _ := run(t, "", "-name=", "-v", "-count=0", "-size", "0x10", "-ratio=0.5", "-addr=::1", "-port=8080")
+	require.Equal(t, opt.Some(""), f.name)
+	require.Equal(t, opt.Some(true), f.verbose)
+	require.Equal(t, opt.Some(0), f.count)
+	require.Equal(t, opt.Some(int64(16)), f.size)
+	require.True(t, f.workers.None())
+	require.True(t, f.limit.None())
+	require.Equal(t, opt.Some(0.5), f.ratio)
+	require.Equal(t, opt.Some(time.Minute), f.timeout)
+	require.Equal(t, opt.Some(netip.IPv6Loopback()), f.addr)
+	require.Equal(t, opt.Some(8080), f.port)
+}
+
+func TestUnset(t *testing.T) {
+	f, _ := run(t, "")
+	require.True(t, f.name.None())
+	require.True(t, f.verbose.None())
+	require.True(t, f.count.None())
+	require.True(t, f.token.None())
+	require.Equal(t, opt.Some(time.Minute), f.timeout)
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("OPTCLI_TEST_NAME", "")
+	t.Setenv("OPTCLI_TEST_COUNT", "3")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret"), 0o600))
+
+	f, _ := run(t, tokenFile)
+	require.Equal(t, opt.Some(""), f.name)
+	require.Equal(t, opt.Some(3), f.count)
+	require.Equal(t, opt.Some("secret"), f.token)
+
+	f, _ = run(t, tokenFile, "-count=4")
+	require.Equal(t, opt.Some(4), f.count)
+}
+
+func TestAction(t *testing.T) {
+	var got []opt.Option[int]
+	flag := &IntFlag{
+		Name: "count",
+		Action: func(_ *cli.Context, o opt.Option[int]) error {
+			got = append(got, o)
+			return nil
+		},
+	}
+	app := &cli.App{
+		Flags: []cli.Flag{flag},
+		Action: func(ctx *cli.Context) error {
+			require.Equal(t, opt.Some(2), flag.Get(ctx))
+			require.True(t, ctx.IsSet("count"))
+			return nil
+		},
+	}
+	require.NoError(t, app.Run([]string{"test", "-count=2"}))
+	require.Equal(t, []opt.Option[int]{opt.Some(2)}, got)
+}
+
+func TestHelp(t *testing.T) {
+	_, out := run(t, "", "--help")
+	require.Contains(t, out, "--timeout value  how long to wait (default: 1m0s)")
+	require.Contains(t, out, "--verbose, -v  ")
+	require.Contains(t, out, "[$OPTCLI_TEST_COUNT]")
+}
@@ -0,0 +1,110 @@
+// Package optcli defines github.com/urfave/cli/v3 flags whose values are
+// opt.Option values.
+//
+// A flag that is not given on the command line, nor by any of its Sources, is
+// None, and one that is given is Some, even if set to its zero value. That
+// tells a command whether the user chose a value before it falls back to a
+// configuration file:
+//
+//	var region opt.Option[string]
+//	cmd := &cli.Command{
+//		Flags: []cli.Flag{
+//			&optcli.StringFlag{
+//				Name:        "region",
+//				Sources:     cli.EnvVars("REGION"),
+//				Destination: &region,
+//			},
+//		},
+//	}
+//
+// Help messages name the value of a flag after its type, which for these
+// flags is an Option; as with any flag, a placeholder can be given in
+// backquotes in the usage instead.
+//
+// Flags of other types are declared as a cli.FlagBase of an Option, with a
+// Config and a Value of the same type:
+//
+//	type AddrFlag = cli.FlagBase[opt.Option[netip.Addr], optcli.Config[netip.Addr], optcli.Value[netip.Addr]]
+package optcli
+
+import (
+	"fmt"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/textparse"
+	"github.com/urfave/cli/v3"
+)
+
+// Option flags of the types the cli package has flags for.
+type (
+	StringFlag   = cli.FlagBase[opt.Option[string], Config[string], Value[string]]
+	BoolFlag     = cli.FlagBase[opt.Option[bool], Config[bool], Value[bool]]
+	IntFlag      = cli.FlagBase[opt.Option[int], Config[int], Value[int]]
+	Int64Flag    = cli.FlagBase[opt.Option[int64], Config[int64], Value[int64]]
+	UintFlag     = cli.FlagBase[opt.Option[uint], Config[uint], Value[uint]]
+	Uint64Flag   = cli.FlagBase[opt.Option[uint64], Config[uint64], Value[uint64]]
+	FloatFlag    = cli.FlagBase[opt.Option[float64], Config[float64], Value[float64]]
+	DurationFlag = cli.FlagBase[opt.Option[time.Duration], Config[time.Duration], Value[time.Duration]]
+)
+
+// Config configures how a flag parses its value.
+type Config[T any] struct {
+	// Parse parses the value of the flag. If nil, values are parsed with their
+	// UnmarshalText method if they have one, or according to their kind like
+	// the flags of the cli package.
+	Parse func(string) (T, error)
+}
+
+// Value is the cli.ValueCreator of Option flags, and the cli.Value it
+// creates.
+type Value[T any] struct {
+	dest  *opt.Option[T]
+	parse func(string) (T, error)
+}
+
+// Create implements cli.ValueCreator.
+func (Value[T]) Create(val opt.Option[T], p *opt.Option[T], c Config[T]) cli.Value {
+	*p = val
+	parse := c.Parse
+	if parse == nil {
+		parse = textparse.Parse[T]
+	}
+	return &Value[T]{dest: p, parse: parse}
+}
+
+// ToString implements cli.ValueCreator. None is shown as no default at all.
+func (Value[T]) ToString(o opt.Option[T]) string {
+	if v, ok := o.MaybeUnwrap(); ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// Set implements flag.Value.
+func (v *Value[T]) Set(s string) error {
+	x, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	*v.dest = opt.Some(x)
+	return nil
+}
+
+// Get implements flag.Getter, returning the Option.
+func (v *Value[T]) Get() any {
+	return *v.dest
+}
+
+// String implements flag.Value.
+func (v *Value[T]) String() string {
+	if v.dest == nil {
+		return ""
+	}
+	return v.ToString(*v.dest)
+}
+
+// IsBoolFlag lets boolean flags be passed without a value.
+func (v *Value[T]) IsBoolFlag() bool {
+	return textparse.IsBool[T]()
+}
@@ -0,0 +1,98 @@
+package optcli
+
+import (
+	"bytes"
+	"context"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+type (
+	addrFlag = cli.FlagBase[opt.Option[netip.Addr], Config[netip.Addr], Value[netip.Addr]]
+	portFlag = cli.FlagBase[opt.Option[int], Config[int], Value[int]]
+)
+
+type flags struct {
+	name    opt.Option[string]
+	verbose opt.Option[bool]
+	count   opt.Option[int]
+	size    opt.Option[int64]
+	workers opt.Option[uint]
+	limit   opt.Option[uint64]
+	ratio   opt.Option[float64]
+	timeout opt.Option[time.Duration]
+	addr    opt.Option[netip.Addr]
+	port    opt.Option[int]
+}
+
+func run(t *testing.T, args ...string) (*flags, string) {
+	t.Helper()
+	var f flags
+	var out bytes.Buffer
+	cmd := &cli.Command{
+		Name:   "test",
+		Writer: &out,
+		Flags: []cli.Flag{
+			&StringFlag{Name: "name", Sources: cli.EnvVars("OPTCLI_TEST_NAME"), Destination: &f.name},
+			&BoolFlag{Name: "verbose", Aliases: []string{"v"}, Destination: &f.verbose},
+			&IntFlag{Name: "count", Sources: cli.EnvVars("OPTCLI_TEST_COUNT"), Destination: &f.count},
+			&Int64Flag{Name: "size", Destination: &f.size},
+			&UintFlag{Name: "workers", Destination: &f.workers},
+			&Uint64Flag{Name: "limit", Destination: &f.limit},
+			&FloatFlag{Name: "ratio", Destination: &f.ratio},
+			&DurationFlag{Name: "timeout", Usage: "how long to wait, as a `duration`", Value: opt.Some(time.Minute), Destination: &f.timeout},
+			&addrFlag{Name: "addr", Destination: &f.addr},
+			&portFlag{Name: "port", Config: Config[int]{Parse: strconv.Atoi}, Destination: &f.port},
+		},
+		Action: func(context.Context, *cli.Command) error {
+			return nil
+		},
+	}
+	require.NoError(t, cmd.Run(context.Background(), append([]string{"test"}, args...)))
+	return &f, out.String()
+}
+
+func TestFlags(t *testing.T) {
+	f, _ := run(t, "--name", "", "-v", "--count=0", "--size", "0x10", "--ratio=0.5", "--addr=::1", "--port=8080")
+	require.Equal(t, opt.Some(""), f.name)
+	require.Equal(t, opt.Some(true), f.verbose)
+	require.Equal(t, opt.Some(0), f.count)
+	require.Equal(t, opt.Some(int64(16)), f.size)
+	require.True(t, f.workers.None())
+	require.True(t, f.limit.None())
+	require.Equal(t, opt.Some(0.5), f.ratio)
+	require.Equal(t, opt.Some(time.Minute), f.timeout)
+	require.Equal(t, opt.Some(netip.IPv6Loopback()), f.addr)
+	require.Equal(t, opt.Some(8080), f.port)
+}
+
+func TestUnset(t *testing.T) {
+	f, _ := run(t)
+	require.True(t, f.name.None())
+	require.True(t, f.verbose.None())
+	require.True(t, f.count.None())
+	require.Equal(t, opt.Some(time.Minute), f.timeout)
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("OPTCLI_TEST_NAME", "")
+	t.Setenv("OPTCLI_TEST_COUNT", "3")
+	f, _ := run(t)
+	require.Equal(t, opt.Some(3), f.count)
+
+	f, _ = run(t, "--count=4")
+	require.Equal(t, opt.Some(4), f.count)
+}
+
+func TestHelp(t *testing.T) {
+	_, out := run(t, "--help")
+	require.Contains(t, out, "--timeout duration")
+	require.Contains(t, out, "(default: 1m0s)")
+	require.Contains(t, out, "[$OPTCLI_TEST_COUNT]")
+}
@@ -0,0 +1,48 @@
+// Package optclickhouse helps move opt.Option values in and out of Nullable
+// columns with github.com/ClickHouse/clickhouse-go/v2.
+//
+// One value at a time, Option needs no help: it implements driver.Valuer and
+// sql.Scanner, which clickhouse-go uses to bind query parameters, to append
+// rows to a batch (including structs with AppendStruct) and to scan rows. None
+// is written as NULL and NULL is read back as None.
+//
+// What clickhouse-go cannot handle are slices of Options. Columnar inserts and
+// Array(Nullable(T)) columns only accept slices of pointers, so convert with
+// ToPointers and FromPointers:
+//
+//	err := batch.Column(1).Append(optclickhouse.ToPointers(ages))
+//	...
+//	var tags []*string
+//	err = rows.Scan(&tags)
+//	options := optclickhouse.FromPointers(tags)
+package optclickhouse
+
+import "code.nkcmr.net/opt"
+
+// ToPointers converts each Option in s to a pointer to its value, or nil if it
+// is None.
+func ToPointers[T any](s []opt.Option[T]) []*T {
+	if s == nil {
+		return nil
+	}
+	out := make([]*T, len(s))
+	for i := range s {
+		if v, ok := s[i].MaybeUnwrap(); ok {
+			out[i] = &v
+		}
+	}
+	return out
+}
+
+// FromPointers converts each pointer in s to an Option, which is None if the
+// pointer is nil.
+func FromPointers[T any](s []*T) []opt.Option[T] {
+	if s == nil {
+		return nil
+	}
+	out := make([]opt.Option[T], len(s))
+	for i := range s {
+		out[i] = opt.FromPointer(s[i])
+	}
+	return out
+}
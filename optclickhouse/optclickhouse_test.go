@@ -0,0 +1,81 @@
+package optclickhouse
+
+import (
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/stretchr/testify/require"
+)
+
+func newColumn(t *testing.T, typ string) column.Interface {
+	col, err := column.Type(typ).Column("x", time.UTC)
+	require.NoError(t, err)
+	return col
+}
+
+func TestRows(t *testing.T) {
+	col := newColumn(t, "Nullable(Int32)")
+	require.NoError(t, col.AppendRow(opt.Some(int32(5))))
+	require.NoError(t, col.AppendRow(opt.None[int32]()))
+
+	var o opt.Option[int32]
+	require.NoError(t, col.ScanRow(&o, 0))
+	require.Equal(t, opt.Some(int32(5)), o)
+	require.NoError(t, col.ScanRow(&o, 1))
+	require.True(t, o.None())
+
+	col = newColumn(t, "Nullable(String)")
+	require.NoError(t, col.AppendRow(opt.None[string]()))
+	require.NoError(t, col.AppendRow(opt.Some("nk")))
+
+	var s opt.Option[string]
+	require.NoError(t, col.ScanRow(&s, 0))
+	require.True(t, s.None())
+	require.NoError(t, col.ScanRow(&s, 1))
+	require.Equal(t, opt.Some("nk"), s)
+}
+
+func TestColumns(t *testing.T) {
+	in := []opt.Option[int64]{opt.Some(int64(1)), opt.None[int64](), opt.Some(int64(3))}
+
+	col := newColumn(t, "Nullable(Int64)")
+	_, err := col.Append(in)
+	require.Error(t, err, "clickhouse-go does not understand slices of Options")
+	_, err = col.Append(ToPointers(in))
+	require.NoError(t, err)
+	require.Equal(t, 3, col.Rows())
+
+	var out []opt.Option[int64]
+	for i := 0; i < col.Rows(); i++ {
+		var o opt.Option[int64]
+		require.NoError(t, col.ScanRow(&o, i))
+		out = append(out, o)
+	}
+	require.Equal(t, in, out)
+}
+
+func TestArrays(t *testing.T) {
+	in := []opt.Option[string]{opt.Some("a"), opt.None[string](), opt.Some("c")}
+
+	col := newColumn(t, "Array(Nullable(String))")
+	require.NoError(t, col.AppendRow(in))
+	require.NoError(t, col.AppendRow(ToPointers(in)))
+
+	for i := 0; i < col.Rows(); i++ {
+		var ptrs []*string
+		require.NoError(t, col.ScanRow(&ptrs, i))
+		require.Equal(t, in, FromPointers(ptrs))
+	}
+}
+
+func TestPointers(t *testing.T) {
+	require.Nil(t, ToPointers[int](nil))
+	require.Nil(t, FromPointers[int](nil))
+
+	ptrs := ToPointers([]opt.Option[int]{opt.None[int](), opt.Some(2)})
+	require.Len(t, ptrs, 2)
+	require.Nil(t, ptrs[0])
+	require.Equal(t, 2, *ptrs[1])
+}
@@ -0,0 +1,40 @@
+// Package optdecimal converts between opt.Option and the nullable decimal
+// type of github.com/shopspring/decimal, for optional monetary amounts and
+// other exact decimal values.
+//
+// An Option[decimal.Decimal] needs nothing from this package to be encoded:
+// it marshals to JSON as decimal.Decimal does, as a quoted string unless
+// decimal.MarshalJSONWithoutQuotes is set, or null, and decodes from quoted
+// and unquoted numbers alike without losing precision. It scans from and is
+// written to SQL columns through decimal.Decimal's own Scan and Value
+// methods, with NULL as None.
+package optdecimal
+
+import (
+	"github.com/shopspring/decimal"
+
+	"code.nkcmr.net/opt"
+)
+
+// FromNullDecimal returns Some(n.Decimal) if n is valid, otherwise None.
+func FromNullDecimal(n decimal.NullDecimal) opt.Option[decimal.Decimal] {
+	return opt.FromMaybe(n.Decimal, n.Valid)
+}
+
+// ToNullDecimal returns a decimal.NullDecimal holding the same value as o.
+func ToNullDecimal(o opt.Option[decimal.Decimal]) decimal.NullDecimal {
+	v, ok := o.MaybeUnwrap()
+	return decimal.NullDecimal{Decimal: v, Valid: ok}
+}
+
+// Parse parses s with decimal.NewFromString, returning None if s is empty.
+func Parse(s string) (opt.Option[decimal.Decimal], error) {
+	if s == "" {
+		return opt.None[decimal.Decimal](), nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return opt.None[decimal.Decimal](), err
+	}
+	return opt.Some(d), nil
+}
@@ -0,0 +1,88 @@
+package optdecimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+// requireDecimal checks that o holds a value equal to want, which
+// require.Equal cannot, since equal decimals can be represented differently.
+func requireDecimal(t *testing.T, want string, o opt.Option[decimal.Decimal]) {
+	t.Helper()
+	d, ok := o.MaybeUnwrap()
+	require.True(t, ok, "got None, want %s", want)
+	require.True(t, decimal.RequireFromString(want).Equal(d), "got %s, want %s", d, want)
+}
+
+func TestConvert(t *testing.T) {
+	d := decimal.RequireFromString("19.99")
+	requireDecimal(t, "19.99", FromNullDecimal(decimal.NullDecimal{Decimal: d, Valid: true}))
+	require.True(t, FromNullDecimal(decimal.NullDecimal{Decimal: d}).None())
+	require.Equal(t, decimal.NullDecimal{Decimal: d, Valid: true}, ToNullDecimal(opt.Some(d)))
+	require.Equal(t, decimal.NullDecimal{}, ToNullDecimal(opt.None[decimal.Decimal]()))
+
+	o, err := Parse("-0.005")
+	require.NoError(t, err)
+	requireDecimal(t, "-0.005", o)
+	o, err = Parse("")
+	require.NoError(t, err)
+	require.True(t, o.None())
+	_, err = Parse("1.2.3")
+	require.Error(t, err)
+}
+
+func TestJSON(t *testing.T) {
+	type invoice struct {
+		Total    decimal.Decimal             `json:"total"`
+		Discount opt.Option[decimal.Decimal] `json:"discount"`
+	}
+	const exact = "12345678901234567890.123456789"
+	data, err := json.Marshal(invoice{
+		Total:    decimal.RequireFromString("100"),
+		Discount: opt.Some(decimal.RequireFromString(exact)),
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"total":"100","discount":"`+exact+`"}`, string(data))
+
+	var got invoice
+	require.NoError(t, json.Unmarshal(data, &got))
+	requireDecimal(t, exact, got.Discount)
+
+	data, err = json.Marshal(invoice{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"total":"0","discount":null}`, string(data))
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.True(t, got.Discount.None())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"discount":0.1}`), &got))
+	requireDecimal(t, "0.1", got.Discount)
+	require.Error(t, json.Unmarshal([]byte(`{"discount":"ten"}`), &got))
+}
+
+func TestSQL(t *testing.T) {
+	for _, src := range []any{"42.10", []byte("42.10"), 42.1, int64(42)} {
+		var o opt.Option[decimal.Decimal]
+		require.NoError(t, o.Scan(src), "%T", src)
+		require.True(t, o.Some(), "%T", src)
+	}
+	var o opt.Option[decimal.Decimal]
+	require.NoError(t, o.Scan("42.10"))
+	requireDecimal(t, "42.1", o)
+	require.NoError(t, o.Scan(nil))
+	require.True(t, o.None())
+
+	v, err := opt.Some(decimal.RequireFromString("42.10")).Value()
+	require.NoError(t, err)
+	require.Equal(t, "42.1", v)
+	require.NoError(t, o.Scan(v))
+	requireDecimal(t, "42.10", o)
+
+	v, err = opt.None[decimal.Decimal]().Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
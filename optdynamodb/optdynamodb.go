@@ -0,0 +1,97 @@
+// Package optdynamodb marshals values containing opt.Option fields to and from
+// DynamoDB attribute values with
+// github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue.
+//
+// The functions here wrap their attributevalue counterparts. By default None is
+// written as a NULL attribute value; set EncoderOptions.OmitNone to leave the
+// attribute out of the item instead. Both NULL and missing attributes are read
+// back as None.
+package optdynamodb
+
+import (
+	"reflect"
+	"sync"
+
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// defaultTagKey is the struct tag key attributevalue uses unless configured
+// otherwise.
+const defaultTagKey = "dynamodbav"
+
+// EncoderOptions extends attributevalue.EncoderOptions with settings for
+// Options.
+type EncoderOptions struct {
+	attributevalue.EncoderOptions
+
+	// OmitNone leaves the attribute of a None struct field out of the item
+	// rather than writing a NULL attribute value.
+	OmitNone bool
+}
+
+// Marshal is like attributevalue.MarshalWithOptions but also accepts Options.
+func Marshal(in any, optFns ...func(*EncoderOptions)) (types.AttributeValue, error) {
+	v, avOptFns := encoderArgs(in, optFns)
+	return attributevalue.MarshalWithOptions(v, avOptFns)
+}
+
+// MarshalMap is like attributevalue.MarshalMapWithOptions but also accepts
+// Options.
+func MarshalMap(in any, optFns ...func(*EncoderOptions)) (map[string]types.AttributeValue, error) {
+	v, avOptFns := encoderArgs(in, optFns)
+	return attributevalue.MarshalMapWithOptions(v, avOptFns)
+}
+
+// Unmarshal is like attributevalue.UnmarshalWithOptions but also accepts
+// Options.
+func Unmarshal(av types.AttributeValue, out any, optFns ...func(*attributevalue.DecoderOptions)) error {
+	return nullMapper.Decode(out, func(out any) error {
+		return attributevalue.UnmarshalWithOptions(av, out, optFns...)
+	})
+}
+
+// UnmarshalMap is like attributevalue.UnmarshalMapWithOptions but also accepts
+// Options.
+func UnmarshalMap(m map[string]types.AttributeValue, out any, optFns ...func(*attributevalue.DecoderOptions)) error {
+	return nullMapper.Decode(out, func(out any) error {
+		return attributevalue.UnmarshalMapWithOptions(m, out, optFns...)
+	})
+}
+
+var (
+	nullMapper  = &shadow.Mapper{}
+	omitMappers sync.Map // map[string]*shadow.Mapper, by tag key
+)
+
+func encoderArgs(in any, optFns []func(*EncoderOptions)) (any, func(*attributevalue.EncoderOptions)) {
+	var opts EncoderOptions
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+	avOptFns := func(o *attributevalue.EncoderOptions) {
+		opts := EncoderOptions{EncoderOptions: *o}
+		for _, fn := range optFns {
+			fn(&opts)
+		}
+		*o = opts.EncoderOptions
+	}
+	if in == nil || !shadow.Needs(reflect.TypeOf(in)) {
+		return in, avOptFns
+	}
+	mapper := nullMapper
+	if opts.OmitNone {
+		tagKey := opts.TagKey
+		if tagKey == "" {
+			tagKey = defaultTagKey
+		}
+		m, _ := omitMappers.LoadOrStore(tagKey, &shadow.Mapper{
+			Tag: func(tag reflect.StructTag) reflect.StructTag {
+				return shadow.AddTagOption(tag, tagKey, "omitempty")
+			},
+		})
+		mapper = m.(*shadow.Mapper)
+	}
+	return mapper.To(reflect.ValueOf(in)).Interface(), avOptFns
+}
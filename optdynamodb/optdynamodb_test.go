@@ -0,0 +1,115 @@
+package optdynamodb
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string
+	Zip  opt.Option[string] `dynamodbav:"zip"`
+}
+
+type Item struct {
+	PK       string              `dynamodbav:"pk"`
+	Nickname opt.Option[string]  `dynamodbav:"nickname"`
+	Age      opt.Option[int]     `dynamodbav:"age"`
+	Address  opt.Option[Address] `dynamodbav:"address"`
+}
+
+func TestMarshalMap(t *testing.T) {
+	t.Run("NULL", func(t *testing.T) {
+		m, err := MarshalMap(Item{PK: "a", Age: opt.Some(0)})
+		require.NoError(t, err)
+		require.Equal(t, map[string]types.AttributeValue{
+			"pk":       &types.AttributeValueMemberS{Value: "a"},
+			"nickname": &types.AttributeValueMemberNULL{Value: true},
+			"age":      &types.AttributeValueMemberN{Value: "0"},
+			"address":  &types.AttributeValueMemberNULL{Value: true},
+		}, m)
+	})
+	t.Run("OmitNone", func(t *testing.T) {
+		m, err := MarshalMap(Item{PK: "a", Address: opt.Some(Address{City: "x"})}, func(o *EncoderOptions) {
+			o.OmitNone = true
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]types.AttributeValue{
+			"pk": &types.AttributeValueMemberS{Value: "a"},
+			"address": &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+				"City": &types.AttributeValueMemberS{Value: "x"},
+			}},
+		}, m)
+	})
+	t.Run("plain values", func(t *testing.T) {
+		av, err := Marshal("x")
+		require.NoError(t, err)
+		require.Equal(t, &types.AttributeValueMemberS{Value: "x"}, av)
+
+		av, err = Marshal(opt.None[string]())
+		require.NoError(t, err)
+		require.Equal(t, &types.AttributeValueMemberNULL{Value: true}, av)
+	})
+}
+
+func TestUnmarshalMap(t *testing.T) {
+	in := Item{
+		PK:       "a",
+		Nickname: opt.Some("nk"),
+		Address:  opt.Some(Address{City: "x", Zip: opt.Some("12345")}),
+	}
+	m, err := MarshalMap(in)
+	require.NoError(t, err)
+
+	var out Item
+	require.NoError(t, UnmarshalMap(m, &out))
+	require.Equal(t, in, out)
+
+	out = Item{Nickname: opt.Some("stale"), Age: opt.Some(3)}
+	require.NoError(t, UnmarshalMap(map[string]types.AttributeValue{
+		"pk":       &types.AttributeValueMemberS{Value: "b"},
+		"nickname": &types.AttributeValueMemberNULL{Value: true},
+	}, &out))
+	require.Equal(t, Item{PK: "b", Age: opt.Some(3)}, out)
+
+	require.Error(t, UnmarshalMap(map[string]types.AttributeValue{
+		"age": &types.AttributeValueMemberS{Value: "nope"},
+	}, &out))
+
+	var o opt.Option[int]
+	require.NoError(t, Unmarshal(&types.AttributeValueMemberN{Value: "7"}, &o))
+	require.Equal(t, opt.Some(7), o)
+}
+
+type Tree struct {
+	Name     string
+	Children []Tree
+}
+
+type Versioned struct {
+	Address
+	Version opt.Option[int] `dynamodbav:"version"`
+}
+
+func TestRecursiveAndEmbedded(t *testing.T) {
+	tree := Tree{Name: "root", Children: []Tree{{Name: "leaf"}}}
+	m, err := MarshalMap(tree)
+	require.NoError(t, err)
+	var gotTree Tree
+	require.NoError(t, UnmarshalMap(m, &gotTree))
+	require.Equal(t, tree, gotTree)
+
+	v := Versioned{Address: Address{City: "x", Zip: opt.Some("12345")}, Version: opt.Some(2)}
+	m, err = MarshalMap(v)
+	require.NoError(t, err)
+	require.Equal(t, map[string]types.AttributeValue{
+		"City":    &types.AttributeValueMemberS{Value: "x"},
+		"zip":     &types.AttributeValueMemberS{Value: "12345"},
+		"version": &types.AttributeValueMemberN{Value: "2"},
+	}, m)
+	var gotVersioned Versioned
+	require.NoError(t, UnmarshalMap(m, &gotVersioned))
+	require.Equal(t, v, gotVersioned)
+}
@@ -0,0 +1,76 @@
+// Package optent helps declare opt.Option fields in entgo.io/ent schemas, so
+// that the generated entities expose Options instead of pointers for nullable
+// columns.
+//
+// Option implements driver.Valuer and sql.Scanner, so any ent field whose
+// column type can hold T can use Option[T] as its Go type:
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			optent.Field[string](field.String("nickname")),
+//			optent.Field[int64](field.Int64("age")).Comment("age in years"),
+//		}
+//	}
+//
+// None is stored as NULL and NULL is read back as None. For a T that has to be
+// converted to and from a database value with a field.TypeValueScanner, wrap
+// that scanner with ValueScanner.
+package optent
+
+import (
+	"database/sql/driver"
+
+	"code.nkcmr.net/opt"
+	"entgo.io/ent/schema/field"
+)
+
+// Builder is implemented by the field builders of ent that accept a GoType,
+// such as the ones returned by field.String, field.Int64 and field.Time.
+type Builder[B any] interface {
+	GoType(typ any) B
+	Optional() B
+}
+
+// Field makes the field built by b an optional field of type Option[T]. The
+// builder is returned so that more options can be chained onto it. Do not mark
+// the field as Nillable: None already stands for NULL.
+func Field[T any, B Builder[B]](b B) B {
+	return b.GoType(opt.Option[T]{}).Optional()
+}
+
+// ValueScanner returns a field.TypeValueScanner for Option[T] that stores None
+// as NULL and otherwise defers to vs. A NULL column is detected by the
+// intermediate value returned by vs.ScanValue reporting a nil driver.Value,
+// as sql.NullString and the other sql.Null types do.
+func ValueScanner[T any](vs field.TypeValueScanner[T]) field.TypeValueScanner[opt.Option[T]] {
+	return valueScanner[T]{vs: vs}
+}
+
+type valueScanner[T any] struct {
+	vs field.TypeValueScanner[T]
+}
+
+func (s valueScanner[T]) Value(o opt.Option[T]) (driver.Value, error) {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil, nil
+	}
+	return s.vs.Value(v)
+}
+
+func (s valueScanner[T]) ScanValue() field.ValueScanner {
+	return s.vs.ScanValue()
+}
+
+func (s valueScanner[T]) FromValue(v driver.Value) (opt.Option[T], error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil && dv == nil {
+			return opt.None[T](), nil
+		}
+	}
+	tv, err := s.vs.FromValue(v)
+	if err != nil {
+		return opt.None[T](), err
+	}
+	return opt.Some(tv), nil
+}
@@ -0,0 +1,60 @@
+package optent
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"entgo.io/ent/schema/field"
+	"github.com/stretchr/testify/require"
+)
+
+func TestField(t *testing.T) {
+	for _, desc := range []*field.Descriptor{
+		Field[string](field.String("nickname")).Comment("what friends call you").Descriptor(),
+		Field[int64](field.Int64("age")).Descriptor(),
+		Field[time.Time](field.Time("seen_at")).Descriptor(),
+		Field[[]byte](field.Bytes("avatar")).Descriptor(),
+	} {
+		require.NoError(t, desc.Err, desc.Name)
+		require.True(t, desc.Optional, desc.Name)
+		require.False(t, desc.Nillable, desc.Name)
+		require.Equal(t, "code.nkcmr.net/opt", desc.Info.PkgPath, desc.Name)
+	}
+
+	desc := Field[string](field.String("nickname")).Comment("what friends call you").Descriptor()
+	require.Equal(t, "opt.Option[string]", desc.Info.Ident)
+	require.Equal(t, "what friends call you", desc.Comment)
+}
+
+func TestValueScanner(t *testing.T) {
+	vs := ValueScanner[*big.Int](field.TextValueScanner[*big.Int]{})
+
+	desc := Field[*big.Int](field.String("balance")).ValueScanner(vs).Descriptor()
+	require.NoError(t, desc.Err)
+
+	v, err := vs.Value(opt.None[*big.Int]())
+	require.NoError(t, err)
+	require.Nil(t, v)
+	v, err = vs.Value(opt.Some(big.NewInt(42)))
+	require.NoError(t, err)
+	require.Equal(t, []byte("42"), v)
+
+	scanned := vs.ScanValue()
+	require.NoError(t, scanned.Scan(nil))
+	o, err := vs.FromValue(scanned)
+	require.NoError(t, err)
+	require.True(t, o.None())
+
+	scanned = vs.ScanValue()
+	require.NoError(t, scanned.Scan("42"))
+	o, err = vs.FromValue(scanned)
+	require.NoError(t, err)
+	require.Equal(t, 0, o.Unwrap().Cmp(big.NewInt(42)))
+
+	scanned = vs.ScanValue()
+	require.NoError(t, scanned.Scan("nope"))
+	_, err = vs.FromValue(scanned)
+	require.Error(t, err)
+}
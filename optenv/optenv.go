@@ -0,0 +1,134 @@
+// Package optenv reads environment variables into opt.Option values, which
+// tell a variable that is not set apart from one that is set to the empty
+// string.
+//
+// Decode fills a whole struct at once from variables named by the `env` tags
+// of its fields:
+//
+//	type Config struct {
+//		Addr    string                    `env:"ADDR"`
+//		Timeout opt.Option[time.Duration] `env:"TIMEOUT"`
+//		Token   opt.Option[string]        `env:"TOKEN"`
+//	}
+//
+//	cfg := Config{Addr: ":8080"}
+//	err := optenv.Decode("MYAPP_", &cfg)
+package optenv
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+	"code.nkcmr.net/opt/internal/textparse"
+)
+
+// Lookup returns the value of the environment variable named by key, or None
+// if it is not set.
+func Lookup(key string) opt.Option[string] {
+	return opt.FromMaybe(os.LookupEnv(key))
+}
+
+// Decode sets the fields of the struct dst points to from the environment.
+//
+// A field tagged `env:"NAME"` is read from the variable prefix+NAME. An Option
+// field is None if the variable is not set, and Some if it is, even to the
+// empty string. Any other field is left as it is if the variable is not set,
+// so it can be given a default beforehand.
+//
+// Values are parsed with their UnmarshalText method if they have one,
+// time.ParseDuration for durations, and according to their kind otherwise:
+// strings, booleans, integers and floating-point numbers.
+//
+// Fields of struct types without an UnmarshalText method, including embedded
+// ones, are decoded recursively, with their own tag appended to the prefix.
+// An Option of such a struct is Some if any of its variables is set. Fields
+// tagged `env:"-"` are skipped.
+func Decode(prefix string, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optenv: cannot decode into %T, it is not a pointer to a struct", dst)
+	}
+	_, err := decodeStruct(prefix, rv.Elem())
+	return err
+}
+
+// decodeStruct decodes the fields of the struct rv and reports whether any of
+// their variables were set.
+func decodeStruct(prefix string, rv reflect.Value) (found bool, err error) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		name, tagged := sf.Tag.Lookup("env")
+		if name == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+		if isNested(sf.Type) {
+			ok, err := decodeField(prefix+name, fv, true)
+			if err != nil {
+				return false, err
+			}
+			found = found || ok
+			continue
+		}
+		if !tagged || !sf.IsExported() {
+			continue
+		}
+		ok, err := decodeField(prefix+name, fv, false)
+		if err != nil {
+			return false, err
+		}
+		found = found || ok
+	}
+	return found, nil
+}
+
+// decodeField decodes the variable key, or the variables starting with key if
+// nested, into fv, which may be an Option.
+func decodeField(key string, fv reflect.Value, nested bool) (bool, error) {
+	if elem, ok := optreflect.Elem(fv.Type()); ok {
+		inner := reflect.New(elem).Elem()
+		found, err := decodeValue(key, inner, nested)
+		if err != nil {
+			return false, err
+		}
+		if found {
+			optreflect.Set(fv.Addr(), inner)
+		} else {
+			optreflect.Set(fv.Addr(), reflect.Value{})
+		}
+		return found, nil
+	}
+	return decodeValue(key, fv, nested)
+}
+
+func decodeValue(key string, rv reflect.Value, nested bool) (bool, error) {
+	if nested {
+		return decodeStruct(key, rv)
+	}
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return false, nil
+	}
+	if err := textparse.Set(rv, s); err != nil {
+		return false, fmt.Errorf("optenv: %s: %w", key, err)
+	}
+	return true, nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[interface{ UnmarshalText([]byte) error }]()
+
+// isNested reports whether fields of type t are decoded from several
+// variables rather than one, looking through Options.
+func isNested(t reflect.Type) bool {
+	if elem, ok := optreflect.Elem(t); ok {
+		t = elem
+	}
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
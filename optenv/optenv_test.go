@@ -0,0 +1,91 @@
+package optenv
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type Database struct {
+	Host opt.Option[string] `env:"HOST"`
+	Port int                `env:"PORT"`
+}
+
+type Common struct {
+	Debug opt.Option[bool] `env:"DEBUG"`
+}
+
+type Config struct {
+	Common
+	Addr     string                    `env:"ADDR"`
+	Token    opt.Option[string]        `env:"TOKEN"`
+	Workers  opt.Option[int]           `env:"WORKERS"`
+	Timeout  opt.Option[time.Duration] `env:"TIMEOUT"`
+	Ratio    float64                   `env:"RATIO"`
+	Listen   opt.Option[netip.Addr]    `env:"LISTEN"`
+	Database Database                  `env:"DB_"`
+	Replica  opt.Option[Database]      `env:"REPLICA_"`
+	Ignored  string                    `env:"-"`
+	Untagged string
+	internal string `env:"INTERNAL"`
+}
+
+func TestDecode(t *testing.T) {
+	t.Setenv("TEST_ADDR", ":9090")
+	t.Setenv("TEST_TOKEN", "")
+	t.Setenv("TEST_TIMEOUT", "5s")
+	t.Setenv("TEST_LISTEN", "::1")
+	t.Setenv("TEST_DEBUG", "true")
+	t.Setenv("TEST_DB_PORT", "5432")
+	t.Setenv("TEST_INTERNAL", "x")
+	t.Setenv("TEST_", "x")
+
+	cfg := Config{
+		Ratio:   0.5,
+		Workers: opt.Some(4),
+		Replica: opt.Some(Database{Port: 1}),
+		Ignored: "kept",
+	}
+	require.NoError(t, Decode("TEST_", &cfg))
+	require.Equal(t, Config{
+		Common:   Common{Debug: opt.Some(true)},
+		Addr:     ":9090",
+		Token:    opt.Some(""),
+		Workers:  opt.None[int](),
+		Timeout:  opt.Some(5 * time.Second),
+		Ratio:    0.5,
+		Listen:   opt.Some(netip.IPv6Loopback()),
+		Database: Database{Port: 5432},
+		Ignored:  "kept",
+	}, cfg)
+}
+
+func TestDecodeNestedOption(t *testing.T) {
+	t.Setenv("REPLICA_HOST", "db2")
+
+	var cfg Config
+	require.NoError(t, Decode("", &cfg))
+	require.Equal(t, opt.Some(Database{Host: opt.Some("db2")}), cfg.Replica)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	t.Setenv("TEST_WORKERS", "many")
+	var cfg Config
+	err := Decode("TEST_", &cfg)
+	require.ErrorContains(t, err, "optenv: TEST_WORKERS: ")
+
+	t.Setenv("TEST_WORKERS", "")
+	require.Error(t, Decode("TEST_", &cfg))
+
+	require.Error(t, Decode("TEST_", cfg))
+	require.Error(t, Decode("TEST_", (*Config)(nil)))
+}
+
+func TestLookup(t *testing.T) {
+	t.Setenv("OPTENV_TEST_SET", "")
+	require.Equal(t, opt.Some(""), Lookup("OPTENV_TEST_SET"))
+	require.True(t, Lookup("OPTENV_TEST_UNSET").None())
+}
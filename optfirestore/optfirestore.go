@@ -0,0 +1,56 @@
+// Package optfirestore converts structs with opt.Option fields to and from the
+// values accepted by cloud.google.com/go/firestore.
+//
+// The Firestore client only understands pointers as optional values, so Data
+// maps a struct to a mirror type in which every Option[T] field is a *T, and
+// DataTo maps a document back. None is stored as null by Data, or left out of
+// the document entirely by DataOmitNone. Both null and missing fields are read
+// back as None.
+//
+//	_, err := client.Doc("users/nk").Set(ctx, optfirestore.Data(user))
+//	...
+//	err = optfirestore.DataTo(snap, &user)
+package optfirestore
+
+import (
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/shadow"
+)
+
+var (
+	nullMapper = &shadow.Mapper{}
+	omitMapper = &shadow.Mapper{
+		Tag: func(tag reflect.StructTag) reflect.StructTag {
+			return shadow.AddTagOption(tag, "firestore", "omitempty")
+		},
+	}
+)
+
+// Data returns v in a form that can be passed to DocumentRef.Set, Create and
+// the like. None fields are stored as null.
+func Data(v any) any {
+	if v == nil {
+		return nil
+	}
+	return nullMapper.To(reflect.ValueOf(v)).Interface()
+}
+
+// DataOmitNone is like Data but None fields are left out of the document.
+func DataOmitNone(v any) any {
+	if v == nil {
+		return nil
+	}
+	return omitMapper.To(reflect.ValueOf(v)).Interface()
+}
+
+// Snapshot is the part of *firestore.DocumentSnapshot that DataTo uses.
+type Snapshot interface {
+	DataTo(p any) error
+}
+
+// DataTo is like (*firestore.DocumentSnapshot).DataTo but also accepts
+// Options.
+func DataTo(snap Snapshot, p any) error {
+	return nullMapper.Decode(p, snap.DataTo)
+}
@@ -0,0 +1,60 @@
+package optfirestore
+
+import (
+	"reflect"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type User struct {
+	Name     string             `firestore:"name"`
+	Nickname opt.Option[string] `firestore:"nickname"`
+	Age      opt.Option[int]    `firestore:"age,omitempty"`
+}
+
+// fakeSnapshot stands in for *firestore.DocumentSnapshot by copying fields of
+// data into p by name, leaving missing fields alone like Firestore does.
+type fakeSnapshot map[string]any
+
+func (s fakeSnapshot) DataTo(p any) error {
+	rv := reflect.ValueOf(p).Elem()
+	for name, v := range s {
+		f := rv.FieldByName(name)
+		if v == nil {
+			f.SetZero()
+			continue
+		}
+		ptr := reflect.New(f.Type().Elem())
+		ptr.Elem().Set(reflect.ValueOf(v))
+		f.Set(ptr)
+	}
+	return nil
+}
+
+func TestData(t *testing.T) {
+	d := reflect.ValueOf(Data(User{Name: "a", Age: opt.Some(0)}))
+	require.Equal(t, "a", d.FieldByName("Name").Interface())
+	require.True(t, d.FieldByName("Nickname").IsNil())
+	require.Equal(t, 0, d.FieldByName("Age").Elem().Interface())
+
+	f, _ := d.Type().FieldByName("Nickname")
+	require.Equal(t, `firestore:"nickname"`, string(f.Tag))
+
+	d = reflect.ValueOf(DataOmitNone(User{}))
+	f, _ = d.Type().FieldByName("Nickname")
+	require.Equal(t, `firestore:"nickname,omitempty"`, string(f.Tag))
+	f, _ = d.Type().FieldByName("Age")
+	require.Equal(t, `firestore:"age,omitempty"`, string(f.Tag))
+
+	require.Nil(t, Data(nil))
+	require.Equal(t, "x", Data("x"))
+}
+
+func TestDataTo(t *testing.T) {
+	u := User{Name: "stale", Nickname: opt.Some("stale"), Age: opt.Some(3)}
+	err := DataTo(fakeSnapshot{"Nickname": nil, "Age": 4}, &u)
+	require.NoError(t, err)
+	require.Equal(t, User{Name: "stale", Age: opt.Some(4)}, u)
+}
@@ -0,0 +1,245 @@
+// Package optflag defines command-line flags, with the standard flag package,
+// that are backed by opt.Option values.
+//
+// A flag.Value cannot tell whether it was set to its zero value or not set at
+// all. An Option can: it is left as None when the flag is not passed and
+// becomes Some when it is, even if the value given is the zero value:
+//
+//	var timeout opt.Option[time.Duration]
+//	optflag.DurationVar(flag.CommandLine, &timeout, "timeout", "request timeout")
+//	flag.Parse()
+//	if d, ok := timeout.MaybeUnwrap(); ok {
+//		...
+//	}
+//
+// An Option that is already Some when the flag is defined is kept until the
+// flag is passed, and is shown as the flag's default in usage messages.
+//
+// Flags can also be defined on a github.com/spf13/pflag FlagSet, which is
+// what cobra commands use, with the functions ending in P:
+//
+//	optflag.StringVarP(cmd.Flags(), &region, "region", "r", "region to deploy to")
+package optflag
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"code.nkcmr.net/opt"
+)
+
+// Value returns a flag.Value that stores the result of parse into dest every
+// time the flag is set. The value returned also implements flag.Getter, with
+// Get returning the Option itself.
+func Value[T any](dest *opt.Option[T], parse func(string) (T, error)) flag.Value {
+	return &value[T]{dest: dest, parse: parse}
+}
+
+type value[T any] struct {
+	dest  *opt.Option[T]
+	parse func(string) (T, error)
+	// typ overrides the name Type reports for T.
+	typ string
+}
+
+func (v *value[T]) String() string {
+	// The flag package calls String on a zero value to find out whether a
+	// flag has a default, so dest may be nil.
+	if v.dest == nil {
+		return ""
+	}
+	if x, ok := v.dest.MaybeUnwrap(); ok {
+		return fmt.Sprint(x)
+	}
+	return ""
+}
+
+func (v *value[T]) Set(s string) error {
+	x, err := v.parse(s)
+	if err != nil {
+		return err
+	}
+	*v.dest = opt.Some(x)
+	return nil
+}
+
+func (v *value[T]) Get() any {
+	return *v.dest
+}
+
+// Type implements pflag.Value, naming the type of the flag in usage messages.
+func (v *value[T]) Type() string {
+	if v.typ != "" {
+		return v.typ
+	}
+	return reflect.TypeFor[T]().String()
+}
+
+// boolValue lets boolean flags be passed without a value, as in "-v".
+type boolValue struct {
+	value[bool]
+}
+
+func (*boolValue) IsBoolFlag() bool {
+	return true
+}
+
+// StringVar defines a string flag with the given name and usage, stored in p.
+func StringVar(fs *flag.FlagSet, p *opt.Option[string], name, usage string) {
+	fs.Var(Value(p, parseString), name, usage)
+}
+
+// String defines a string flag with the given name and usage, and returns
+// the Option it is stored in.
+func String(fs *flag.FlagSet, name, usage string) *opt.Option[string] {
+	p := new(opt.Option[string])
+	StringVar(fs, p, name, usage)
+	return p
+}
+
+// BoolVar defines a bool flag with the given name and usage, stored in p. Like
+// the flags of the flag package, it can be passed without a value to set it
+// to true.
+func BoolVar(fs *flag.FlagSet, p *opt.Option[bool], name, usage string) {
+	fs.Var(&boolValue{value[bool]{dest: p, parse: strconv.ParseBool}}, name, usage)
+}
+
+// Bool defines a bool flag with the given name and usage, and returns the
+// Option it is stored in.
+func Bool(fs *flag.FlagSet, name, usage string) *opt.Option[bool] {
+	p := new(opt.Option[bool])
+	BoolVar(fs, p, name, usage)
+	return p
+}
+
+// IntVar defines an int flag with the given name and usage, stored in p.
+func IntVar(fs *flag.FlagSet, p *opt.Option[int], name, usage string) {
+	fs.Var(Value(p, parseInt), name, usage)
+}
+
+// Int defines an int flag with the given name and usage, and returns the
+// Option it is stored in.
+func Int(fs *flag.FlagSet, name, usage string) *opt.Option[int] {
+	p := new(opt.Option[int])
+	IntVar(fs, p, name, usage)
+	return p
+}
+
+// Int64Var defines an int64 flag with the given name and usage, stored in p.
+func Int64Var(fs *flag.FlagSet, p *opt.Option[int64], name, usage string) {
+	fs.Var(Value(p, parseInt64), name, usage)
+}
+
+// Int64 defines an int64 flag with the given name and usage, and returns the
+// Option it is stored in.
+func Int64(fs *flag.FlagSet, name, usage string) *opt.Option[int64] {
+	p := new(opt.Option[int64])
+	Int64Var(fs, p, name, usage)
+	return p
+}
+
+// UintVar defines a uint flag with the given name and usage, stored in p.
+func UintVar(fs *flag.FlagSet, p *opt.Option[uint], name, usage string) {
+	fs.Var(Value(p, parseUint), name, usage)
+}
+
+// Uint defines a uint flag with the given name and usage, and returns the
+// Option it is stored in.
+func Uint(fs *flag.FlagSet, name, usage string) *opt.Option[uint] {
+	p := new(opt.Option[uint])
+	UintVar(fs, p, name, usage)
+	return p
+}
+
+// Uint64Var defines a uint64 flag with the given name and usage, stored in p.
+func Uint64Var(fs *flag.FlagSet, p *opt.Option[uint64], name, usage string) {
+	fs.Var(Value(p, parseUint64), name, usage)
+}
+
+// Uint64 defines a uint64 flag with the given name and usage, and returns the
+// Option it is stored in.
+func Uint64(fs *flag.FlagSet, name, usage string) *opt.Option[uint64] {
+	p := new(opt.Option[uint64])
+	Uint64Var(fs, p, name, usage)
+	return p
+}
+
+// Float64Var defines a float64 flag with the given name and usage, stored in
+// p.
+func Float64Var(fs *flag.FlagSet, p *opt.Option[float64], name, usage string) {
+	fs.Var(Value(p, parseFloat64), name, usage)
+}
+
+// Float64 defines a float64 flag with the given name and usage, and returns
+// the Option it is stored in.
+func Float64(fs *flag.FlagSet, name, usage string) *opt.Option[float64] {
+	p := new(opt.Option[float64])
+	Float64Var(fs, p, name, usage)
+	return p
+}
+
+// DurationVar defines a time.Duration flag with the given name and usage,
+// stored in p. The flag accepts anything time.ParseDuration does.
+func DurationVar(fs *flag.FlagSet, p *opt.Option[time.Duration], name, usage string) {
+	fs.Var(durationValue(p), name, usage)
+}
+
+// Duration defines a time.Duration flag with the given name and usage, and
+// returns the Option it is stored in.
+func Duration(fs *flag.FlagSet, name, usage string) *opt.Option[time.Duration] {
+	p := new(opt.Option[time.Duration])
+	DurationVar(fs, p, name, usage)
+	return p
+}
+
+// TextVar defines a flag with the given name and usage, stored in p, whose
+// value is parsed with the UnmarshalText method of T.
+func TextVar[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](fs *flag.FlagSet, p *opt.Option[T], name, usage string) {
+	fs.Var(Value(p, parseText[T, PT]), name, usage)
+}
+
+func durationValue(p *opt.Option[time.Duration]) *value[time.Duration] {
+	return &value[time.Duration]{dest: p, parse: time.ParseDuration, typ: "duration"}
+}
+
+func parseText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](s string) (T, error) {
+	var v T
+	err := PT(&v).UnmarshalText([]byte(s))
+	return v, err
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func parseInt(s string) (int, error) {
+	i, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	return int(i), err
+}
+
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 0, 64)
+}
+
+func parseUint(s string) (uint, error) {
+	u, err := strconv.ParseUint(s, 0, strconv.IntSize)
+	return uint(u), err
+}
+
+func parseUint64(s string) (uint64, error) {
+	return strconv.ParseUint(s, 0, 64)
+}
+
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
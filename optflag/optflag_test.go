@@ -0,0 +1,89 @@
+package optflag
+
+import (
+	"bytes"
+	"flag"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	return fs
+}
+
+func TestFlags(t *testing.T) {
+	fs := newFlagSet()
+	name := String(fs, "name", "")
+	verbose := Bool(fs, "v", "")
+	count := Int(fs, "count", "")
+	size := Int64(fs, "size", "")
+	workers := Uint(fs, "workers", "")
+	limit := Uint64(fs, "limit", "")
+	ratio := Float64(fs, "ratio", "")
+	timeout := Duration(fs, "timeout", "")
+	var addr opt.Option[netip.Addr]
+	TextVar(fs, &addr, "addr", "")
+
+	err := fs.Parse([]string{"-name=", "-v", "-count=0", "-size", "0x10", "-ratio=0.5", "-timeout=1s", "-addr=::1"})
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(""), *name)
+	require.Equal(t, opt.Some(true), *verbose)
+	require.Equal(t, opt.Some(0), *count)
+	require.Equal(t, opt.Some(int64(16)), *size)
+	require.True(t, workers.None())
+	require.True(t, limit.None())
+	require.Equal(t, opt.Some(0.5), *ratio)
+	require.Equal(t, opt.Some(time.Second), *timeout)
+	require.Equal(t, opt.Some(netip.IPv6Loopback()), addr)
+
+	require.Equal(t, opt.Some(0), fs.Lookup("count").Value.(flag.Getter).Get())
+	require.Equal(t, "1s", fs.Lookup("timeout").Value.String())
+	require.Equal(t, "", fs.Lookup("workers").Value.String())
+}
+
+func TestBoolFalse(t *testing.T) {
+	fs := newFlagSet()
+	verbose := Bool(fs, "v", "")
+	require.NoError(t, fs.Parse([]string{"-v=false"}))
+	require.Equal(t, opt.Some(false), *verbose)
+}
+
+func TestInvalid(t *testing.T) {
+	fs := newFlagSet()
+	count := Int(fs, "count", "")
+	require.Error(t, fs.Parse([]string{"-count=nope"}))
+	require.True(t, count.None())
+}
+
+func TestValue(t *testing.T) {
+	fs := newFlagSet()
+	port := opt.Some(8080)
+	fs.Var(Value(&port, strconv.Atoi), "port", "port to listen on")
+
+	var usage bytes.Buffer
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	require.Contains(t, usage.String(), "(default 8080)")
+
+	require.NoError(t, fs.Parse(nil))
+	require.Equal(t, opt.Some(8080), port)
+	require.NoError(t, fs.Parse([]string{"-port=9090"}))
+	require.Equal(t, opt.Some(9090), port)
+}
+
+func TestNoDefault(t *testing.T) {
+	fs := newFlagSet()
+	Int(fs, "count", "how many")
+
+	var usage bytes.Buffer
+	fs.SetOutput(&usage)
+	fs.PrintDefaults()
+	require.NotContains(t, usage.String(), "default")
+}
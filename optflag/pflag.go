@@ -0,0 +1,133 @@
+package optflag
+
+import (
+	"encoding"
+	"strconv"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/spf13/pflag"
+)
+
+// VarP defines a flag on a pflag.FlagSet with the given name, shorthand and
+// usage, which stores the result of parse into p. It is the pflag counterpart
+// of Value. The shorthand may be empty.
+func VarP[T any](fs *pflag.FlagSet, p *opt.Option[T], parse func(string) (T, error), name, shorthand, usage string) {
+	fs.VarP(&value[T]{dest: p, parse: parse}, name, shorthand, usage)
+}
+
+// StringVarP defines a string flag on a pflag.FlagSet, stored in p.
+func StringVarP(fs *pflag.FlagSet, p *opt.Option[string], name, shorthand, usage string) {
+	VarP(fs, p, parseString, name, shorthand, usage)
+}
+
+// StringP defines a string flag on a pflag.FlagSet and returns the Option it
+// is stored in.
+func StringP(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[string] {
+	p := new(opt.Option[string])
+	StringVarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// BoolVarP defines a bool flag on a pflag.FlagSet, stored in p. Like the
+// flags of the pflag package, it can be passed without a value to set it to
+// true.
+func BoolVarP(fs *pflag.FlagSet, p *opt.Option[bool], name, shorthand, usage string) {
+	f := fs.VarPF(&boolValue{value[bool]{dest: p, parse: strconv.ParseBool}}, name, shorthand, usage)
+	f.NoOptDefVal = "true"
+}
+
+// BoolP defines a bool flag on a pflag.FlagSet and returns the Option it is
+// stored in.
+func BoolP(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[bool] {
+	p := new(opt.Option[bool])
+	BoolVarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// IntVarP defines an int flag on a pflag.FlagSet, stored in p.
+func IntVarP(fs *pflag.FlagSet, p *opt.Option[int], name, shorthand, usage string) {
+	VarP(fs, p, parseInt, name, shorthand, usage)
+}
+
+// IntP defines an int flag on a pflag.FlagSet and returns the Option it is
+// stored in.
+func IntP(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[int] {
+	p := new(opt.Option[int])
+	IntVarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// Int64VarP defines an int64 flag on a pflag.FlagSet, stored in p.
+func Int64VarP(fs *pflag.FlagSet, p *opt.Option[int64], name, shorthand, usage string) {
+	VarP(fs, p, parseInt64, name, shorthand, usage)
+}
+
+// Int64P defines an int64 flag on a pflag.FlagSet and returns the Option it
+// is stored in.
+func Int64P(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[int64] {
+	p := new(opt.Option[int64])
+	Int64VarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// UintVarP defines a uint flag on a pflag.FlagSet, stored in p.
+func UintVarP(fs *pflag.FlagSet, p *opt.Option[uint], name, shorthand, usage string) {
+	VarP(fs, p, parseUint, name, shorthand, usage)
+}
+
+// UintP defines a uint flag on a pflag.FlagSet and returns the Option it is
+// stored in.
+func UintP(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[uint] {
+	p := new(opt.Option[uint])
+	UintVarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// Uint64VarP defines a uint64 flag on a pflag.FlagSet, stored in p.
+func Uint64VarP(fs *pflag.FlagSet, p *opt.Option[uint64], name, shorthand, usage string) {
+	VarP(fs, p, parseUint64, name, shorthand, usage)
+}
+
+// Uint64P defines a uint64 flag on a pflag.FlagSet and returns the Option it
+// is stored in.
+func Uint64P(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[uint64] {
+	p := new(opt.Option[uint64])
+	Uint64VarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// Float64VarP defines a float64 flag on a pflag.FlagSet, stored in p.
+func Float64VarP(fs *pflag.FlagSet, p *opt.Option[float64], name, shorthand, usage string) {
+	VarP(fs, p, parseFloat64, name, shorthand, usage)
+}
+
+// Float64P defines a float64 flag on a pflag.FlagSet and returns the Option
+// it is stored in.
+func Float64P(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[float64] {
+	p := new(opt.Option[float64])
+	Float64VarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// DurationVarP defines a time.Duration flag on a pflag.FlagSet, stored in p.
+func DurationVarP(fs *pflag.FlagSet, p *opt.Option[time.Duration], name, shorthand, usage string) {
+	fs.VarP(durationValue(p), name, shorthand, usage)
+}
+
+// DurationP defines a time.Duration flag on a pflag.FlagSet and returns the
+// Option it is stored in.
+func DurationP(fs *pflag.FlagSet, name, shorthand, usage string) *opt.Option[time.Duration] {
+	p := new(opt.Option[time.Duration])
+	DurationVarP(fs, p, name, shorthand, usage)
+	return p
+}
+
+// TextVarP defines a flag on a pflag.FlagSet, stored in p, whose value is
+// parsed with the UnmarshalText method of T.
+func TextVarP[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](fs *pflag.FlagSet, p *opt.Option[T], name, shorthand, usage string) {
+	VarP(fs, p, parseText[T, PT], name, shorthand, usage)
+}
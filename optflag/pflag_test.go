@@ -0,0 +1,79 @@
+package optflag
+
+import (
+	"bytes"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPflag(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	name := StringP(fs, "name", "n", "")
+	verbose := BoolP(fs, "verbose", "v", "")
+	count := IntP(fs, "count", "c", "")
+	size := Int64P(fs, "size", "", "")
+	workers := UintP(fs, "workers", "", "")
+	limit := Uint64P(fs, "limit", "", "")
+	ratio := Float64P(fs, "ratio", "", "")
+	timeout := DurationP(fs, "timeout", "t", "")
+	var addr opt.Option[netip.Addr]
+	TextVarP(fs, &addr, "addr", "", "")
+
+	err := fs.Parse([]string{"-n", "", "-v", "--count=0", "--size", "16", "--ratio=0.5", "-t", "1s", "--addr=::1"})
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(""), *name)
+	require.Equal(t, opt.Some(true), *verbose)
+	require.Equal(t, opt.Some(0), *count)
+	require.Equal(t, opt.Some(int64(16)), *size)
+	require.True(t, workers.None())
+	require.True(t, limit.None())
+	require.Equal(t, opt.Some(0.5), *ratio)
+	require.Equal(t, opt.Some(time.Second), *timeout)
+	require.Equal(t, opt.Some(netip.IPv6Loopback()), addr)
+
+	require.Equal(t, "string", fs.Lookup("name").Value.Type())
+	require.Equal(t, "bool", fs.Lookup("verbose").Value.Type())
+	require.Equal(t, "duration", fs.Lookup("timeout").Value.Type())
+	require.Equal(t, "netip.Addr", fs.Lookup("addr").Value.Type())
+	require.True(t, fs.Changed("count"))
+	require.False(t, fs.Changed("workers"))
+}
+
+func TestCobra(t *testing.T) {
+	var (
+		region opt.Option[string]
+		port   = opt.Some(8080)
+		dryRun opt.Option[bool]
+	)
+	cmd := &cobra.Command{
+		Use: "deploy",
+		RunE: func(*cobra.Command, []string) error {
+			return nil
+		},
+	}
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	StringVarP(cmd.Flags(), &region, "region", "r", "region to deploy to")
+	VarP(cmd.Flags(), &port, strconv.Atoi, "port", "p", "port to listen on")
+	BoolVarP(cmd.Flags(), &dryRun, "dry-run", "", "only print what would happen")
+
+	usage := cmd.UsageString()
+	require.Contains(t, usage, "-r, --region string")
+	require.Contains(t, usage, "-p, --port int")
+	require.Contains(t, usage, "(default 8080)")
+	require.NotContains(t, usage, `(default "")`)
+
+	cmd.SetArgs([]string{"-r", "us-east-1", "--dry-run=false"})
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, opt.Some("us-east-1"), region)
+	require.Equal(t, opt.Some(8080), port)
+	require.Equal(t, opt.Some(false), dryRun)
+}
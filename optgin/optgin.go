@@ -0,0 +1,99 @@
+// Package optgin binds the parameters of Gin requests into structs with
+// opt.Option fields.
+//
+// Gin only binds query strings, forms, URI parameters and headers into
+// struct fields of types it knows or that implement its own BindUnmarshaler
+// interface, which Option does not. The bindings here instead bind a mirror
+// type in which every Option[T] is a *T, so an Option is bound as Gin binds a
+// pointer: a parameter that is present is Some, even if it is empty, and a
+// parameter that is missing leaves the Option as it was, unless the "default"
+// tag option gives it a value. Validation runs on the mirror, so "required"
+// fails for a missing parameter.
+//
+//	type listRequest struct {
+//		Org    string             `uri:"org"`
+//		Cursor opt.Option[string] `form:"cursor"`
+//		Limit  opt.Option[int]    `form:"limit,default=50"`
+//	}
+//
+//	var req listRequest
+//	if err := optgin.ShouldBindUri(c, &req); err != nil {
+//		// ...
+//	}
+//	if err := optgin.ShouldBind(c, &req); err != nil {
+//		// ...
+//	}
+package optgin
+
+import (
+	"net/http"
+
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var mapper = &shadow.Mapper{}
+
+// The bindings of package binding that read parameters, wrapped to also
+// accept Options.
+var (
+	Form          binding.Binding    = Wrap(binding.Form)
+	Query         binding.Binding    = Wrap(binding.Query)
+	FormPost      binding.Binding    = Wrap(binding.FormPost)
+	FormMultipart binding.Binding    = Wrap(binding.FormMultipart)
+	Header        binding.Binding    = Wrap(binding.Header)
+	Uri           binding.BindingUri = uriBinding{binding.Uri}
+)
+
+// Wrap returns a binding that binds like b but also accepts Options.
+func Wrap(b binding.Binding) binding.Binding {
+	return wrapped{b}
+}
+
+// Default is like binding.Default but also accepts Options.
+func Default(method, contentType string) binding.Binding {
+	return Wrap(binding.Default(method, contentType))
+}
+
+// ShouldBind is like c.ShouldBind but also accepts Options.
+func ShouldBind(c *gin.Context, obj any) error {
+	return c.ShouldBindWith(obj, Default(c.Request.Method, c.ContentType()))
+}
+
+// ShouldBindUri is like c.ShouldBindUri but also accepts Options.
+func ShouldBindUri(c *gin.Context, obj any) error {
+	m := make(map[string][]string, len(c.Params))
+	for _, p := range c.Params {
+		m[p.Key] = []string{p.Value}
+	}
+	return Uri.BindUri(m, obj)
+}
+
+type wrapped struct {
+	b binding.Binding
+}
+
+func (w wrapped) Name() string {
+	return w.b.Name()
+}
+
+func (w wrapped) Bind(req *http.Request, obj any) error {
+	return mapper.Decode(obj, func(out any) error {
+		return w.b.Bind(req, out)
+	})
+}
+
+type uriBinding struct {
+	b binding.BindingUri
+}
+
+func (u uriBinding) Name() string {
+	return u.b.Name()
+}
+
+func (u uriBinding) BindUri(m map[string][]string, obj any) error {
+	return mapper.Decode(obj, func(out any) error {
+		return u.b.BindUri(m, out)
+	})
+}
@@ -0,0 +1,83 @@
+package optgin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	ID     opt.Option[int64]  `uri:"id"`
+	Cursor opt.Option[string] `form:"cursor" json:"cursor"`
+	Limit  opt.Option[int]    `form:"limit,default=50" json:"limit"`
+	Tag    opt.Option[string] `form:"tag" json:"tag"`
+	Token  opt.Option[string] `header:"X-Token"`
+}
+
+func ginContext(req *http.Request) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	return c
+}
+
+func TestBindQuery(t *testing.T) {
+	c := ginContext(httptest.NewRequest(http.MethodGet, "/?cursor=abc&tag=", nil))
+	var req request
+	require.NoError(t, c.ShouldBindWith(&req, Query))
+	require.Equal(t, opt.Some("abc"), req.Cursor)
+	require.Equal(t, opt.Some(50), req.Limit)
+	require.Equal(t, opt.Some(""), req.Tag)
+	require.Equal(t, opt.None[string](), req.Token)
+
+	c = ginContext(httptest.NewRequest(http.MethodGet, "/?limit=ten", nil))
+	require.Error(t, c.ShouldBindWith(&request{}, Query))
+}
+
+func TestBindForm(t *testing.T) {
+	body := url.Values{"cursor": {"abc"}, "limit": {"10"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Token", "secret")
+	c := ginContext(r)
+	req := request{Tag: opt.Some("kept")}
+	require.NoError(t, ShouldBind(c, &req))
+	require.NoError(t, c.ShouldBindWith(&req, Header))
+	require.Equal(t, opt.Some("abc"), req.Cursor)
+	require.Equal(t, opt.Some(10), req.Limit)
+	require.Equal(t, opt.Some("kept"), req.Tag)
+	require.Equal(t, opt.Some("secret"), req.Token)
+}
+
+func TestBindURIAndJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/items/7", strings.NewReader(`{"cursor":"abc","tag":null}`))
+	r.Header.Set("Content-Type", "application/json")
+	c := ginContext(r)
+	c.Params = gin.Params{{Key: "id", Value: "7"}}
+	var req request
+	require.NoError(t, ShouldBindUri(c, &req))
+	require.NoError(t, ShouldBind(c, &req))
+	require.Equal(t, opt.Some[int64](7), req.ID)
+	require.Equal(t, opt.Some("abc"), req.Cursor)
+	require.Equal(t, opt.None[int](), req.Limit)
+	require.Equal(t, opt.None[string](), req.Tag)
+}
+
+func TestRequired(t *testing.T) {
+	type required struct {
+		Limit opt.Option[int] `form:"limit" binding:"required"`
+	}
+	c := ginContext(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Error(t, c.ShouldBindWith(&required{}, Query))
+
+	c = ginContext(httptest.NewRequest(http.MethodGet, "/?limit=0", nil))
+	var req required
+	require.NoError(t, c.ShouldBindWith(&req, Query))
+	require.Equal(t, opt.Some(0), req.Limit)
+}
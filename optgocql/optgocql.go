@@ -0,0 +1,108 @@
+// Package optgocql lets opt.Option values be bound to and scanned from
+// Cassandra queries with github.com/gocql/gocql.
+//
+// Cassandra distinguishes between binding a column to null, which writes a
+// tombstone, and leaving it unset, which leaves whatever is stored untouched
+// (unset requires protocol version 4 or later). Which one None stands for is
+// chosen with a NoneMode when binding:
+//
+//	err := session.Query(
+//		`UPDATE users SET nickname = ?, age = ? WHERE id = ?`,
+//		optgocql.Values(optgocql.Unset, user.Nickname, user.Age, user.ID)...,
+//	).Exec()
+//
+// When scanning, null is decoded as None:
+//
+//	err := session.Query(`SELECT nickname FROM users WHERE id = ?`, id).
+//		Scan(optgocql.Scan(&user.Nickname))
+package optgocql
+
+import (
+	"reflect"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+	"github.com/gocql/gocql"
+)
+
+// NoneMode selects how None is bound to a query.
+type NoneMode int
+
+const (
+	// Null binds None as null. Writing null to a column creates a tombstone.
+	Null NoneMode = iota
+	// Unset binds None as gocql.UnsetValue, so that the column is not written
+	// at all.
+	Unset
+)
+
+// Value returns o in a form that can be bound to a query, with None bound
+// according to mode.
+func Value[T any](o opt.Option[T], mode NoneMode) any {
+	v, ok := o.MaybeUnwrap()
+	return bind(v, ok, mode)
+}
+
+// Values is like Value for every Option in args. Any other argument is
+// returned as is.
+func Values(mode NoneMode, args ...any) []any {
+	out := make([]any, len(args))
+	for i, arg := range args {
+		out[i] = arg
+		if arg == nil {
+			continue
+		}
+		rv := reflect.ValueOf(arg)
+		if _, ok := optreflect.Elem(rv.Type()); ok {
+			v, ok := optreflect.Get(rv)
+			var inner any
+			if ok {
+				inner = v.Interface()
+			}
+			out[i] = bind(inner, ok, mode)
+		}
+	}
+	return out
+}
+
+func bind(v any, ok bool, mode NoneMode) any {
+	if !ok && mode == Unset {
+		return gocql.UnsetValue
+	}
+	return marshaler{v: v, ok: ok}
+}
+
+type marshaler struct {
+	v  any
+	ok bool
+}
+
+func (m marshaler) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if !m.ok {
+		return nil, nil
+	}
+	return gocql.Marshal(info, m.v)
+}
+
+// Scan returns a destination for Iter.Scan, Query.Scan and the like that
+// stores the column into o. Null is decoded as None.
+func Scan[T any](o *opt.Option[T]) gocql.Unmarshaler {
+	return unmarshaler[T]{o: o}
+}
+
+type unmarshaler[T any] struct {
+	o *opt.Option[T]
+}
+
+func (u unmarshaler[T]) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if data == nil {
+		*u.o = opt.None[T]()
+		return nil
+	}
+	var v T
+	if err := gocql.Unmarshal(info, data, &v); err != nil {
+		return err
+	}
+	*u.o = opt.Some(v)
+	return nil
+}
@@ -0,0 +1,72 @@
+package optgocql
+
+import (
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue(t *testing.T) {
+	intInfo := gocql.NewNativeType(4, gocql.TypeInt, "")
+
+	data, err := gocql.Marshal(intInfo, Value(opt.None[int](), Null))
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	require.Equal(t, gocql.UnsetValue, Value(opt.None[int](), Unset))
+
+	for _, mode := range []NoneMode{Null, Unset} {
+		want, err := gocql.Marshal(intInfo, 42)
+		require.NoError(t, err)
+		got, err := gocql.Marshal(intInfo, Value(opt.Some(42), mode))
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	_, err = gocql.Marshal(intInfo, Value(opt.Some("nope"), Null))
+	require.Error(t, err)
+}
+
+func TestValues(t *testing.T) {
+	textInfo := gocql.NewNativeType(4, gocql.TypeText, "")
+
+	args := Values(Unset, opt.Some("nk"), opt.None[int](), "id", nil)
+	require.Len(t, args, 4)
+	data, err := gocql.Marshal(textInfo, args[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("nk"), data)
+	require.Equal(t, gocql.UnsetValue, args[1])
+	require.Equal(t, "id", args[2])
+	require.Nil(t, args[3])
+
+	args = Values(Null, opt.None[string]())
+	data, err = gocql.Marshal(textInfo, args[0])
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestScan(t *testing.T) {
+	tsInfo := gocql.NewNativeType(4, gocql.TypeTimestamp, "")
+	at := time.UnixMilli(1709294400000).UTC()
+
+	data, err := gocql.Marshal(tsInfo, at)
+	require.NoError(t, err)
+
+	o := opt.None[time.Time]()
+	require.NoError(t, gocql.Unmarshal(tsInfo, data, Scan(&o)))
+	require.True(t, at.Equal(o.Unwrap()))
+
+	require.NoError(t, gocql.Unmarshal(tsInfo, nil, Scan(&o)))
+	require.True(t, o.None())
+
+	textInfo := gocql.NewNativeType(4, gocql.TypeText, "")
+	s := opt.None[string]()
+	require.NoError(t, gocql.Unmarshal(textInfo, []byte{}, Scan(&s)))
+	require.Equal(t, opt.Some(""), s, "an empty string is not null")
+
+	var i opt.Option[int]
+	require.Error(t, gocql.Unmarshal(textInfo, []byte("x"), Scan(&i)))
+}
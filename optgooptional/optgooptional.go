@@ -0,0 +1,24 @@
+// Package optgooptional converts between opt.Option and the Option type of
+// github.com/moznion/go-optional, for code that uses both.
+package optgooptional
+
+import (
+	"code.nkcmr.net/opt"
+	"github.com/moznion/go-optional"
+)
+
+// From returns an opt.Option holding the same value as o.
+func From[T any](o optional.Option[T]) opt.Option[T] {
+	if o.IsNone() {
+		return opt.None[T]()
+	}
+	return opt.Some(o.Unwrap())
+}
+
+// To returns an optional.Option holding the same value as o.
+func To[T any](o opt.Option[T]) optional.Option[T] {
+	if v, ok := o.MaybeUnwrap(); ok {
+		return optional.Some(v)
+	}
+	return optional.None[T]()
+}
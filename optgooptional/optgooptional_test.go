@@ -0,0 +1,22 @@
+package optgooptional
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/moznion/go-optional"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	require.Equal(t, opt.Some(5), From(optional.Some(5)))
+	require.Equal(t, opt.Some(0), From(optional.Some(0)))
+	require.Equal(t, opt.None[int](), From(optional.None[int]()))
+	require.Equal(t, opt.None[int](), From[int](nil))
+}
+
+func TestTo(t *testing.T) {
+	require.Equal(t, optional.Some("x"), To(opt.Some("x")))
+	require.True(t, To(opt.None[string]()).IsNone())
+	require.Equal(t, opt.Some([]int{1}), From(To(opt.Some([]int{1}))))
+}
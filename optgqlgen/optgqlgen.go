@@ -0,0 +1,169 @@
+// Package optgqlgen lets github.com/99designs/gqlgen bind nullable GraphQL
+// fields to opt.Option instead of pointers.
+//
+// gqlgen finds the Marshal<Name> and Unmarshal<Name> functions of a model
+// package, so listing this package as an extra model of a scalar in
+// gqlgen.yml lets fields of that scalar bind to the matching Option. gqlgen
+// uses the first model of a scalar that is compatible with the Go type it
+// binds to, so the plain type stays first:
+//
+//	models:
+//	  String:
+//	    model:
+//	      - github.com/99designs/gqlgen/graphql.String
+//	      - code.nkcmr.net/opt/optgqlgen.String
+//	  Int:
+//	    model:
+//	      - github.com/99designs/gqlgen/graphql.Int
+//	      - code.nkcmr.net/opt/optgqlgen.Int
+//	  User:
+//	    model:
+//	      - example.com/app/model.User
+//
+// The models gqlgen generates are always built from the first model of each
+// scalar, so their nullable fields stay pointers. Options are only used for
+// fields of Go types that are bound with "model", as User is above, and that
+// declare those fields as Options themselves:
+//
+//	type User struct {
+//		ID       string
+//		Nickname opt.Option[string]
+//		Age      opt.Option[int]
+//	}
+//
+// null is unmarshaled as None and None is marshaled as null. For input fields
+// where leaving a field out has to be told apart from setting it to null, wrap
+// the Option in a graphql.Omittable: an omitted field is left unset, while an
+// explicit null is set to None.
+//
+// For custom scalars, build the pair of functions from the scalar's own with
+// Marshal and Unmarshal.
+package optgqlgen
+
+import (
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// Marshal marshals o with marshal, or as null if it is None.
+func Marshal[T any](o opt.Option[T], marshal func(T) graphql.Marshaler) graphql.Marshaler {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return graphql.Null
+	}
+	return marshal(v)
+}
+
+// Unmarshal unmarshals v with unmarshal, or as None if it is null.
+func Unmarshal[T any](v any, unmarshal func(any) (T, error)) (opt.Option[T], error) {
+	if v == nil {
+		return opt.None[T](), nil
+	}
+	x, err := unmarshal(v)
+	if err != nil {
+		return opt.None[T](), err
+	}
+	return opt.Some(x), nil
+}
+
+// MarshalString is the Option counterpart of graphql.MarshalString.
+func MarshalString(o opt.Option[string]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalString)
+}
+
+// UnmarshalString is the Option counterpart of graphql.UnmarshalString.
+func UnmarshalString(v any) (opt.Option[string], error) {
+	return Unmarshal(v, graphql.UnmarshalString)
+}
+
+// MarshalInt is the Option counterpart of graphql.MarshalInt.
+func MarshalInt(o opt.Option[int]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalInt)
+}
+
+// UnmarshalInt is the Option counterpart of graphql.UnmarshalInt.
+func UnmarshalInt(v any) (opt.Option[int], error) {
+	return Unmarshal(v, graphql.UnmarshalInt)
+}
+
+// MarshalInt64 is the Option counterpart of graphql.MarshalInt64.
+func MarshalInt64(o opt.Option[int64]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalInt64)
+}
+
+// UnmarshalInt64 is the Option counterpart of graphql.UnmarshalInt64.
+func UnmarshalInt64(v any) (opt.Option[int64], error) {
+	return Unmarshal(v, graphql.UnmarshalInt64)
+}
+
+// MarshalInt32 is the Option counterpart of graphql.MarshalInt32.
+func MarshalInt32(o opt.Option[int32]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalInt32)
+}
+
+// UnmarshalInt32 is the Option counterpart of graphql.UnmarshalInt32.
+func UnmarshalInt32(v any) (opt.Option[int32], error) {
+	return Unmarshal(v, graphql.UnmarshalInt32)
+}
+
+// MarshalFloat is the Option counterpart of graphql.MarshalFloat.
+func MarshalFloat(o opt.Option[float64]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalFloat)
+}
+
+// UnmarshalFloat is the Option counterpart of graphql.UnmarshalFloat.
+func UnmarshalFloat(v any) (opt.Option[float64], error) {
+	return Unmarshal(v, graphql.UnmarshalFloat)
+}
+
+// MarshalBoolean is the Option counterpart of graphql.MarshalBoolean.
+func MarshalBoolean(o opt.Option[bool]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalBoolean)
+}
+
+// UnmarshalBoolean is the Option counterpart of graphql.UnmarshalBoolean.
+func UnmarshalBoolean(v any) (opt.Option[bool], error) {
+	return Unmarshal(v, graphql.UnmarshalBoolean)
+}
+
+// MarshalID is the Option counterpart of graphql.MarshalID.
+func MarshalID(o opt.Option[string]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalID)
+}
+
+// UnmarshalID is the Option counterpart of graphql.UnmarshalID.
+func UnmarshalID(v any) (opt.Option[string], error) {
+	return Unmarshal(v, graphql.UnmarshalID)
+}
+
+// MarshalIntID is the Option counterpart of graphql.MarshalIntID.
+func MarshalIntID(o opt.Option[int]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalIntID)
+}
+
+// UnmarshalIntID is the Option counterpart of graphql.UnmarshalIntID.
+func UnmarshalIntID(v any) (opt.Option[int], error) {
+	return Unmarshal(v, graphql.UnmarshalIntID)
+}
+
+// MarshalUintID is the Option counterpart of graphql.MarshalUintID.
+func MarshalUintID(o opt.Option[uint]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalUintID)
+}
+
+// UnmarshalUintID is the Option counterpart of graphql.UnmarshalUintID.
+func UnmarshalUintID(v any) (opt.Option[uint], error) {
+	return Unmarshal(v, graphql.UnmarshalUintID)
+}
+
+// MarshalTime is the Option counterpart of graphql.MarshalTime.
+func MarshalTime(o opt.Option[time.Time]) graphql.Marshaler {
+	return Marshal(o, graphql.MarshalTime)
+}
+
+// UnmarshalTime is the Option counterpart of graphql.UnmarshalTime.
+func UnmarshalTime(v any) (opt.Option[time.Time], error) {
+	return Unmarshal(v, graphql.UnmarshalTime)
+}
@@ -0,0 +1,67 @@
+package optgqlgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/require"
+)
+
+func marshaled(m graphql.Marshaler) string {
+	var buf bytes.Buffer
+	m.MarshalGQL(&buf)
+	return buf.String()
+}
+
+func TestMarshal(t *testing.T) {
+	require.Equal(t, "null", marshaled(MarshalString(opt.None[string]())))
+	require.Equal(t, `"nk"`, marshaled(MarshalString(opt.Some("nk"))))
+	require.Equal(t, "null", marshaled(MarshalInt(opt.None[int]())))
+	require.Equal(t, "0", marshaled(MarshalInt(opt.Some(0))))
+	require.Equal(t, "false", marshaled(MarshalBoolean(opt.Some(false))))
+	require.Equal(t, "1.5", marshaled(MarshalFloat(opt.Some(1.5))))
+	require.Equal(t, `"id"`, marshaled(MarshalID(opt.Some("id"))))
+	require.Equal(t, `"2024-03-01T00:00:00Z"`, marshaled(MarshalTime(opt.Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))))
+}
+
+func TestUnmarshal(t *testing.T) {
+	s, err := UnmarshalString(nil)
+	require.NoError(t, err)
+	require.True(t, s.None())
+	s, err = UnmarshalString("nk")
+	require.NoError(t, err)
+	require.Equal(t, opt.Some("nk"), s)
+
+	i, err := UnmarshalInt64(json.Number("42"))
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(int64(42)), i)
+	_, err = UnmarshalInt64("nope")
+	require.Error(t, err)
+
+	id, err := UnmarshalUintID("7")
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(uint(7)), id)
+
+	at, err := UnmarshalTime("2024-03-01T00:00:00Z")
+	require.NoError(t, err)
+	require.True(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Equal(at.Unwrap()))
+}
+
+func TestOmittable(t *testing.T) {
+	type input struct {
+		Name graphql.Omittable[opt.Option[string]] `json:"name"`
+	}
+	for doc, want := range map[string]graphql.Omittable[opt.Option[string]]{
+		`{}`:             {},
+		`{"name": null}`: graphql.OmittableOf(opt.None[string]()),
+		`{"name": "nk"}`: graphql.OmittableOf(opt.Some("nk")),
+	} {
+		var in input
+		require.NoError(t, json.Unmarshal([]byte(doc), &in), doc)
+		require.Equal(t, want, in.Name, doc)
+	}
+}
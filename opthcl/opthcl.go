@@ -0,0 +1,84 @@
+// Package opthcl decodes HCL configuration into structs with opt.Option
+// fields, using github.com/hashicorp/hcl/v2/gohcl, and converts values with
+// Options to and from cty values with github.com/zclconf/go-cty/cty/gocty.
+//
+// gohcl and gocty cannot see inside an Option, so the functions here decode
+// into a mirror type in which every Option[T] is a *T, which they
+// understand, and map the result back:
+//
+//	type Config struct {
+//		Region  string                         `hcl:"region"`
+//		Timeout opt.Option[int]                `hcl:"timeout,optional"`
+//		Proxy   opt.Option[opt.Option[string]] `hcl:"proxy,optional"`
+//		Backend opt.Option[Backend]            `hcl:"backend,block"`
+//	}
+//
+//	diags := opthcl.DecodeBody(file.Body, nil, &cfg)
+//
+// An attribute that is absent leaves its Option as it was, so None unless it
+// was set before decoding, and one that is null makes it None. To tell the
+// two apart, use an Option of an Option: absent leaves it None, and null
+// makes it Some(None). An Option of a struct can hold an optional block,
+// which is None if the block is absent.
+package opthcl
+
+import (
+	"reflect"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"code.nkcmr.net/opt/internal/shadow"
+)
+
+var mapper = &shadow.Mapper{}
+
+// DecodeBody is like gohcl.DecodeBody but also accepts Options. As with
+// gohcl.DecodeBody, val may be partially populated when there are error
+// diagnostics.
+func DecodeBody(body hcl.Body, ctx *hcl.EvalContext, val any) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	_ = mapper.Decode(val, func(out any) error {
+		diags = gohcl.DecodeBody(body, ctx, out)
+		return nil
+	})
+	return diags
+}
+
+// DecodeExpression is like gohcl.DecodeExpression but also accepts Options,
+// so that val can point to an Option, or to a value containing them.
+func DecodeExpression(expr hcl.Expression, ctx *hcl.EvalContext, val any) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	_ = mapper.Decode(val, func(out any) error {
+		diags = gohcl.DecodeExpression(expr, ctx, out)
+		return nil
+	})
+	return diags
+}
+
+// FromCtyValue is like gocty.FromCtyValue but also accepts Options.
+func FromCtyValue(val cty.Value, target any) error {
+	return mapper.Decode(target, func(out any) error {
+		return gocty.FromCtyValue(val, out)
+	})
+}
+
+// ToCtyValue is like gocty.ToCtyValue but also accepts Options, converting
+// None to null.
+func ToCtyValue(val any, ty cty.Type) (cty.Value, error) {
+	if val != nil {
+		val = mapper.To(reflect.ValueOf(val)).Interface()
+	}
+	return gocty.ToCtyValue(val, ty)
+}
+
+// ImpliedType is like gocty.ImpliedType but also accepts Options, whose type
+// is the type of the value they hold.
+func ImpliedType(gv any) (cty.Type, error) {
+	if gv != nil {
+		gv = reflect.Zero(mapper.TypeOf(reflect.TypeOf(gv))).Interface()
+	}
+	return gocty.ImpliedType(gv)
+}
@@ -0,0 +1,128 @@
+package opthcl
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"code.nkcmr.net/opt"
+)
+
+type backend struct {
+	Kind   string             `hcl:"kind,label"`
+	Bucket opt.Option[string] `hcl:"bucket,optional"`
+}
+
+type config struct {
+	Region  string                         `hcl:"region"`
+	Timeout opt.Option[int]                `hcl:"timeout,optional"`
+	Retries opt.Option[int]                `hcl:"retries,optional"`
+	Tags    opt.Option[map[string]string]  `hcl:"tags,optional"`
+	Proxy   opt.Option[opt.Option[string]] `hcl:"proxy,optional"`
+	Mirror  opt.Option[opt.Option[string]] `hcl:"mirror,optional"`
+	Backend opt.Option[backend]            `hcl:"backend,block"`
+}
+
+func parse(t *testing.T, src string) hcl.Body {
+	t.Helper()
+	file, diags := hclsyntax.ParseConfig([]byte(src), "test.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+	return file.Body
+}
+
+func TestDecodeBody(t *testing.T) {
+	ctx := &hcl.EvalContext{Variables: map[string]cty.Value{"default_timeout": cty.NumberIntVal(30)}}
+	var cfg config
+	diags := DecodeBody(parse(t, `
+region  = "us-east-1"
+timeout = default_timeout
+retries = null
+proxy   = null
+
+backend "s3" {
+  bucket = "configs"
+}
+`), ctx, &cfg)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Equal(t, config{
+		Region:  "us-east-1",
+		Timeout: opt.Some(30),
+		Retries: opt.None[int](),
+		Tags:    opt.None[map[string]string](),
+		Proxy:   opt.Some(opt.None[string]()),
+		Mirror:  opt.None[opt.Option[string]](),
+		Backend: opt.Some(backend{Kind: "s3", Bucket: opt.Some("configs")}),
+	}, cfg)
+}
+
+func TestDecodeBodyKeepsValues(t *testing.T) {
+	cfg := config{Timeout: opt.Some(10), Retries: opt.Some(3)}
+	diags := DecodeBody(parse(t, `
+region  = "eu-west-1"
+retries = null
+tags    = { team = "core" }
+mirror  = "https://mirror.example"
+`), nil, &cfg)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Equal(t, opt.Some(10), cfg.Timeout)
+	require.Equal(t, opt.None[int](), cfg.Retries)
+	require.Equal(t, opt.Some(map[string]string{"team": "core"}), cfg.Tags)
+	require.Equal(t, opt.Some(opt.Some("https://mirror.example")), cfg.Mirror)
+	require.True(t, cfg.Backend.None())
+}
+
+func TestDecodeBodyErrors(t *testing.T) {
+	var cfg config
+	diags := DecodeBody(parse(t, `
+region  = "us-east-1"
+timeout = "soon"
+`), nil, &cfg)
+	require.True(t, diags.HasErrors())
+	require.Contains(t, diags.Error(), "Unsuitable value")
+	require.Equal(t, "us-east-1", cfg.Region)
+}
+
+func TestDecodeExpression(t *testing.T) {
+	expr, diags := hclsyntax.ParseExpression([]byte(`[1, null, 3]`), "test.hcl", hcl.InitialPos)
+	require.False(t, diags.HasErrors())
+	var got []opt.Option[int]
+	diags = DecodeExpression(expr, nil, &got)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Equal(t, []opt.Option[int]{opt.Some(1), opt.None[int](), opt.Some(3)}, got)
+
+	expr, _ = hclsyntax.ParseExpression([]byte(`"x"`), "test.hcl", hcl.InitialPos)
+	var o opt.Option[string]
+	diags = DecodeExpression(expr, nil, &o)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.Equal(t, opt.Some("x"), o)
+}
+
+func TestCty(t *testing.T) {
+	type server struct {
+		Host string          `cty:"host"`
+		Port opt.Option[int] `cty:"port"`
+	}
+	ty, err := ImpliedType(server{})
+	require.NoError(t, err)
+	require.Equal(t, cty.Object(map[string]cty.Type{"host": cty.String, "port": cty.Number}), ty)
+
+	val, err := ToCtyValue(server{Host: "a", Port: opt.Some(80)}, ty)
+	require.NoError(t, err)
+	require.True(t, val.GetAttr("port").RawEquals(cty.NumberIntVal(80)))
+	val, err = ToCtyValue(server{Host: "a"}, ty)
+	require.NoError(t, err)
+	require.True(t, val.GetAttr("port").IsNull())
+
+	var s server
+	require.NoError(t, FromCtyValue(cty.ObjectVal(map[string]cty.Value{
+		"host": cty.StringVal("b"),
+		"port": cty.NumberIntVal(443),
+	}), &s))
+	require.Equal(t, server{Host: "b", Port: opt.Some(443)}, s)
+	require.NoError(t, FromCtyValue(val, &s))
+	require.Equal(t, server{Host: "a"}, s)
+	require.Error(t, FromCtyValue(cty.StringVal("x"), &s))
+}
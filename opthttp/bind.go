@@ -0,0 +1,148 @@
+package opthttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"code.nkcmr.net/opt/internal/textparse"
+)
+
+// sources are the struct tags Bind reads, in the order it looks for them.
+var sources = []struct {
+	tag, name string
+	lookup    func(b *binder, key string) ([]string, bool)
+}{
+	{"path", "path value", func(b *binder, key string) ([]string, bool) {
+		if v := b.r.PathValue(key); v != "" {
+			return []string{v}, true
+		}
+		return nil, false
+	}},
+	{"query", "query parameter", func(b *binder, key string) ([]string, bool) {
+		v, ok := b.query[key]
+		return v, ok
+	}},
+	{"header", "header", func(b *binder, key string) ([]string, bool) {
+		v := b.r.Header.Values(key)
+		return v, len(v) > 0
+	}},
+}
+
+type binder struct {
+	r     *http.Request
+	query url.Values
+}
+
+// Bind sets the fields of the struct dst points to from r.
+//
+// A field tagged `path:"name"` is read from r.PathValue, one tagged
+// `query:"name"` from the query string and one tagged `header:"Name"` from the
+// headers. An Option field is None if the value is not in the request, and
+// Some if it is, even if empty. Any other field is left as it is if the value
+// is not in the request, so it can be given a default beforehand. A path
+// value is only in the request if it is not empty, since r.PathValue does not
+// tell the difference.
+//
+// Values are parsed with their UnmarshalText method if they have one,
+// time.ParseDuration for durations, and according to their kind otherwise:
+// strings, booleans, integers and floating-point numbers. Fields that are
+// slices, or Options of slices, receive every value of a repeated query
+// parameter or header; other fields receive the first.
+//
+// Fields of struct types without an UnmarshalText method, including embedded
+// ones, are bound recursively.
+func Bind(r *http.Request, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("opthttp: cannot bind into %T, it is not a pointer to a struct", dst)
+	}
+	b := &binder{r: r, query: r.URL.Query()}
+	return b.bindStruct(rv.Elem())
+}
+
+func (b *binder) bindStruct(rv reflect.Value) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := rv.Field(i)
+		if !sf.IsExported() {
+			if sf.Anonymous && isNested(sf.Type) {
+				if err := b.bindStruct(fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		bound := false
+		for _, src := range sources {
+			key, ok := sf.Tag.Lookup(src.tag)
+			if !ok || key == "-" {
+				continue
+			}
+			bound = true
+			values, ok := src.lookup(b, key)
+			if err := bindField(fv, values, ok); err != nil {
+				return fmt.Errorf("opthttp: %s %q: %w", src.name, key, err)
+			}
+			break
+		}
+		if !bound && isNested(sf.Type) {
+			if err := b.bindStruct(fv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bindField stores values into fv, which may be an Option, if found.
+func bindField(fv reflect.Value, values []string, found bool) error {
+	elem, isOption := optreflect.Elem(fv.Type())
+	if !isOption {
+		if !found {
+			return nil
+		}
+		return setValues(fv, values)
+	}
+	if !found {
+		optreflect.Set(fv.Addr(), reflect.Value{})
+		return nil
+	}
+	x := reflect.New(elem).Elem()
+	if err := setValues(x, values); err != nil {
+		return err
+	}
+	optreflect.Set(fv.Addr(), x)
+	return nil
+}
+
+func setValues(rv reflect.Value, values []string) error {
+	if rv.Kind() == reflect.Slice && !isText(rv.Type()) {
+		s := reflect.MakeSlice(rv.Type(), len(values), len(values))
+		for i, v := range values {
+			if err := textparse.Set(s.Index(i), v); err != nil {
+				return err
+			}
+		}
+		rv.Set(s)
+		return nil
+	}
+	return textparse.Set(rv, values[0])
+}
+
+var textUnmarshalerType = reflect.TypeFor[interface{ UnmarshalText([]byte) error }]()
+
+func isText(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// isNested reports whether t is a struct that is bound field by field.
+func isNested(t reflect.Type) bool {
+	if _, ok := optreflect.Elem(t); ok {
+		return false
+	}
+	return t.Kind() == reflect.Struct && !isText(t)
+}
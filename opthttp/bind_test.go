@@ -0,0 +1,90 @@
+package opthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type Paging struct {
+	Limit  opt.Option[int]    `query:"limit"`
+	Cursor opt.Option[string] `query:"cursor"`
+}
+
+type listParams struct {
+	Paging
+	Org      string                    `path:"org"`
+	Repo     opt.Option[string]        `path:"repo"`
+	Sort     string                    `query:"sort"`
+	Tags     []string                  `query:"tag"`
+	IDs      opt.Option[[]int]         `query:"id"`
+	Since    opt.Option[time.Time]     `query:"since"`
+	Wait     opt.Option[time.Duration] `query:"wait"`
+	Tenant   opt.Option[string]        `header:"X-Tenant"`
+	Client   opt.Option[netip.Addr]    `header:"X-Client"`
+	Ignored  string                    `query:"-"`
+	Untagged string
+}
+
+func bind(t *testing.T, pattern, target string, header http.Header) (listParams, error) {
+	t.Helper()
+	params := listParams{Sort: "name", Ignored: "kept"}
+	var err error
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, func(_ http.ResponseWriter, r *http.Request) {
+		err = Bind(r, &params)
+	})
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+	return params, err
+}
+
+func TestBind(t *testing.T) {
+	params, err := bind(t, "GET /orgs/{org}/repos", "/orgs/nk/repos?limit=10&cursor=&tag=a&tag=b&id=1&id=2&since=2024-03-01T00:00:00Z&wait=5s&Ignored=x", http.Header{
+		"X-Tenant": {""},
+		"X-Client": {"::1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, listParams{
+		Paging:  Paging{Limit: opt.Some(10), Cursor: opt.Some("")},
+		Org:     "nk",
+		Sort:    "name",
+		Tags:    []string{"a", "b"},
+		IDs:     opt.Some([]int{1, 2}),
+		Since:   opt.Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		Wait:    opt.Some(5 * time.Second),
+		Tenant:  opt.Some(""),
+		Client:  opt.Some(netip.IPv6Loopback()),
+		Ignored: "kept",
+	}, params)
+}
+
+func TestBindMissing(t *testing.T) {
+	params, err := bind(t, "GET /orgs/{org}/repos/{repo}", "/orgs/nk/repos/opt?sort=stars", nil)
+	require.NoError(t, err)
+	require.Equal(t, listParams{
+		Org:     "nk",
+		Repo:    opt.Some("opt"),
+		Sort:    "stars",
+		Ignored: "kept",
+	}, params)
+}
+
+func TestBindErrors(t *testing.T) {
+	_, err := bind(t, "GET /", "/?limit=ten", nil)
+	require.ErrorContains(t, err, `opthttp: query parameter "limit": `)
+
+	_, err = bind(t, "GET /", "/?id=1&id=x", nil)
+	require.ErrorContains(t, err, `opthttp: query parameter "id": `)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.Error(t, Bind(r, listParams{}))
+}
@@ -0,0 +1,26 @@
+package opthttp
+
+import (
+	"net/http"
+
+	"code.nkcmr.net/opt"
+)
+
+// Header returns the first value of the header key in h. It is Some if the
+// header is present, even if empty. The key is canonicalized the same way
+// h.Get canonicalizes it.
+func Header(h http.Header, key string) opt.Option[string] {
+	if v := h.Values(key); len(v) > 0 {
+		return opt.Some(v[0])
+	}
+	return opt.None[string]()
+}
+
+// Cookie returns the cookie called name in r, or None if r does not have one.
+func Cookie(r *http.Request, name string) opt.Option[*http.Cookie] {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return opt.None[*http.Cookie]()
+	}
+	return opt.Some(c)
+}
@@ -0,0 +1,32 @@
+package opthttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeader(t *testing.T) {
+	h := http.Header{}
+	h.Add("X-Tenant", "acme")
+	h.Add("X-Tenant", "other")
+	h["X-Empty"] = []string{""}
+
+	require.Equal(t, opt.Some("acme"), Header(h, "x-tenant"))
+	require.Equal(t, opt.Some(""), Header(h, "X-Empty"))
+	require.Equal(t, opt.None[string](), Header(h, "X-Missing"))
+	require.Equal(t, opt.None[string](), Header(nil, "X-Tenant"))
+}
+
+func TestCookie(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	c := Cookie(r, "session")
+	require.True(t, c.Some())
+	require.Equal(t, "abc", c.Unwrap().Value)
+	require.Equal(t, opt.None[*http.Cookie](), Cookie(r, "missing"))
+}
@@ -0,0 +1,24 @@
+// Package opthttp reads the parts of net/http requests that may be missing
+// into opt.Option values.
+//
+// Bind fills a whole struct at once from the path values, query parameters
+// and headers named by the tags of its fields:
+//
+//	type ListParams struct {
+//		Org    string             `path:"org"`
+//		Limit  opt.Option[int]    `query:"limit"`
+//		Cursor opt.Option[string] `query:"cursor"`
+//		Tenant opt.Option[string] `header:"X-Tenant"`
+//	}
+//
+//	var params ListParams
+//	if err := opthttp.Bind(r, &params); err != nil {
+//		http.Error(w, err.Error(), http.StatusBadRequest)
+//		return
+//	}
+//
+// Query and its typed shortcuts read a single query parameter where binding a
+// whole struct would be too much:
+//
+//	limit := opthttp.QueryInt(r, "limit").UnwrapOr(50)
+package opthttp
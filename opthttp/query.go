@@ -0,0 +1,49 @@
+package opthttp
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.nkcmr.net/opt"
+)
+
+// Query returns the first value of the query parameter key in r, parsed with
+// parse. It is None if the parameter is absent or cannot be parsed, so a
+// handler that needs to reject bad input should use Bind instead.
+func Query[T any](r *http.Request, key string, parse func(string) (T, error)) opt.Option[T] {
+	values, ok := r.URL.Query()[key]
+	if !ok || len(values) == 0 {
+		return opt.None[T]()
+	}
+	v, err := parse(values[0])
+	if err != nil {
+		return opt.None[T]()
+	}
+	return opt.Some(v)
+}
+
+// QueryString returns the query parameter key in r. It is Some if the
+// parameter is present, even if empty.
+func QueryString(r *http.Request, key string) opt.Option[string] {
+	return Query(r, key, func(s string) (string, error) { return s, nil })
+}
+
+// QueryInt returns the query parameter key in r as a base 10 integer.
+func QueryInt(r *http.Request, key string) opt.Option[int] {
+	return Query(r, key, strconv.Atoi)
+}
+
+// QueryBool returns the query parameter key in r as a boolean, accepting the
+// values strconv.ParseBool does.
+func QueryBool(r *http.Request, key string) opt.Option[bool] {
+	return Query(r, key, strconv.ParseBool)
+}
+
+// QueryTime returns the query parameter key in r as a time in the given
+// layout, such as time.RFC3339 or time.DateOnly.
+func QueryTime(r *http.Request, key, layout string) opt.Option[time.Time] {
+	return Query(r, key, func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	})
+}
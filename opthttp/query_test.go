@@ -0,0 +1,34 @@
+package opthttp
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?limit=10&limit=20&page=two&q=&debug=true&since=2024-03-01&hex=0x1f", nil)
+
+	require.Equal(t, opt.Some(10), QueryInt(r, "limit"))
+	require.Equal(t, opt.None[int](), QueryInt(r, "page"))
+	require.Equal(t, opt.None[int](), QueryInt(r, "missing"))
+	require.Equal(t, opt.None[int](), QueryInt(r, "q"))
+
+	require.Equal(t, opt.Some(""), QueryString(r, "q"))
+	require.Equal(t, opt.Some("two"), QueryString(r, "page"))
+	require.Equal(t, opt.None[string](), QueryString(r, "missing"))
+
+	require.Equal(t, opt.Some(true), QueryBool(r, "debug"))
+	require.Equal(t, opt.None[bool](), QueryBool(r, "page"))
+
+	require.Equal(t, opt.Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), QueryTime(r, "since", time.DateOnly))
+	require.Equal(t, opt.None[time.Time](), QueryTime(r, "since", time.RFC3339))
+
+	require.Equal(t, opt.Some(int64(31)), Query(r, "hex", func(s string) (int64, error) {
+		return strconv.ParseInt(s, 0, 64)
+	}))
+}
@@ -0,0 +1,167 @@
+// Package optjson holds the helpers that the JSON methods optgen generates
+// with -json call. They let the generated code encode and decode structs
+// field by field, without the reflection encoding/json relies on.
+package optjson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrSyntax is returned, wrapped, when Members finds that its input is not a
+// JSON object.
+var ErrSyntax = errors.New("optjson: invalid JSON object")
+
+// Members calls fn with the key and the raw value of each member of the JSON
+// object in data, in order, stopping at the first error fn returns, which is
+// returned prefixed with the key. Members
+// only checks as much of the syntax of values as it needs to find where they
+// end; fn is expected to decode them. If data is null, fn is not called.
+func Members(data []byte, fn func(key string, value []byte) error) error {
+	i := skipSpace(data, 0)
+	if hasLiteral(data[i:], "null") && skipSpace(data, i+4) == len(data) {
+		return nil
+	}
+	if i == len(data) || data[i] != '{' {
+		return syntaxError(data, i)
+	}
+	i = skipSpace(data, i+1)
+	if i < len(data) && data[i] == '}' {
+		return trailing(data, i+1)
+	}
+	for {
+		if i == len(data) || data[i] != '"' {
+			return syntaxError(data, i)
+		}
+		end, escaped := scanString(data, i)
+		if end < 0 {
+			return syntaxError(data, len(data))
+		}
+		key := string(data[i+1 : end-1])
+		if escaped {
+			if err := json.Unmarshal(data[i:end], &key); err != nil {
+				return fmt.Errorf("%w: %v", ErrSyntax, err)
+			}
+		}
+		i = skipSpace(data, end)
+		if i == len(data) || data[i] != ':' {
+			return syntaxError(data, i)
+		}
+		i = skipSpace(data, i+1)
+		end = scanValue(data, i)
+		if end < 0 {
+			return syntaxError(data, i)
+		}
+		if err := fn(key, data[i:end]); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		i = skipSpace(data, end)
+		if i == len(data) {
+			return syntaxError(data, i)
+		}
+		switch data[i] {
+		case ',':
+			i = skipSpace(data, i+1)
+		case '}':
+			return trailing(data, i+1)
+		default:
+			return syntaxError(data, i)
+		}
+	}
+}
+
+func trailing(data []byte, i int) error {
+	if i = skipSpace(data, i); i != len(data) {
+		return syntaxError(data, i)
+	}
+	return nil
+}
+
+func syntaxError(data []byte, i int) error {
+	if i >= len(data) {
+		return fmt.Errorf("%w: unexpected end of input", ErrSyntax)
+	}
+	return fmt.Errorf("%w: unexpected %q at offset %d", ErrSyntax, data[i], i)
+}
+
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+func hasLiteral(data []byte, lit string) bool {
+	return len(data) >= len(lit) && string(data[:len(lit)]) == lit
+}
+
+// scanString returns the offset just past the string starting at data[i],
+// and whether it contains escapes, or -1 if it is not terminated.
+func scanString(data []byte, i int) (int, bool) {
+	escaped := false
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			escaped = true
+			i++
+		case '"':
+			return i + 1, escaped
+		}
+	}
+	return -1, escaped
+}
+
+// scanValue returns the offset just past the value starting at data[i], or
+// -1 if there is no value there.
+func scanValue(data []byte, i int) int {
+	if i == len(data) {
+		return -1
+	}
+	switch c := data[i]; {
+	case c == '"':
+		end, _ := scanString(data, i)
+		return end
+	case c == '{' || c == '[':
+		depth := 0
+		for i < len(data) {
+			switch data[i] {
+			case '"':
+				end, _ := scanString(data, i)
+				if end < 0 {
+					return -1
+				}
+				i = end
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+			i++
+		}
+		return -1
+	case c == '-' || c >= '0' && c <= '9' || c == 't' || c == 'f' || c == 'n':
+		start := i
+		for i < len(data) {
+			switch data[i] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return i
+			}
+			i++
+		}
+		if i == start {
+			return -1
+		}
+		return i
+	}
+	return -1
+}
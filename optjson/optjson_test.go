@@ -0,0 +1,76 @@
+package optjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type member struct {
+	key, value string
+}
+
+func members(t *testing.T, data string) ([]member, error) {
+	t.Helper()
+	var out []member
+	err := Members([]byte(data), func(key string, value []byte) error {
+		out = append(out, member{key, string(value)})
+		return nil
+	})
+	return out, err
+}
+
+func TestMembers(t *testing.T) {
+	got, err := members(t, ` { "a" : 1 , "b":"x\"}y", "cA":{"d":[1,{"e":"]"}]},"f":[],"g":true,"h":null,"i":-1.5e3, "\u006a":0 } `)
+	require.NoError(t, err)
+	require.Equal(t, []member{
+		{"a", "1"},
+		{"b", `"x\"}y"`},
+		{"cA", `{"d":[1,{"e":"]"}]}`},
+		{"f", "[]"},
+		{"g", "true"},
+		{"h", "null"},
+		{"i", "-1.5e3"},
+		{"j", "0"},
+	}, got)
+
+	for _, data := range []string{"{}", " { } ", "null", " null "} {
+		got, err := members(t, data)
+		require.NoError(t, err, data)
+		require.Empty(t, got, data)
+	}
+}
+
+func TestMembersError(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"[]",
+		`"a"`,
+		"nul",
+		"{",
+		`{"a"}`,
+		`{"a":}`,
+		`{"a":1`,
+		`{"a":1,}`,
+		`{"a":1 "b":2}`,
+		`{"a":"unterminated}`,
+		`{"a":{"b":1}`,
+		`{"a":1}x`,
+		`{1:2}`,
+		`{"\x":1}`,
+	} {
+		_, err := members(t, data)
+		require.ErrorIs(t, err, ErrSyntax, data)
+	}
+
+	boom := errors.New("boom")
+	n := 0
+	err := Members([]byte(`{"a":1,"b":2}`), func(key string, value []byte) error {
+		n++
+		return boom
+	})
+	require.ErrorIs(t, err, boom)
+	require.EqualError(t, err, "a: boom")
+	require.Equal(t, 1, n)
+}
@@ -0,0 +1,127 @@
+// Package optjsonlite encodes and decodes Options of strings, booleans and
+// numbers as JSON without reflection or encoding/json, for targets such as
+// TinyGo and WebAssembly where those are costly. A program that encodes its
+// Options only through this package, and never passes them to encoding/json,
+// gives the linker nothing that reaches the reflection-based encoder, so it
+// can leave it out:
+//
+//	buf = append(buf, `{"name":`...)
+//	buf, err = optjsonlite.Append(buf, user.Name)
+//	...
+//	err = optjsonlite.Unmarshal(data, &user.Name)
+//
+// The encoding is the same as Option.MarshalJSON and json.Marshal produce,
+// and anything json.Unmarshal would decode into the value type is decoded the
+// same way.
+package optjsonlite
+
+import (
+	"errors"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/jsonlite"
+)
+
+// Value is the set of types optjsonlite encodes and decodes. Types defined on
+// them are not included, since telling them apart takes reflection.
+type Value interface {
+	string | bool |
+		int | int8 | int16 | int32 | int64 |
+		uint | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64
+}
+
+// ErrUnsupportedValue is returned when encoding a NaN or infinite number,
+// which JSON cannot represent.
+var ErrUnsupportedValue = errors.New("optjsonlite: cannot encode NaN or an infinite number")
+
+// Marshal returns the JSON encoding of o, which is null if o is None.
+func Marshal[T Value](o opt.Option[T]) ([]byte, error) {
+	return Append(nil, o)
+}
+
+// Append appends the JSON encoding of o to dst and returns the extended
+// buffer. It does not allocate unless dst has to grow.
+func Append[T Value](dst []byte, o opt.Option[T]) ([]byte, error) {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return append(dst, "null"...), nil
+	}
+	out, ok := jsonlite.Append(dst, v)
+	if !ok {
+		return dst, ErrUnsupportedValue
+	}
+	return out, nil
+}
+
+// Unmarshal decodes the JSON value data holds into o, setting it to None if
+// data is null. Leading and trailing white space is ignored.
+func Unmarshal[T Value](data []byte, o *opt.Option[T]) error {
+	data = trimSpace(data)
+	if string(data) == "null" {
+		*o = opt.None[T]()
+		return nil
+	}
+	var v T
+	if !jsonlite.Unmarshal(data, &v, true) {
+		return errors.New("optjsonlite: cannot decode " + quote(data) + " as " + typeName(v))
+	}
+	*o = opt.Some(v)
+	return nil
+}
+
+func trimSpace(data []byte) []byte {
+	isSpace := func(b byte) bool { return b == ' ' || b == '\t' || b == '\n' || b == '\r' }
+	for len(data) > 0 && isSpace(data[0]) {
+		data = data[1:]
+	}
+	for len(data) > 0 && isSpace(data[len(data)-1]) {
+		data = data[:len(data)-1]
+	}
+	return data
+}
+
+// quote returns data as a JSON string for an error message, shortened if it
+// is long.
+func quote(data []byte) string {
+	const limit = 32
+	s := string(data)
+	if len(s) > limit {
+		s = s[:limit] + "..."
+	}
+	return string(jsonlite.AppendString(nil, s))
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int:
+		return "int"
+	case int8:
+		return "int8"
+	case int16:
+		return "int16"
+	case int32:
+		return "int32"
+	case int64:
+		return "int64"
+	case uint:
+		return "uint"
+	case uint8:
+		return "uint8"
+	case uint16:
+		return "uint16"
+	case uint32:
+		return "uint32"
+	case uint64:
+		return "uint64"
+	case float32:
+		return "float32"
+	case float64:
+		return "float64"
+	}
+	return "unknown"
+}
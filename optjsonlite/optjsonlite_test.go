@@ -0,0 +1,119 @@
+package optjsonlite
+
+import (
+	"encoding/json"
+	"go/build"
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+// requireMarshal checks that Marshal encodes o the same way json.Marshal
+// does.
+func requireMarshal[T Value](t *testing.T, o opt.Option[T]) {
+	t.Helper()
+	want, wantErr := json.Marshal(o)
+	got, err := Marshal(o)
+	if wantErr != nil {
+		require.ErrorIs(t, err, ErrUnsupportedValue)
+		return
+	}
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got))
+}
+
+func TestMarshal(t *testing.T) {
+	requireMarshal(t, opt.None[string]())
+	for _, s := range []string{"", "plain", "quote\" <html> &", "\x00\n ", "bad \xff"} {
+		requireMarshal(t, opt.Some(s))
+	}
+	requireMarshal(t, opt.Some(true))
+	requireMarshal(t, opt.Some(math.MinInt64))
+	requireMarshal(t, opt.Some(uint64(math.MaxUint64)))
+	requireMarshal(t, opt.Some(int8(-8)))
+	for _, f := range []float64{0, -1.5, 1e-7, 1e21, math.NaN(), math.Inf(-1)} {
+		requireMarshal(t, opt.Some(f))
+		requireMarshal(t, opt.Some(float32(f)))
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = Append(buf[:0], opt.Some("hello <world>"))
+	})
+	require.Zero(t, allocs)
+}
+
+// requireUnmarshal checks that Unmarshal decodes data the same way
+// json.Unmarshal decodes it into an Option[T].
+func requireUnmarshal[T Value](t *testing.T, data string) {
+	t.Helper()
+	var want opt.Option[T]
+	wantErr := json.Unmarshal([]byte(data), &want)
+	got := opt.Some(*new(T))
+	err := Unmarshal([]byte(data), &got)
+	if wantErr != nil {
+		require.Error(t, err, data)
+		return
+	}
+	require.NoError(t, err, data)
+	require.Equal(t, want, got, data)
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, data := range []string{
+		`null`, ` null `, `""`, `"plain"`, "\t\"spaced\"\n", `"esc\"aped\\\/"`, `"\b\f\n\r\t"`,
+		`"é日"`, `"😀"`, `"\ud83d"`, `"\ud83dx"`, `"\ude00😀"`,
+		`"\ud83dA"`, "\"bad \xff\"", `"\u12"`, `"\x"`, `"\"`, "\"tab\t\"",
+		`"unterminated`, `1`, `true`, `{}`,
+	} {
+		requireUnmarshal[string](t, data)
+	}
+	for _, data := range []string{`true`, `false`, `"true"`, `1`, `tru`} {
+		requireUnmarshal[bool](t, data)
+	}
+	for _, data := range []string{`0`, `-1`, `128`, `256`, `1e3`, `1.0`, `01`, `"1"`, ``} {
+		requireUnmarshal[int](t, data)
+		requireUnmarshal[int8](t, data)
+		requireUnmarshal[uint8](t, data)
+		requireUnmarshal[uint64](t, data)
+	}
+	for _, data := range []string{`1.5`, `-1e-7`, `1e400`, `.5`, `NaN`, `3.5e38`} {
+		requireUnmarshal[float32](t, data)
+		requireUnmarshal[float64](t, data)
+	}
+
+	require.NoError(t, quick.Check(func(s string, f float64, i int64) bool {
+		for _, v := range []any{s, f, i} {
+			data, err := json.Marshal(v)
+			require.NoError(t, err)
+			switch v.(type) {
+			case string:
+				requireUnmarshal[string](t, string(data))
+			case float64:
+				requireUnmarshal[float64](t, string(data))
+			case int64:
+				requireUnmarshal[int64](t, string(data))
+			}
+		}
+		return true
+	}, nil))
+
+	var o opt.Option[int]
+	require.EqualError(t, Unmarshal([]byte(`"x"`), &o), `optjsonlite: cannot decode "\"x\"" as int`)
+}
+
+// TestImports checks that the encoding itself does not depend on the packages
+// optjsonlite exists to avoid.
+func TestImports(t *testing.T) {
+	for _, dir := range []string{".", "../internal/jsonlite"} {
+		pkg, err := build.ImportDir(dir, 0)
+		require.NoError(t, err)
+		for _, imp := range pkg.Imports {
+			require.NotContains(t, []string{"encoding/json", "reflect", "fmt"}, imp, dir)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package optjsonschema generates JSON Schemas for types that contain
+// opt.Option with github.com/invopop/jsonschema.
+//
+// On its own, the reflector describes an Option[T] with the schema of T (see
+// Option.JSONSchemaAlias), but it has no way of knowing that an Option field
+// may be null or left out. Reflect and ReflectFromType reflect as usual and
+// then allow null for every Option, and remove Option fields from "required":
+//
+//	schema := optjsonschema.Reflect(&jsonschema.Reflector{}, &User{})
+package optjsonschema
+
+import (
+	"reflect"
+	"strings"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"github.com/invopop/jsonschema"
+)
+
+// Reflect is like r.Reflect, but Options may be null and Option fields are not
+// required.
+func Reflect(r *jsonschema.Reflector, v any) *jsonschema.Schema {
+	return ReflectFromType(r, reflect.TypeOf(v))
+}
+
+// ReflectFromType is like r.ReflectFromType, but Options may be null and
+// Option fields are not required.
+func ReflectFromType(r *jsonschema.Reflector, t reflect.Type) *jsonschema.Schema {
+	s := r.ReflectFromType(t)
+	w := &walker{r: r, defs: s.Definitions, seen: make(map[*jsonschema.Schema]bool)}
+	w.walk(t, s)
+	return s
+}
+
+type walker struct {
+	r    *jsonschema.Reflector
+	defs jsonschema.Definitions
+	seen map[*jsonschema.Schema]bool
+}
+
+// walk visits the schema s that was reflected from t.
+func (w *walker) walk(t reflect.Type, s *jsonschema.Schema) {
+	for {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		} else if elem, ok := optreflect.Elem(t); ok {
+			t = elem
+		} else {
+			break
+		}
+	}
+	if s == nil || w.seen[s] {
+		return
+	}
+	w.seen[s] = true
+	if name, ok := strings.CutPrefix(s.Ref, "#/$defs/"); ok {
+		w.walk(t, w.defs[name])
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		w.walkFields(t, s)
+	case reflect.Slice, reflect.Array:
+		s.Items = w.walkValue(t.Elem(), s.Items)
+	case reflect.Map:
+		s.AdditionalProperties = w.walkValue(t.Elem(), s.AdditionalProperties)
+	}
+}
+
+// walkValue visits the schema s reflected from t and returns it, allowing null
+// if t is an Option.
+func (w *walker) walkValue(t reflect.Type, s *jsonschema.Schema) *jsonschema.Schema {
+	w.walk(t, s)
+	if _, ok := optreflect.Elem(t); ok && s != nil {
+		return nullable(s)
+	}
+	return s
+}
+
+func (w *walker) walkFields(t reflect.Type, s *jsonschema.Schema) {
+	if s.Properties == nil {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, embed := w.fieldName(f)
+		if embed {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				w.walkFields(ft, s)
+			}
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		prop, ok := s.Properties.Get(name)
+		if !ok {
+			continue
+		}
+		if _, isOption := optreflect.Elem(f.Type); isOption {
+			s.Required = remove(s.Required, name)
+		}
+		s.Properties.Set(name, w.walkValue(f.Type, prop))
+	}
+}
+
+// fieldName returns the property name the reflector gives f, or whether its
+// fields are inlined into the parent, following the same rules.
+func (w *walker) fieldName(f reflect.StructField) (name string, embed bool) {
+	tag := w.r.FieldNameTag
+	if tag == "" {
+		tag = "json"
+	}
+	tags := strings.Split(f.Tag.Get(tag), ",")
+	if tags[0] == "-" || strings.Split(f.Tag.Get("jsonschema"), ",")[0] == "-" {
+		return "", false
+	}
+	if f.Anonymous && tags[0] == "" {
+		return "", true
+	}
+	for _, t := range tags[1:] {
+		if t == "inline" {
+			return "", true
+		}
+	}
+	if !f.IsExported() {
+		return "", false
+	}
+	name = f.Name
+	if tags[0] != "" {
+		name = tags[0]
+	}
+	if w.r.KeyNamer != nil {
+		name = w.r.KeyNamer(name)
+	}
+	return name, false
+}
+
+// nullable returns s changed to also allow null.
+func nullable(s *jsonschema.Schema) *jsonschema.Schema {
+	for _, alt := range s.OneOf {
+		if alt.Type == "null" {
+			return s
+		}
+	}
+	return &jsonschema.Schema{OneOf: []*jsonschema.Schema{s, {Type: "null"}}}
+}
+
+func remove(names []string, name string) []string {
+	out := names[:0]
+	for _, n := range names {
+		if n != name {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package optjsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/invopop/jsonschema"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string             `json:"city"`
+	Zip  opt.Option[string] `json:"zip"`
+}
+
+type Base struct {
+	CreatedAt opt.Option[int64] `json:"created_at"`
+}
+
+type User struct {
+	Base
+	Name     string                      `json:"name"`
+	Nickname opt.Option[string]          `json:"nickname"`
+	Age      opt.Option[int]             `json:"age,omitempty"`
+	Home     opt.Option[Address]         `json:"home"`
+	Work     Address                     `json:"work"`
+	Scores   []opt.Option[float64]       `json:"scores"`
+	Tags     map[string]opt.Option[bool] `json:"tags"`
+	Ignored  opt.Option[string]          `json:"-"`
+}
+
+func TestReflect(t *testing.T) {
+	s := Reflect(&jsonschema.Reflector{}, &User{})
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id": "https://code.nkcmr.net/opt/optjsonschema/user",
+		"$ref": "#/$defs/User",
+		"$defs": {
+			"Address": {
+				"type": "object",
+				"properties": {
+					"city": {"type": "string"},
+					"zip": {"oneOf": [{"type": "string"}, {"type": "null"}]}
+				},
+				"additionalProperties": false,
+				"required": ["city"]
+			},
+			"User": {
+				"type": "object",
+				"properties": {
+					"created_at": {"oneOf": [{"type": "integer"}, {"type": "null"}]},
+					"name": {"type": "string"},
+					"nickname": {"oneOf": [{"type": "string"}, {"type": "null"}]},
+					"age": {"oneOf": [{"type": "integer"}, {"type": "null"}]},
+					"home": {"oneOf": [{"$ref": "#/$defs/Address"}, {"type": "null"}]},
+					"work": {"$ref": "#/$defs/Address"},
+					"scores": {
+						"type": "array",
+						"items": {"oneOf": [{"type": "number"}, {"type": "null"}]}
+					},
+					"tags": {
+						"type": "object",
+						"additionalProperties": {"oneOf": [{"type": "boolean"}, {"type": "null"}]}
+					}
+				},
+				"additionalProperties": false,
+				"required": ["name", "work", "scores", "tags"]
+			}
+		}
+	}`, string(data))
+}
+
+func TestAliasOnly(t *testing.T) {
+	s := (&jsonschema.Reflector{DoNotReference: true}).Reflect(opt.Some(1))
+	require.Equal(t, "integer", s.Type)
+}
@@ -0,0 +1,82 @@
+// Package optkoanf reads github.com/knadh/koanf configuration into opt.Option
+// values, which tell a key that was never configured apart from one set to
+// its zero value.
+//
+// Unmarshal and UnmarshalWithConf decode into structs with Option fields,
+// which are None for the keys that are not set:
+//
+//	type Config struct {
+//		Port    opt.Option[int]           `koanf:"port"`
+//		Timeout opt.Option[time.Duration] `koanf:"timeout"`
+//	}
+//	var cfg Config
+//	err := optkoanf.Unmarshal(k, "server", &cfg)
+//
+// Get looks up a single key:
+//
+//	port := optkoanf.Get[int](k, "server.port")
+package optkoanf
+
+import (
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/optmapstructure"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/knadh/koanf/v2"
+)
+
+const defaultTag = "koanf"
+
+// DecodeHook returns the hooks koanf decodes with by default, which parse
+// durations and values with an UnmarshalText method, composed with
+// optmapstructure.OptionHook for struct fields tagged with tag, or "koanf" if
+// it is empty.
+func DecodeHook(tag string) mapstructure.DecodeHookFunc {
+	if tag == "" {
+		tag = defaultTag
+	}
+	return optmapstructure.OptionHook(tag,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.TextUnmarshallerHookFunc(),
+	)
+}
+
+// Unmarshal is k.Unmarshal, decoding Options.
+func Unmarshal(k *koanf.Koanf, path string, o any) error {
+	return UnmarshalWithConf(k, path, o, koanf.UnmarshalConf{})
+}
+
+// UnmarshalWithConf is k.UnmarshalWithConf, decoding Options. If
+// c.DecoderConfig is nil, it is set to koanf's default configuration with
+// DecodeHook as its hook. Otherwise, it is used as is and should include
+// DecodeHook.
+func UnmarshalWithConf(k *koanf.Koanf, path string, o any, c koanf.UnmarshalConf) error {
+	if c.DecoderConfig == nil {
+		c.DecoderConfig = &mapstructure.DecoderConfig{
+			DecodeHook:       DecodeHook(c.Tag),
+			WeaklyTypedInput: true,
+			Result:           o,
+		}
+	}
+	return k.UnmarshalWithConf(path, o, c)
+}
+
+// Get returns the value of path decoded as a T, or None if the path does not
+// exist in k. Like the getters of koanf, it ignores values that cannot be
+// decoded, returning None for them; use GetE to find out why.
+func Get[T any](k *koanf.Koanf, path string) opt.Option[T] {
+	o, _ := GetE[T](k, path)
+	return o
+}
+
+// GetE is like Get, but returns an error if the value of path cannot be
+// decoded as a T.
+func GetE[T any](k *koanf.Koanf, path string) (opt.Option[T], error) {
+	if !k.Exists(path) {
+		return opt.None[T](), nil
+	}
+	var x T
+	if err := Unmarshal(k, path, &x); err != nil {
+		return opt.None[T](), err
+	}
+	return opt.Some(x), nil
+}
@@ -0,0 +1,87 @@
+package optkoanf
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newKoanf(t *testing.T) *koanf.Koanf {
+	k := koanf.New(".")
+	require.NoError(t, k.Load(confmap.Provider(map[string]any{
+		"server.host":    "",
+		"server.port":    8080,
+		"server.timeout": "5s",
+		"server.listen":  "::1",
+		"database.host":  "db",
+		"database.port":  "5432",
+	}, "."), nil))
+	return k
+}
+
+type Database struct {
+	Host string             `koanf:"host"`
+	Port opt.Option[int]    `koanf:"port"`
+	User opt.Option[string] `koanf:"user"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	type Server struct {
+		Host    opt.Option[string]        `koanf:"host"`
+		Port    opt.Option[int]           `koanf:"port"`
+		Timeout opt.Option[time.Duration] `koanf:"timeout"`
+		Listen  opt.Option[netip.Addr]    `koanf:"listen"`
+		Workers opt.Option[int]           `koanf:"workers"`
+	}
+	type Config struct {
+		Server   Server               `koanf:"server"`
+		Database opt.Option[Database] `koanf:"database"`
+		Cache    opt.Option[Database] `koanf:"cache"`
+	}
+
+	k := newKoanf(t)
+	var cfg Config
+	require.NoError(t, Unmarshal(k, "", &cfg))
+	require.Equal(t, Config{
+		Server: Server{
+			Host:    opt.Some(""),
+			Port:    opt.Some(8080),
+			Timeout: opt.Some(5 * time.Second),
+			Listen:  opt.Some(netip.IPv6Loopback()),
+		},
+		Database: opt.Some(Database{Host: "db", Port: opt.Some(5432)}),
+	}, cfg)
+
+	var server Server
+	require.NoError(t, UnmarshalWithConf(k, "server", &server, koanf.UnmarshalConf{}))
+	require.Equal(t, cfg.Server, server)
+}
+
+func TestUnmarshalTag(t *testing.T) {
+	type Config struct {
+		Database opt.Option[struct {
+			Host string `cfg:"host"`
+		}] `cfg:"database"`
+	}
+	var cfg Config
+	require.NoError(t, UnmarshalWithConf(newKoanf(t), "", &cfg, koanf.UnmarshalConf{Tag: "cfg"}))
+	require.Equal(t, "db", cfg.Database.Unwrap().Host)
+}
+
+func TestGet(t *testing.T) {
+	k := newKoanf(t)
+	require.Equal(t, opt.Some(""), Get[string](k, "server.host"))
+	require.Equal(t, opt.Some(8080), Get[int](k, "server.port"))
+	require.Equal(t, opt.Some(5*time.Second), Get[time.Duration](k, "server.timeout"))
+	require.Equal(t, opt.Some(Database{Host: "db", Port: opt.Some(5432)}), Get[Database](k, "database"))
+	require.True(t, Get[int](k, "server.workers").None())
+	require.True(t, Get[int](k, "database.host").None())
+
+	_, err := GetE[int](k, "database.host")
+	require.Error(t, err)
+}
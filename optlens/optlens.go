@@ -0,0 +1,141 @@
+// Package optlens provides lenses for reading and updating values nested in
+// structs that may be missing along the way, without mutating the structs.
+//
+// A Lens[S, A] focuses on a value of type A inside an S. Getting it returns
+// an Option, which is None if any optional step along the way is missing, and
+// setting it returns a copy of the S with the value replaced:
+//
+//	var (
+//		orderUser = optlens.OptionField(
+//			func(o Order) opt.Option[User] { return o.User },
+//			func(o Order, u opt.Option[User]) Order { o.User = u; return o },
+//		)
+//		userEmail = optlens.Field(
+//			func(u User) string { return u.Email },
+//			func(u User, email string) User { u.Email = email; return u },
+//		)
+//		orderEmail = optlens.Compose(orderUser, userEmail)
+//	)
+//
+//	// None if order.User is None, and order is unchanged by Modify.
+//	email := orderEmail.Get(order)
+//	order = orderEmail.Modify(order, strings.ToLower)
+//
+// A Prism[S, A] focuses on one case of a sum type S, such as an interface, and
+// can be turned into a Lens to compose with others.
+package optlens
+
+import "code.nkcmr.net/opt"
+
+// Lens focuses on a value of type A that may be inside an S.
+type Lens[S, A any] struct {
+	get func(S) opt.Option[A]
+	set func(S, A) S
+}
+
+// New returns a Lens that gets values with get and sets them with set. If
+// the value is missing, set should return s as it is, or make it present.
+func New[S, A any](get func(S) opt.Option[A], set func(S, A) S) Lens[S, A] {
+	return Lens[S, A]{get: get, set: set}
+}
+
+// Field returns a Lens for a field of S that is always present.
+func Field[S, A any](get func(S) A, set func(S, A) S) Lens[S, A] {
+	return New(func(s S) opt.Option[A] { return opt.Some(get(s)) }, set)
+}
+
+// OptionField returns a Lens for an Option field of S. Getting it returns the
+// field, and setting it sets the field to Some of the new value, even if it was
+// None.
+func OptionField[S, A any](get func(S) opt.Option[A], set func(S, opt.Option[A]) S) Lens[S, A] {
+	return Lens[S, A]{
+		get: get,
+		set: func(s S, a A) S { return set(s, opt.Some(a)) },
+	}
+}
+
+// Get returns the value l focuses on in s, or None if it is missing.
+func (l Lens[S, A]) Get(s S) opt.Option[A] {
+	return l.get(s)
+}
+
+// Set returns s with the value l focuses on replaced by a. If the value is
+// missing, s is returned as it is, except that an OptionField at the end of l
+// is set to Some(a) as long as the rest of the path is present.
+func (l Lens[S, A]) Set(s S, a A) S {
+	return l.set(s, a)
+}
+
+// Modify returns s with the value l focuses on replaced by the result of
+// calling fn with it. If the value is missing, fn is not called and s is
+// returned as it is.
+func (l Lens[S, A]) Modify(s S, fn func(A) A) S {
+	if a, ok := l.get(s).MaybeUnwrap(); ok {
+		return l.set(s, fn(a))
+	}
+	return s
+}
+
+// Compose returns a Lens that focuses on the value inner focuses on inside
+// the value outer focuses on.
+func Compose[S, A, B any](outer Lens[S, A], inner Lens[A, B]) Lens[S, B] {
+	return Lens[S, B]{
+		get: func(s S) opt.Option[B] {
+			return opt.Map(outer.get(s), inner.get)
+		},
+		set: func(s S, b B) S {
+			if a, ok := outer.get(s).MaybeUnwrap(); ok {
+				return outer.set(s, inner.set(a, b))
+			}
+			return s
+		},
+	}
+}
+
+// Prism focuses on the values of a sum type S that are an A.
+type Prism[S, A any] struct {
+	preview func(S) opt.Option[A]
+	review  func(A) S
+}
+
+// NewPrism returns a Prism that matches values with preview and builds them
+// with review.
+func NewPrism[S, A any](preview func(S) opt.Option[A], review func(A) S) Prism[S, A] {
+	return Prism[S, A]{preview: preview, review: review}
+}
+
+// Assert returns a Prism for the values of the interface type S whose
+// dynamic type is A. A must implement S.
+func Assert[S, A any]() Prism[S, A] {
+	return Prism[S, A]{
+		preview: func(s S) opt.Option[A] {
+			a, ok := any(s).(A)
+			return opt.FromMaybe(a, ok)
+		},
+		review: func(a A) S { return any(a).(S) },
+	}
+}
+
+// Preview returns s as an A, or None if it is not one.
+func (p Prism[S, A]) Preview(s S) opt.Option[A] {
+	return p.preview(s)
+}
+
+// Review returns a as an S.
+func (p Prism[S, A]) Review(a A) S {
+	return p.review(a)
+}
+
+// Lens returns a Lens that gets what Preview returns, and only sets values
+// that are already an A.
+func (p Prism[S, A]) Lens() Lens[S, A] {
+	return Lens[S, A]{
+		get: p.preview,
+		set: func(s S, a A) S {
+			if p.preview(s).Some() {
+				return p.review(a)
+			}
+			return s
+		},
+	}
+}
@@ -0,0 +1,143 @@
+package optlens
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string
+}
+
+type User struct {
+	Email   string
+	Address opt.Option[Address]
+}
+
+type Order struct {
+	ID   int
+	User opt.Option[User]
+}
+
+var (
+	orderUser = OptionField(
+		func(o Order) opt.Option[User] { return o.User },
+		func(o Order, u opt.Option[User]) Order { o.User = u; return o },
+	)
+	userEmail = Field(
+		func(u User) string { return u.Email },
+		func(u User, email string) User { u.Email = email; return u },
+	)
+	userAddress = OptionField(
+		func(u User) opt.Option[Address] { return u.Address },
+		func(u User, a opt.Option[Address]) User { u.Address = a; return u },
+	)
+	addressCity = Field(
+		func(a Address) string { return a.City },
+		func(a Address, city string) Address { a.City = city; return a },
+	)
+)
+
+func TestField(t *testing.T) {
+	u := User{Email: "A@example.com"}
+	require.Equal(t, opt.Some("A@example.com"), userEmail.Get(u))
+	require.Equal(t, User{Email: "b@example.com"}, userEmail.Set(u, "b@example.com"))
+	require.Equal(t, User{Email: "a@example.com"}, userEmail.Modify(u, strings.ToLower))
+	require.Equal(t, "A@example.com", u.Email)
+}
+
+func TestOptionField(t *testing.T) {
+	var o Order
+	require.Equal(t, opt.None[User](), orderUser.Get(o))
+	require.Equal(t, o, orderUser.Modify(o, func(User) User { panic("called") }))
+
+	o = orderUser.Set(o, User{Email: "a@example.com"})
+	require.Equal(t, opt.Some(User{Email: "a@example.com"}), o.User)
+}
+
+func TestCompose(t *testing.T) {
+	orderEmail := Compose(orderUser, userEmail)
+	orderCity := Compose(Compose(orderUser, userAddress), addressCity)
+
+	o := Order{ID: 1}
+	require.Equal(t, opt.None[string](), orderEmail.Get(o))
+	require.Equal(t, o, orderEmail.Set(o, "a@example.com"))
+	require.Equal(t, o, orderCity.Modify(o, strings.ToUpper))
+
+	o.User = opt.Some(User{Email: "A@example.com"})
+	require.Equal(t, opt.Some("A@example.com"), orderEmail.Get(o))
+	require.Equal(t, opt.None[string](), orderCity.Get(o))
+	require.Equal(t, "a@example.com", orderEmail.Modify(o, strings.ToLower).User.Unwrap().Email)
+
+	// The last step is an OptionField, so it is set when everything before
+	// it is present.
+	orderAddress := Compose(orderUser, userAddress)
+	o = orderAddress.Set(o, Address{City: "paris"})
+	require.Equal(t, opt.Some("paris"), orderCity.Get(o))
+	require.Equal(t, opt.Some("PARIS"), orderCity.Get(orderCity.Modify(o, strings.ToUpper)))
+	require.Equal(t, opt.Some("paris"), orderCity.Get(o))
+}
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct{ R float64 }
+
+func (c Circle) Area() float64 { return math.Pi * c.R * c.R }
+
+type Square struct{ Side float64 }
+
+func (s Square) Area() float64 { return s.Side * s.Side }
+
+func TestPrism(t *testing.T) {
+	circle := Assert[Shape, Circle]()
+	require.Equal(t, opt.Some(Circle{R: 1}), circle.Preview(Circle{R: 1}))
+	require.Equal(t, opt.None[Circle](), circle.Preview(Square{Side: 1}))
+	require.Equal(t, opt.None[Circle](), circle.Preview(nil))
+	require.Equal(t, Shape(Circle{R: 2}), circle.Review(Circle{R: 2}))
+
+	radius := Compose(circle.Lens(), Field(
+		func(c Circle) float64 { return c.R },
+		func(c Circle, r float64) Circle { c.R = r; return c },
+	))
+	double := func(r float64) float64 { return r * 2 }
+	require.Equal(t, Shape(Circle{R: 2}), radius.Modify(Circle{R: 1}, double))
+	require.Equal(t, Shape(Square{Side: 1}), radius.Modify(Square{Side: 1}, double))
+	require.Equal(t, Shape(Square{Side: 1}), radius.Set(Square{Side: 1}, 3))
+
+	positive := NewPrism(
+		func(n int) opt.Option[uint] { return opt.FromMaybe(uint(n), n > 0) },
+		func(u uint) int { return int(u) },
+	)
+	require.Equal(t, opt.Some(uint(3)), positive.Preview(3))
+	require.Equal(t, opt.None[uint](), positive.Preview(-3))
+	require.Equal(t, 5, positive.Lens().Set(3, 5))
+	require.Equal(t, -3, positive.Lens().Set(-3, 5))
+}
+
+func TestNew(t *testing.T) {
+	first := New(
+		func(s []int) opt.Option[int] {
+			if len(s) == 0 {
+				return opt.None[int]()
+			}
+			return opt.Some(s[0])
+		},
+		func(s []int, v int) []int {
+			if len(s) == 0 {
+				return s
+			}
+			return append([]int{v}, s[1:]...)
+		},
+	)
+	require.Equal(t, opt.Some(1), first.Get([]int{1, 2}))
+	require.Equal(t, opt.None[int](), first.Get(nil))
+	require.Equal(t, []int{3, 2}, first.Set([]int{1, 2}, 3))
+	require.Equal(t, []int(nil), first.Set(nil, 3))
+	require.Equal(t, []int(nil), first.Modify(nil, func(int) int { panic("called") }))
+}
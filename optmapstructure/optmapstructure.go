@@ -0,0 +1,76 @@
+// Package optmapstructure provides decode hooks that decode any value into an
+// Option using github.com/go-viper/mapstructure/v2, the version of
+// mapstructure used by viper:
+//
+//	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+//		DecodeHook: optmapstructure.DecodeHook(),
+//		Result:     &cfg,
+//	})
+//
+// opt.MapstructureHook only decodes values that are simple conversions of the
+// contained type. The hooks of this package decode the contained value with
+// mapstructure itself, so that maps can be decoded into an Option of a struct
+// for example.
+package optmapstructure
+
+import (
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// DecodeHook returns a hook that decodes Options the way viper decodes: it is
+// OptionHook for "mapstructure" tags composed with the hooks viper uses by
+// default, which parse durations and split comma-separated strings into
+// slices.
+func DecodeHook() mapstructure.DecodeHookFunc {
+	return OptionHook("mapstructure",
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToWeakSliceHookFunc(","),
+	)
+}
+
+// OptionHook returns a hook that decodes into Options like
+// opt.MapstructureHook, composed with hooks. The contents of an Option are
+// decoded by a decoder of their own, with weakly typed input, the given
+// struct tag name and the returned hook, which is needed when the decoder the
+// hook is given to does not use "mapstructure" tags.
+func OptionHook(tag string, hooks ...mapstructure.DecodeHookFunc) mapstructure.DecodeHookFunc {
+	var composed mapstructure.DecodeHookFunc
+	hook := func(from, to reflect.Value) (any, error) {
+		elem, ok := optreflect.Elem(to.Type())
+		if !ok || (from.IsValid() && from.Type() == to.Type()) {
+			return from.Interface(), nil
+		}
+		out := reflect.New(to.Type())
+		if !from.IsValid() || isNil(from) {
+			return out.Elem().Interface(), nil
+		}
+		x := reflect.New(elem)
+		d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       composed,
+			WeaklyTypedInput: true,
+			TagName:          tag,
+			Result:           x.Interface(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := d.Decode(from.Interface()); err != nil {
+			return nil, err
+		}
+		optreflect.Set(out, x.Elem())
+		return out.Elem().Interface(), nil
+	}
+	composed = mapstructure.ComposeDecodeHookFunc(append([]mapstructure.DecodeHookFunc{hook}, hooks...)...)
+	return composed
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
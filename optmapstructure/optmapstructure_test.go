@@ -0,0 +1,47 @@
+package optmapstructure
+
+import (
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	type Database struct {
+		Host string          `mapstructure:"host"`
+		Port opt.Option[int] `mapstructure:"port"`
+	}
+	type Config struct {
+		Database opt.Option[Database]      `mapstructure:"database"`
+		Replica  opt.Option[Database]      `mapstructure:"replica"`
+		Tags     opt.Option[[]string]      `mapstructure:"tags"`
+		Workers  opt.Option[int]           `mapstructure:"workers"`
+		Timeout  opt.Option[time.Duration] `mapstructure:"timeout"`
+		Hosts    opt.Option[[]string]      `mapstructure:"hosts"`
+	}
+
+	var cfg Config
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: DecodeHook(),
+		Result:     &cfg,
+	})
+	require.NoError(t, err)
+	err = d.Decode(map[string]any{
+		"database": map[string]any{"host": "db", "port": "5432"},
+		"tags":     []any{"a", "b"},
+		"workers":  "4",
+		"timeout":  "5s",
+		"hosts":    "a,b",
+	})
+	require.NoError(t, err)
+	require.Equal(t, Config{
+		Database: opt.Some(Database{Host: "db", Port: opt.Some(5432)}),
+		Tags:     opt.Some([]string{"a", "b"}),
+		Workers:  opt.Some(4),
+		Timeout:  opt.Some(5 * time.Second),
+		Hosts:    opt.Some([]string{"a", "b"}),
+	}, cfg)
+}
@@ -0,0 +1,42 @@
+// Package optmath provides arithmetic on Options of numbers, so that
+// calculations with optional inputs read like ordinary arithmetic:
+//
+//	total := optmath.Add(subtotal, optmath.Mul(subtotal, taxRate))
+//	perItem := optmath.Div(total, count)
+//
+// The result of each operation is None if any operand is None. Unlike
+// opt.Sum and opt.Product, which skip None values, these propagate them.
+package optmath
+
+import "code.nkcmr.net/opt"
+
+// Add returns Some(a + b) if both a and b are Some.
+func Add[N opt.Number](a, b opt.Option[N]) opt.Option[N] {
+	return opt.Join(a, b, func(a, b N) N { return a + b })
+}
+
+// Sub returns Some(a - b) if both a and b are Some.
+func Sub[N opt.Number](a, b opt.Option[N]) opt.Option[N] {
+	return opt.Join(a, b, func(a, b N) N { return a - b })
+}
+
+// Mul returns Some(a * b) if both a and b are Some.
+func Mul[N opt.Number](a, b opt.Option[N]) opt.Option[N] {
+	return opt.Join(a, b, func(a, b N) N { return a * b })
+}
+
+// Div returns Some(a / b) if both a and b are Some and b is not zero. This
+// holds for floating-point numbers too, which would otherwise give an
+// infinity or NaN.
+func Div[N opt.Number](a, b opt.Option[N]) opt.Option[N] {
+	if b.UnwrapOrZero() == 0 {
+		return opt.None[N]()
+	}
+	return opt.Join(a, b, func(a, b N) N { return a / b })
+}
+
+// Neg returns Some(-a) if a is Some. For unsigned types, the negation wraps
+// around as it does in Go.
+func Neg[N opt.Number](a opt.Option[N]) opt.Option[N] {
+	return opt.Map(a, func(a N) opt.Option[N] { return opt.Some(-a) })
+}
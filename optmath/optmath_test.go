@@ -0,0 +1,46 @@
+package optmath
+
+import (
+	"math"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd(t *testing.T) {
+	require.Equal(t, opt.Some(5), Add(opt.Some(2), opt.Some(3)))
+	require.Equal(t, opt.None[int](), Add(opt.Some(2), opt.None[int]()))
+	require.Equal(t, opt.None[int](), Add(opt.None[int](), opt.Some(3)))
+	require.Equal(t, opt.Some(1.5), Add(opt.Some(1.0), opt.Some(0.5)))
+}
+
+func TestSub(t *testing.T) {
+	require.Equal(t, opt.Some(-1), Sub(opt.Some(2), opt.Some(3)))
+	require.Equal(t, opt.Some(uint(math.MaxUint)), Sub(opt.Some(uint(0)), opt.Some(uint(1))))
+	require.Equal(t, opt.None[int](), Sub(opt.None[int](), opt.None[int]()))
+}
+
+func TestMul(t *testing.T) {
+	require.Equal(t, opt.Some(6), Mul(opt.Some(2), opt.Some(3)))
+	require.Equal(t, opt.Some(0.0), Mul(opt.Some(0.0), opt.Some(3.0)))
+	require.Equal(t, opt.None[float64](), Mul(opt.Some(2.0), opt.None[float64]()))
+}
+
+func TestDiv(t *testing.T) {
+	require.Equal(t, opt.Some(2), Div(opt.Some(7), opt.Some(3)))
+	require.Equal(t, opt.Some(2.5), Div(opt.Some(5.0), opt.Some(2.0)))
+	require.Equal(t, opt.None[int](), Div(opt.Some(7), opt.Some(0)))
+	require.Equal(t, opt.None[float64](), Div(opt.Some(1.0), opt.Some(0.0)))
+	require.Equal(t, opt.None[float64](), Div(opt.Some(1.0), opt.Some(math.Copysign(0, -1))))
+	require.Equal(t, opt.None[int](), Div(opt.None[int](), opt.Some(1)))
+	require.Equal(t, opt.None[int](), Div(opt.Some(1), opt.None[int]()))
+	require.Equal(t, opt.Some(int8(math.MinInt8)), Div(opt.Some(int8(math.MinInt8)), opt.Some(int8(-1))))
+}
+
+func TestNeg(t *testing.T) {
+	require.Equal(t, opt.Some(-2), Neg(opt.Some(2)))
+	require.Equal(t, opt.Some(1.5), Neg(opt.Some(-1.5)))
+	require.Equal(t, opt.Some(uint8(255)), Neg(opt.Some(uint8(1))))
+	require.Equal(t, opt.None[int](), Neg(opt.None[int]()))
+}
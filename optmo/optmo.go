@@ -0,0 +1,21 @@
+// Package optmo converts between opt.Option and the Option type of
+// github.com/samber/mo, for code that uses both.
+package optmo
+
+import (
+	"code.nkcmr.net/opt"
+	"github.com/samber/mo"
+)
+
+// From returns an opt.Option holding the same value as o.
+func From[T any](o mo.Option[T]) opt.Option[T] {
+	return opt.FromMaybe(o.Get())
+}
+
+// To returns a mo.Option holding the same value as o.
+func To[T any](o opt.Option[T]) mo.Option[T] {
+	if v, ok := o.MaybeUnwrap(); ok {
+		return mo.Some(v)
+	}
+	return mo.None[T]()
+}
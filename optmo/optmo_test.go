@@ -0,0 +1,22 @@
+package optmo
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/samber/mo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	require.Equal(t, opt.Some(5), From(mo.Some(5)))
+	require.Equal(t, opt.Some(0), From(mo.Some(0)))
+	require.Equal(t, opt.None[int](), From(mo.None[int]()))
+	require.Equal(t, opt.None[int](), From(mo.Option[int]{}))
+}
+
+func TestTo(t *testing.T) {
+	require.Equal(t, mo.Some("x"), To(opt.Some("x")))
+	require.Equal(t, mo.None[string](), To(opt.None[string]()))
+	require.Equal(t, opt.Some([]int{1}), From(To(opt.Some([]int{1}))))
+}
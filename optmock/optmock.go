@@ -0,0 +1,107 @@
+// Package optmock provides go.uber.org/mock matchers for opt.Option
+// arguments:
+//
+//	store.EXPECT().List(ctx, optmock.SomeEq(50), optmock.None[string]())
+//	store.EXPECT().Find(optmock.SomeFunc("a positive ID", func(id int) bool {
+//		return id > 0
+//	}))
+//
+// The matchers describe the Options they received as Some(x) or None when an
+// expectation is not met.
+package optmock
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+	"go.uber.org/mock/gomock"
+)
+
+// SomeEq returns a matcher that matches Some of a value equal to v, as
+// gomock.Eq determines.
+func SomeEq[T any](v T) gomock.Matcher {
+	return Some[T](gomock.Eq(v))
+}
+
+// Some returns a matcher that matches Some of a value matched by m.
+func Some[T any](m gomock.Matcher) gomock.Matcher {
+	return someMatcher[T]{m}
+}
+
+// SomeFunc returns a matcher that matches Some of a value for which pred
+// returns true. The matcher is described by desc when it is not met.
+func SomeFunc[T any](desc string, pred func(T) bool) gomock.Matcher {
+	return Some[T](funcMatcher[T]{desc, pred})
+}
+
+// None returns a matcher that matches None of type T.
+func None[T any]() gomock.Matcher {
+	return noneMatcher[T]{}
+}
+
+type someMatcher[T any] struct {
+	m gomock.Matcher
+}
+
+func (s someMatcher[T]) Matches(x any) bool {
+	o, ok := x.(opt.Option[T])
+	if !ok {
+		return false
+	}
+	v, ok := o.MaybeUnwrap()
+	return ok && s.m.Matches(v)
+}
+
+func (s someMatcher[T]) String() string {
+	return fmt.Sprintf("is Some(%s)", s.m)
+}
+
+func (someMatcher[T]) Got(got any) string {
+	return format(got)
+}
+
+type noneMatcher[T any] struct{}
+
+func (noneMatcher[T]) Matches(x any) bool {
+	o, ok := x.(opt.Option[T])
+	return ok && o.None()
+}
+
+func (noneMatcher[T]) String() string {
+	return "is None"
+}
+
+func (noneMatcher[T]) Got(got any) string {
+	return format(got)
+}
+
+type funcMatcher[T any] struct {
+	desc string
+	pred func(T) bool
+}
+
+func (f funcMatcher[T]) Matches(x any) bool {
+	v, ok := x.(T)
+	return ok && f.pred(v)
+}
+
+func (f funcMatcher[T]) String() string {
+	return f.desc
+}
+
+// format describes got the way gomock does, but showing Options as Some(x) or
+// None.
+func format(got any) string {
+	rv := reflect.ValueOf(got)
+	if rv.IsValid() {
+		if _, ok := optreflect.Elem(rv.Type()); ok {
+			if v, ok := optreflect.Get(rv); ok {
+				return fmt.Sprintf("Some(%v) (%T)", v, got)
+			}
+			return fmt.Sprintf("None (%T)", got)
+		}
+	}
+	return fmt.Sprintf("%v (%T)", got, got)
+}
@@ -0,0 +1,64 @@
+package optmock
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSomeEq(t *testing.T) {
+	m := SomeEq(50)
+	require.True(t, m.Matches(opt.Some(50)))
+	require.False(t, m.Matches(opt.Some(49)))
+	require.False(t, m.Matches(opt.None[int]()))
+	require.False(t, m.Matches(opt.Some(int64(50))))
+	require.False(t, m.Matches(50))
+	require.Equal(t, "is Some(is equal to 50 (int))", m.String())
+
+	require.True(t, SomeEq([]string{"a"}).Matches(opt.Some([]string{"a"})))
+}
+
+func TestSome(t *testing.T) {
+	m := Some[[]int](gomock.Len(2))
+	require.True(t, m.Matches(opt.Some([]int{1, 2})))
+	require.False(t, m.Matches(opt.Some([]int{1})))
+	require.False(t, m.Matches(opt.None[[]int]()))
+	require.Equal(t, "is Some(has length 2)", m.String())
+}
+
+func TestSomeFunc(t *testing.T) {
+	m := SomeFunc("a positive ID", func(id int) bool { return id > 0 })
+	require.True(t, m.Matches(opt.Some(1)))
+	require.False(t, m.Matches(opt.Some(0)))
+	require.False(t, m.Matches(opt.None[int]()))
+	require.Equal(t, "is Some(a positive ID)", m.String())
+}
+
+func TestNone(t *testing.T) {
+	m := None[string]()
+	require.True(t, m.Matches(opt.None[string]()))
+	require.False(t, m.Matches(opt.Some("")))
+	require.False(t, m.Matches(opt.None[int]()))
+	require.False(t, m.Matches(nil))
+	require.Equal(t, "is None", m.String())
+}
+
+func TestGot(t *testing.T) {
+	got := gotFormatter(t, None[int]())
+	require.Equal(t, "Some(3) (opt.Option[int])", got.Got(opt.Some(3)))
+	require.Equal(t, "None (opt.Option[int])", got.Got(opt.None[int]()))
+	require.Equal(t, "3 (int)", got.Got(3))
+	require.Equal(t, "<nil> (<nil>)", got.Got(nil))
+
+	gotFormatter(t, SomeEq(1))
+	gotFormatter(t, SomeFunc("odd", func(int) bool { return true }))
+}
+
+func gotFormatter(t *testing.T, m gomock.Matcher) gomock.GotFormatter {
+	t.Helper()
+	g, ok := m.(gomock.GotFormatter)
+	require.True(t, ok)
+	return g
+}
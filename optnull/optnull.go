@@ -0,0 +1,77 @@
+// Package optnull converts between opt.Option and the nullable types of
+// github.com/guregu/null, so models built on guregu/null can adopt opt one
+// field at a time.
+package optnull
+
+import (
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/guregu/null/v5"
+)
+
+// FromString returns Some(s.String) if s is valid, otherwise None.
+func FromString(s null.String) opt.Option[string] {
+	return opt.FromMaybe(s.String, s.Valid)
+}
+
+// ToString returns a null.String holding the same value as o.
+func ToString(o opt.Option[string]) null.String {
+	v, ok := o.MaybeUnwrap()
+	return null.NewString(v, ok)
+}
+
+// FromInt returns Some(i.Int64) if i is valid, otherwise None.
+func FromInt(i null.Int) opt.Option[int64] {
+	return opt.FromMaybe(i.Int64, i.Valid)
+}
+
+// ToInt returns a null.Int holding the same value as o.
+func ToInt(o opt.Option[int64]) null.Int {
+	v, ok := o.MaybeUnwrap()
+	return null.NewInt(v, ok)
+}
+
+// FromFloat returns Some(f.Float64) if f is valid, otherwise None.
+func FromFloat(f null.Float) opt.Option[float64] {
+	return opt.FromMaybe(f.Float64, f.Valid)
+}
+
+// ToFloat returns a null.Float holding the same value as o.
+func ToFloat(o opt.Option[float64]) null.Float {
+	v, ok := o.MaybeUnwrap()
+	return null.NewFloat(v, ok)
+}
+
+// FromBool returns Some(b.Bool) if b is valid, otherwise None.
+func FromBool(b null.Bool) opt.Option[bool] {
+	return opt.FromMaybe(b.Bool, b.Valid)
+}
+
+// ToBool returns a null.Bool holding the same value as o.
+func ToBool(o opt.Option[bool]) null.Bool {
+	v, ok := o.MaybeUnwrap()
+	return null.NewBool(v, ok)
+}
+
+// FromTime returns Some(t.Time) if t is valid, otherwise None.
+func FromTime(t null.Time) opt.Option[time.Time] {
+	return opt.FromMaybe(t.Time, t.Valid)
+}
+
+// ToTime returns a null.Time holding the same value as o.
+func ToTime(o opt.Option[time.Time]) null.Time {
+	v, ok := o.MaybeUnwrap()
+	return null.NewTime(v, ok)
+}
+
+// FromValue returns Some(v.V) if v is valid, otherwise None.
+func FromValue[T any](v null.Value[T]) opt.Option[T] {
+	return opt.FromMaybe(v.V, v.Valid)
+}
+
+// ToValue returns a null.Value holding the same value as o.
+func ToValue[T any](o opt.Option[T]) null.Value[T] {
+	v, ok := o.MaybeUnwrap()
+	return null.NewValue(v, ok)
+}
@@ -0,0 +1,54 @@
+package optnull
+
+import (
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestString(t *testing.T) {
+	require.Equal(t, opt.Some("a"), FromString(null.StringFrom("a")))
+	require.Equal(t, opt.Some(""), FromString(null.StringFrom("")))
+	require.Equal(t, opt.None[string](), FromString(null.String{}))
+	require.Equal(t, null.StringFrom("a"), ToString(opt.Some("a")))
+	require.Equal(t, null.String{}, ToString(opt.None[string]()))
+}
+
+func TestInt(t *testing.T) {
+	require.Equal(t, opt.Some[int64](7), FromInt(null.IntFrom(7)))
+	require.Equal(t, opt.None[int64](), FromInt(null.Int{}))
+	require.Equal(t, null.IntFrom(0), ToInt(opt.Some[int64](0)))
+	require.Equal(t, null.Int{}, ToInt(opt.None[int64]()))
+}
+
+func TestFloat(t *testing.T) {
+	require.Equal(t, opt.Some(1.5), FromFloat(null.FloatFrom(1.5)))
+	require.Equal(t, opt.None[float64](), FromFloat(null.Float{}))
+	require.Equal(t, null.FloatFrom(1.5), ToFloat(opt.Some(1.5)))
+	require.Equal(t, null.Float{}, ToFloat(opt.None[float64]()))
+}
+
+func TestBool(t *testing.T) {
+	require.Equal(t, opt.Some(false), FromBool(null.BoolFrom(false)))
+	require.Equal(t, opt.None[bool](), FromBool(null.Bool{}))
+	require.Equal(t, null.BoolFrom(true), ToBool(opt.Some(true)))
+	require.Equal(t, null.Bool{}, ToBool(opt.None[bool]()))
+}
+
+func TestTime(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, opt.Some(now), FromTime(null.TimeFrom(now)))
+	require.Equal(t, opt.None[time.Time](), FromTime(null.Time{}))
+	require.Equal(t, null.TimeFrom(now), ToTime(opt.Some(now)))
+	require.Equal(t, null.Time{}, ToTime(opt.None[time.Time]()))
+}
+
+func TestValue(t *testing.T) {
+	require.Equal(t, opt.Some[byte](3), FromValue(null.ValueFrom[byte](3)))
+	require.Equal(t, opt.None[byte](), FromValue(null.Value[byte]{}))
+	require.Equal(t, null.ValueFrom("x"), ToValue(opt.Some("x")))
+	require.Equal(t, null.Value[string]{}, ToValue(opt.None[string]()))
+}
@@ -0,0 +1,64 @@
+// Package optopenapi teaches the OpenAPI schema generator of
+// github.com/getkin/kin-openapi (openapi3gen) about opt.Option.
+//
+// Without help, the generator sees an Option as a struct with no exported
+// fields and describes it as an empty schema. SchemaCustomizer describes an
+// Option[T] with the schema of T instead, marked `nullable: true`:
+//
+//	opts := []openapi3gen.Option{openapi3gen.UseAllExportedFields()}
+//	ref, err := openapi3gen.NewSchemaRefForValue(&User{}, nil,
+//		append(opts, optopenapi.SchemaCustomizer(nil, opts...))...)
+package optopenapi
+
+import (
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+)
+
+// SchemaCustomizer returns a generator option that describes Options with the
+// schema of the value they contain, made nullable. next, if not nil, is
+// called for every schema like any other openapi3gen.SchemaCustomizerFn,
+// including the ones of Options once they are filled in. opts are the other
+// options given to the generator, which are needed to generate the schemas of
+// the values inside Options the same way.
+//
+// The returned option keeps track of the Options it is describing in order to
+// handle recursive types, so it must not be used by several generators at the
+// same time.
+func SchemaCustomizer(next openapi3gen.SchemaCustomizerFn, opts ...openapi3gen.Option) openapi3gen.Option {
+	c := &customizer{next: next, active: make(map[reflect.Type]bool)}
+	c.opts = append(append([]openapi3gen.Option(nil), opts...), openapi3gen.SchemaCustomizer(c.customize))
+	return openapi3gen.SchemaCustomizer(c.customize)
+}
+
+type customizer struct {
+	next   openapi3gen.SchemaCustomizerFn
+	opts   []openapi3gen.Option
+	active map[reflect.Type]bool
+}
+
+func (c *customizer) customize(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	if elem, ok := optreflect.Elem(t); ok {
+		// A recursive type is only expanded once; the Option nested inside
+		// itself is left as an empty schema.
+		if !c.active[elem] {
+			c.active[elem] = true
+			ref, err := openapi3gen.NewGenerator(c.opts...).NewSchemaRefForValue(reflect.New(elem).Interface(), nil)
+			delete(c.active, elem)
+			if err != nil {
+				return err
+			}
+			if ref != nil && ref.Value != nil {
+				*schema = *ref.Value
+			}
+		}
+		schema.Nullable = true
+	}
+	if c.next != nil {
+		return c.next(name, t, tag, schema)
+	}
+	return nil
+}
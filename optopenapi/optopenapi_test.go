@@ -0,0 +1,82 @@
+package optopenapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string             `json:"city"`
+	Zip  opt.Option[string] `json:"zip"`
+}
+
+type user struct {
+	Name     string                `json:"name"`
+	Nickname opt.Option[string]    `json:"nickname" description:"what friends call you"`
+	Age      opt.Option[int32]     `json:"age"`
+	Home     opt.Option[address]   `json:"home"`
+	Scores   []opt.Option[float64] `json:"scores"`
+}
+
+type node struct {
+	Value int               `json:"value"`
+	Next  opt.Option[*node] `json:"next"`
+}
+
+func generate(t *testing.T, v any, next openapi3gen.SchemaCustomizerFn) string {
+	ref, err := openapi3gen.NewSchemaRefForValue(v, nil, SchemaCustomizer(next))
+	require.NoError(t, err)
+	data, err := json.Marshal(ref)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestSchemaCustomizer(t *testing.T) {
+	describe := func(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+		schema.Description = tag.Get("description")
+		return nil
+	}
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"nickname": {"type": "string", "nullable": true, "description": "what friends call you"},
+			"age": {"type": "integer", "format": "int32", "nullable": true},
+			"home": {
+				"type": "object",
+				"nullable": true,
+				"properties": {
+					"city": {"type": "string"},
+					"zip": {"type": "string", "nullable": true}
+				}
+			},
+			"scores": {
+				"type": "array",
+				"items": {"type": "number", "format": "double", "nullable": true}
+			}
+		}
+	}`, generate(t, &user{}, describe))
+}
+
+func TestRecursive(t *testing.T) {
+	require.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"value": {"type": "integer"},
+			"next": {
+				"type": "object",
+				"nullable": true,
+				"properties": {
+					"value": {"type": "integer"},
+					"next": {"nullable": true}
+				}
+			}
+		}
+	}`, generate(t, &node{}, nil))
+}
@@ -0,0 +1,108 @@
+// Package optotel builds OpenTelemetry attributes from opt.Option values,
+// using go.opentelemetry.io/otel/attribute. Each function mirrors the
+// attribute constructor of the same name, but returns a slice that is empty
+// for None, so only attributes that are present are set on a span:
+//
+//	span.SetAttributes(optotel.Join(
+//		optotel.String("user.id", userID),
+//		optotel.Int64("retry.count", retries),
+//	)...)
+package optotel
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"code.nkcmr.net/opt"
+)
+
+func attr[T any](key string, o opt.Option[T], kv func(string, T) attribute.KeyValue) []attribute.KeyValue {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return []attribute.KeyValue{kv(key, v)}
+}
+
+// String returns a string attribute holding the value of o, or nothing if o
+// is None.
+func String(key string, o opt.Option[string]) []attribute.KeyValue {
+	return attr(key, o, attribute.String)
+}
+
+// Int returns an int attribute holding the value of o, or nothing if o is
+// None.
+func Int(key string, o opt.Option[int]) []attribute.KeyValue {
+	return attr(key, o, attribute.Int)
+}
+
+// Int64 returns an int64 attribute holding the value of o, or nothing if o is
+// None.
+func Int64(key string, o opt.Option[int64]) []attribute.KeyValue {
+	return attr(key, o, attribute.Int64)
+}
+
+// Float64 returns a float64 attribute holding the value of o, or nothing if o
+// is None.
+func Float64(key string, o opt.Option[float64]) []attribute.KeyValue {
+	return attr(key, o, attribute.Float64)
+}
+
+// Bool returns a bool attribute holding the value of o, or nothing if o is
+// None.
+func Bool(key string, o opt.Option[bool]) []attribute.KeyValue {
+	return attr(key, o, attribute.Bool)
+}
+
+// Stringer returns a string attribute holding the String of the value of o,
+// or nothing if o is None.
+func Stringer[T fmt.Stringer](key string, o opt.Option[T]) []attribute.KeyValue {
+	return attr(key, o, func(key string, v T) attribute.KeyValue {
+		return attribute.Stringer(key, v)
+	})
+}
+
+// StringSlice returns a string slice attribute holding the value of o, or
+// nothing if o is None.
+func StringSlice(key string, o opt.Option[[]string]) []attribute.KeyValue {
+	return attr(key, o, attribute.StringSlice)
+}
+
+// IntSlice returns an int slice attribute holding the value of o, or nothing
+// if o is None.
+func IntSlice(key string, o opt.Option[[]int]) []attribute.KeyValue {
+	return attr(key, o, attribute.IntSlice)
+}
+
+// Int64Slice returns an int64 slice attribute holding the value of o, or
+// nothing if o is None.
+func Int64Slice(key string, o opt.Option[[]int64]) []attribute.KeyValue {
+	return attr(key, o, attribute.Int64Slice)
+}
+
+// Float64Slice returns a float64 slice attribute holding the value of o, or
+// nothing if o is None.
+func Float64Slice(key string, o opt.Option[[]float64]) []attribute.KeyValue {
+	return attr(key, o, attribute.Float64Slice)
+}
+
+// BoolSlice returns a bool slice attribute holding the value of o, or nothing
+// if o is None.
+func BoolSlice(key string, o opt.Option[[]bool]) []attribute.KeyValue {
+	return attr(key, o, attribute.BoolSlice)
+}
+
+// Join returns the attributes of each of attrs in a single slice, for passing
+// to functions such as Span.SetAttributes.
+func Join(attrs ...[]attribute.KeyValue) []attribute.KeyValue {
+	n := 0
+	for _, a := range attrs {
+		n += len(a)
+	}
+	out := make([]attribute.KeyValue, 0, n)
+	for _, a := range attrs {
+		out = append(out, a...)
+	}
+	return out
+}
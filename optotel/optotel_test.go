@@ -0,0 +1,43 @@
+package optotel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"code.nkcmr.net/opt"
+)
+
+func TestAttributes(t *testing.T) {
+	require.Equal(t, []attribute.KeyValue{attribute.String("k", "v")}, String("k", opt.Some("v")))
+	require.Equal(t, []attribute.KeyValue{attribute.Int("k", 1)}, Int("k", opt.Some(1)))
+	require.Equal(t, []attribute.KeyValue{attribute.Int64("k", 2)}, Int64("k", opt.Some[int64](2)))
+	require.Equal(t, []attribute.KeyValue{attribute.Float64("k", 1.5)}, Float64("k", opt.Some(1.5)))
+	require.Equal(t, []attribute.KeyValue{attribute.Bool("k", true)}, Bool("k", opt.Some(true)))
+	require.Equal(t, []attribute.KeyValue{attribute.String("k", "1s")}, Stringer("k", opt.Some(time.Second)))
+	require.Equal(t, []attribute.KeyValue{attribute.StringSlice("k", []string{"a"})}, StringSlice("k", opt.Some([]string{"a"})))
+	require.Equal(t, []attribute.KeyValue{attribute.IntSlice("k", []int{1})}, IntSlice("k", opt.Some([]int{1})))
+	require.Equal(t, []attribute.KeyValue{attribute.Int64Slice("k", []int64{1})}, Int64Slice("k", opt.Some([]int64{1})))
+	require.Equal(t, []attribute.KeyValue{attribute.Float64Slice("k", []float64{1})}, Float64Slice("k", opt.Some([]float64{1})))
+	require.Equal(t, []attribute.KeyValue{attribute.BoolSlice("k", []bool{true})}, BoolSlice("k", opt.Some([]bool{true})))
+
+	require.Empty(t, String("k", opt.None[string]()))
+	require.Empty(t, Int64("k", opt.None[int64]()))
+	require.Empty(t, Stringer("k", opt.None[time.Duration]()))
+	require.Empty(t, BoolSlice("k", opt.None[[]bool]()))
+}
+
+func TestJoin(t *testing.T) {
+	attrs := Join(
+		String("user.id", opt.Some("u1")),
+		Int64("retry.count", opt.None[int64]()),
+		Bool("cached", opt.Some(false)),
+	)
+	require.Equal(t, []attribute.KeyValue{
+		attribute.String("user.id", "u1"),
+		attribute.Bool("cached", false),
+	}, attrs)
+	require.Empty(t, Join())
+}
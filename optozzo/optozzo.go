@@ -0,0 +1,118 @@
+// Package optozzo provides github.com/go-ozzo/ozzo-validation rules for
+// opt.Option values.
+//
+// WhenSome applies rules to the value an Option contains, skipping them when
+// it is None, and Required requires it to be Some:
+//
+//	func (s Signup) Validate() error {
+//		return validation.ValidateStruct(&s,
+//			validation.Field(&s.Email, optozzo.Required, optozzo.WhenSome(is.Email)),
+//			validation.Field(&s.Nickname, optozzo.WhenSome(validation.Length(3, 20))),
+//		)
+//	}
+package optozzo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ErrRequired is the error Required returns when an Option is None.
+var ErrRequired = validation.NewError("validation_some_required", "is required")
+
+// Required is a rule that checks that an Option is Some, whatever it
+// contains. Unlike validation.Required, it accepts Some of an empty value.
+var Required = RequiredRule{}
+
+// RequiredRule is a rule that checks that an Option is Some.
+type RequiredRule struct {
+	err validation.Error
+}
+
+// Validate checks that value, an Option or a pointer to one, is Some.
+func (r RequiredRule) Validate(value any) error {
+	_, ok, err := get(value)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if r.err != nil {
+			return r.err
+		}
+		return ErrRequired
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r RequiredRule) Error(message string) RequiredRule {
+	if r.err == nil {
+		r.err = ErrRequired
+	}
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r RequiredRule) ErrorObject(err validation.Error) RequiredRule {
+	r.err = err
+	return r
+}
+
+// WhenSome returns a rule that validates the value an Option contains with
+// rules if it is Some, and does nothing if it is None.
+func WhenSome(rules ...validation.Rule) SomeRule {
+	return SomeRule{rules: rules}
+}
+
+// SomeRule is a rule that validates the value an Option contains.
+type SomeRule struct {
+	rules []validation.Rule
+}
+
+// Validate validates the value contained by value, an Option or a pointer to
+// one, if it is Some.
+func (r SomeRule) Validate(value any) error {
+	v, ok, err := get(value)
+	if err != nil || !ok {
+		return err
+	}
+	return validation.Validate(v, r.rules...)
+}
+
+// ValidateWithContext is like Validate, passing ctx on to rules that accept
+// one.
+func (r SomeRule) ValidateWithContext(ctx context.Context, value any) error {
+	v, ok, err := get(value)
+	if err != nil || !ok {
+		return err
+	}
+	return validation.ValidateWithContext(ctx, v, r.rules...)
+}
+
+// get returns the value contained by the Option value, or by the Option it
+// points to, and whether it is Some. A nil pointer is treated as None.
+func get(value any) (any, bool, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false, nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, false, nil
+	}
+	if _, ok := optreflect.Elem(rv.Type()); !ok {
+		return nil, false, validation.NewInternalError(fmt.Errorf("optozzo: %T is not an opt.Option", value))
+	}
+	v, ok := optreflect.Get(rv)
+	if !ok {
+		return nil, false, nil
+	}
+	return v.Interface(), true, nil
+}
@@ -0,0 +1,83 @@
+package optozzo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/stretchr/testify/require"
+)
+
+type Signup struct {
+	Email    opt.Option[string]
+	Nickname opt.Option[string]
+	Age      opt.Option[int]
+}
+
+func (s Signup) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Email, Required, WhenSome(validation.Required, is.Email)),
+		validation.Field(&s.Nickname, WhenSome(validation.Length(3, 20))),
+		validation.Field(&s.Age, WhenSome(validation.Min(13))),
+	)
+}
+
+func TestValidateStruct(t *testing.T) {
+	require.NoError(t, Signup{Email: opt.Some("a@example.com")}.Validate())
+	require.NoError(t, Signup{
+		Email:    opt.Some("a@example.com"),
+		Nickname: opt.Some("nick"),
+		Age:      opt.Some(30),
+	}.Validate())
+
+	err := Signup{}.Validate()
+	var errs validation.Errors
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, validation.Errors{"Email": ErrRequired}, errs)
+
+	err = Signup{Email: opt.Some("nope"), Nickname: opt.Some("a"), Age: opt.Some(3)}.Validate()
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 3)
+	require.EqualError(t, errs["Email"], "must be a valid email address")
+
+	err = Signup{Email: opt.Some("")}.Validate()
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, validation.Errors{"Email": validation.ErrRequired}, errs)
+}
+
+func TestRequired(t *testing.T) {
+	require.NoError(t, Required.Validate(opt.Some("")))
+	require.Equal(t, ErrRequired, Required.Validate(opt.None[string]()))
+	require.Equal(t, ErrRequired, Required.Validate((*opt.Option[string])(nil)))
+	require.EqualError(t, Required.Error("must be given").Validate(opt.None[int]()), "must be given")
+
+	custom := validation.NewError("custom", "missing")
+	require.Equal(t, custom, Required.ErrorObject(custom).Validate(opt.None[int]()))
+
+	var internal validation.InternalError
+	require.True(t, errors.As(Required.Validate("x"), &internal))
+}
+
+func TestWhenSome(t *testing.T) {
+	rule := WhenSome(validation.Max(10))
+	require.NoError(t, rule.Validate(opt.None[int]()))
+	require.NoError(t, rule.Validate(opt.Some(5)))
+	require.Error(t, rule.Validate(opt.Some(11)))
+
+	o := opt.Some(11)
+	require.Error(t, rule.Validate(&o))
+
+	ctxRule := validation.WithContext(func(ctx context.Context, value any) error {
+		if ctx.Value("limit") == value {
+			return errors.New("at limit")
+		}
+		return nil
+	})
+	ctx := context.WithValue(context.Background(), "limit", 3)
+	require.NoError(t, WhenSome(ctxRule).ValidateWithContext(ctx, opt.Some(2)))
+	require.EqualError(t, WhenSome(ctxRule).ValidateWithContext(ctx, opt.Some(3)), "at limit")
+	require.NoError(t, WhenSome(ctxRule).ValidateWithContext(ctx, opt.None[int]()))
+}
@@ -0,0 +1,76 @@
+// Package optparquet lets structs with opt.Option fields be written to and read
+// from Parquet files with github.com/parquet-go/parquet-go.
+//
+// parquet-go only understands pointers as optional values, so rows are mapped
+// to a mirror type built at run time in which every Option[T] field is a *T
+// tagged "optional". None is written as a null cell (a definition level below
+// the column's maximum) and null cells are read back as None.
+package optparquet
+
+import (
+	"io"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/parquet-go/parquet-go"
+)
+
+// SchemaOf is like parquet.SchemaOf except that Option fields become OPTIONAL
+// columns of the type they contain.
+func SchemaOf(model any) *parquet.Schema {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return parquet.NewSchema(t.Name(), parquet.SchemaOf(reflect.New(mapper.TypeOf(t)).Interface()))
+}
+
+// Write writes rows to a parquet file written to w, like parquet.Write.
+func Write[T any](w io.Writer, rows []T, options ...parquet.WriterOption) error {
+	var model T
+	if !shadow.Needs(reflect.TypeOf(&model).Elem()) {
+		return parquet.Write(w, rows, options...)
+	}
+	writer := parquet.NewWriter(w, append(options, SchemaOf(&model))...)
+	for i := range rows {
+		if err := writer.Write(mapper.To(reflect.ValueOf(&rows[i]).Elem()).Interface()); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// Read reads all the rows of the parquet file in r, like parquet.Read.
+func Read[T any](r io.ReaderAt, size int64, options ...parquet.ReaderOption) (rows []T, err error) {
+	var model T
+	t := reflect.TypeOf(&model).Elem()
+	if !shadow.Needs(t) {
+		return parquet.Read[T](r, size, options...)
+	}
+	file, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return nil, err
+	}
+	reader := parquet.NewReader(file, append(options, SchemaOf(&model))...)
+	defer reader.Close()
+
+	rows = make([]T, 0, file.NumRows())
+	row := reflect.New(mapper.TypeOf(t))
+	for {
+		row.Elem().SetZero()
+		if err := reader.Read(row.Interface()); err == io.EOF {
+			return rows, nil
+		} else if err != nil {
+			return rows, err
+		}
+		var v T
+		mapper.From(reflect.ValueOf(&v).Elem(), row.Elem())
+		rows = append(rows, v)
+	}
+}
+
+var mapper = &shadow.Mapper{
+	Tag: func(tag reflect.StructTag) reflect.StructTag {
+		return shadow.AddTagOption(tag, "parquet", "optional")
+	},
+}
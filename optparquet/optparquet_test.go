@@ -0,0 +1,77 @@
+package optparquet
+
+import (
+	"bytes"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string            `parquet:"city"`
+	Zip  opt.Option[int32] `parquet:"zip"`
+}
+
+type Row struct {
+	ID       int64               `parquet:"id"`
+	Name     opt.Option[string]  `parquet:"name"`
+	Score    opt.Option[float64] `parquet:"score,snappy"`
+	Address  opt.Option[Address] `parquet:"address"`
+	Tags     []string            `parquet:"tags,list"`
+	internal string
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema := SchemaOf(Row{})
+	require.Equal(t, "Row", schema.Name())
+
+	for _, path := range [][]string{{"name"}, {"score"}, {"address", "zip"}} {
+		col, ok := schema.Lookup(path...)
+		require.True(t, ok, path)
+		require.True(t, col.Node.Optional(), path)
+	}
+	col, ok := schema.Lookup("id")
+	require.True(t, ok)
+	require.True(t, col.Node.Required())
+	require.True(t, schema.Fields()[3].Optional())
+}
+
+func TestRoundTrip(t *testing.T) {
+	rows := []Row{
+		{ID: 1},
+		{
+			ID:      2,
+			Name:    opt.Some(""),
+			Score:   opt.Some(1.5),
+			Address: opt.Some(Address{City: "x", Zip: opt.Some(int32(12345))}),
+			Tags:    []string{"a"},
+		},
+		{ID: 3, Address: opt.Some(Address{City: "y"})},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, rows))
+
+	out, err := Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, out, 3)
+	require.True(t, out[0].Name.None())
+	require.True(t, out[0].Score.None())
+	require.True(t, out[0].Address.None())
+	require.Equal(t, opt.Some(""), out[1].Name)
+	require.Equal(t, opt.Some(1.5), out[1].Score)
+	require.Equal(t, opt.Some(Address{City: "x", Zip: opt.Some(int32(12345))}), out[1].Address)
+	require.Equal(t, []string{"a"}, out[1].Tags)
+	require.Equal(t, opt.Some(Address{City: "y"}), out[2].Address)
+}
+
+func TestPlainStructs(t *testing.T) {
+	type Plain struct {
+		ID int64 `parquet:"id"`
+	}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, []Plain{{ID: 1}}))
+	out, err := Read[Plain](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, []Plain{{ID: 1}}, out)
+}
@@ -0,0 +1,109 @@
+package optpb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// FieldMask returns a field mask with a path for exactly the Option fields of
+// patch that are Some, for building the update_mask of an Update request.
+// patch must be a struct or a pointer to one. Fields of nested structs are
+// reported with dotted paths, while an Option holding a struct is reported as
+// a single path.
+//
+// The path of a field is the name from its protobuf struct tag if it has one,
+// or else its Go name in snake_case. pathMapping overrides this: its keys are
+// Go field names (dotted for nested fields) and its values are the paths to
+// use instead, where an empty path leaves the field out of the mask. It is an
+// error for a key of pathMapping not to name a field of patch.
+func FieldMask(patch any, pathMapping ...map[string]string) (*fieldmaskpb.FieldMask, error) {
+	rv := reflect.ValueOf(patch)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("optpb: FieldMask of a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("optpb: FieldMask of %T, which is not a struct", patch)
+	}
+	mapping := make(map[string]string)
+	for _, m := range pathMapping {
+		for k, v := range m {
+			mapping[k] = v
+		}
+	}
+	fm := &fieldmaskpb.FieldMask{}
+	seen := make(map[string]bool, len(mapping))
+	walkFieldMask(rv, "", "", mapping, seen, &fm.Paths)
+	for k := range mapping {
+		if !seen[k] {
+			return nil, fmt.Errorf("optpb: path mapping refers to unknown field %q of %s", k, rv.Type())
+		}
+	}
+	return fm, nil
+}
+
+func walkFieldMask(rv reflect.Value, goPrefix, pathPrefix string, mapping map[string]string, seen map[string]bool, paths *[]string) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		goName := goPrefix + sf.Name
+		path, mapped := mapping[goName]
+		if mapped {
+			seen[goName] = true
+		} else {
+			path = pathPrefix + protoName(sf)
+		}
+		fv := rv.Field(i)
+		if _, ok := optreflect.Elem(sf.Type); ok {
+			if _, some := optreflect.Get(fv); some && path != "" {
+				*paths = append(*paths, path)
+			}
+			continue
+		}
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && path != "" {
+			walkFieldMask(fv, goName+".", path+".", mapping, seen, paths)
+		}
+	}
+}
+
+// protoName returns the name of the proto field sf corresponds to.
+func protoName(sf reflect.StructField) string {
+	for _, part := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(part, "name="); ok {
+			return name
+		}
+	}
+	return snakeCase(sf.Name)
+}
+
+// snakeCase converts a Go identifier such as "HTTPServerID" to
+// "http_server_id".
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
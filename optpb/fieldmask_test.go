@@ -0,0 +1,82 @@
+package optpb
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldMask(t *testing.T) {
+	type address struct {
+		City opt.Option[string]
+		Zip  opt.Option[string]
+	}
+	type patch struct {
+		ID          string
+		DisplayName opt.Option[string]
+		HTTPPort    opt.Option[int]
+		Email       opt.Option[string] `protobuf:"bytes,3,opt,name=email_address,json=emailAddress,proto3"`
+		Address     address
+		Billing     opt.Option[address]
+		Shipping    *address
+		internal    opt.Option[string]
+	}
+
+	fm, err := FieldMask(&patch{
+		ID:          "u1",
+		DisplayName: opt.Some(""),
+		HTTPPort:    opt.Some(80),
+		Email:       opt.Some("nk@example.com"),
+		Address:     address{City: opt.Some("Austin")},
+		Billing:     opt.Some(address{}),
+		Shipping:    &address{Zip: opt.Some("78701")},
+		internal:    opt.Some("x"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"display_name",
+		"http_port",
+		"email_address",
+		"address.city",
+		"billing",
+		"shipping.zip",
+	}, fm.GetPaths())
+
+	fm, err = FieldMask(patch{})
+	require.NoError(t, err)
+	require.Empty(t, fm.GetPaths())
+
+	fm, err = FieldMask(patch{
+		DisplayName: opt.Some("nk"),
+		HTTPPort:    opt.Some(80),
+		Address:     address{City: opt.Some("Austin"), Zip: opt.Some("78701")},
+	}, map[string]string{
+		"DisplayName": "profile.name",
+		"Address":     "addr",
+	}, map[string]string{
+		"HTTPPort":     "",
+		"Address.City": "locality",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"profile.name", "locality", "addr.zip"}, fm.GetPaths())
+
+	_, err = FieldMask(patch{}, map[string]string{"Nope": "nope"})
+	require.Error(t, err)
+	_, err = FieldMask((*patch)(nil))
+	require.Error(t, err)
+	_, err = FieldMask(5)
+	require.Error(t, err)
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"Name":         "name",
+		"DisplayName":  "display_name",
+		"HTTPServerID": "http_server_id",
+		"UserID":       "user_id",
+		"V2Name":       "v2_name",
+	} {
+		require.Equal(t, want, snakeCase(in), in)
+	}
+}
@@ -0,0 +1,180 @@
+// Package optpb converts between opt.Option and the types of
+// google.golang.org/protobuf, such as the well-known wrapper types that proto3
+// APIs use for optional scalar fields.
+package optpb
+
+import (
+	"code.nkcmr.net/opt"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// FromWrapperDouble converts a *wrapperspb.DoubleValue to an Option[float64]. A
+// nil wrapper becomes None.
+func FromWrapperDouble(w *wrapperspb.DoubleValue) opt.Option[float64] {
+	if w == nil {
+		return opt.None[float64]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperDouble converts an Option[float64] to a *wrapperspb.DoubleValue, which
+// is nil if the Option is None.
+func ToWrapperDouble(o opt.Option[float64]) *wrapperspb.DoubleValue {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Double(v)
+}
+
+// FromWrapperFloat converts a *wrapperspb.FloatValue to an Option[float32]. A
+// nil wrapper becomes None.
+func FromWrapperFloat(w *wrapperspb.FloatValue) opt.Option[float32] {
+	if w == nil {
+		return opt.None[float32]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperFloat converts an Option[float32] to a *wrapperspb.FloatValue, which
+// is nil if the Option is None.
+func ToWrapperFloat(o opt.Option[float32]) *wrapperspb.FloatValue {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Float(v)
+}
+
+// FromWrapperInt64 converts a *wrapperspb.Int64Value to an Option[int64]. A
+// nil wrapper becomes None.
+func FromWrapperInt64(w *wrapperspb.Int64Value) opt.Option[int64] {
+	if w == nil {
+		return opt.None[int64]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperInt64 converts an Option[int64] to a *wrapperspb.Int64Value, which
+// is nil if the Option is None.
+func ToWrapperInt64(o opt.Option[int64]) *wrapperspb.Int64Value {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Int64(v)
+}
+
+// FromWrapperUInt64 converts a *wrapperspb.UInt64Value to an Option[uint64]. A
+// nil wrapper becomes None.
+func FromWrapperUInt64(w *wrapperspb.UInt64Value) opt.Option[uint64] {
+	if w == nil {
+		return opt.None[uint64]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperUInt64 converts an Option[uint64] to a *wrapperspb.UInt64Value, which
+// is nil if the Option is None.
+func ToWrapperUInt64(o opt.Option[uint64]) *wrapperspb.UInt64Value {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.UInt64(v)
+}
+
+// FromWrapperInt32 converts a *wrapperspb.Int32Value to an Option[int32]. A
+// nil wrapper becomes None.
+func FromWrapperInt32(w *wrapperspb.Int32Value) opt.Option[int32] {
+	if w == nil {
+		return opt.None[int32]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperInt32 converts an Option[int32] to a *wrapperspb.Int32Value, which
+// is nil if the Option is None.
+func ToWrapperInt32(o opt.Option[int32]) *wrapperspb.Int32Value {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Int32(v)
+}
+
+// FromWrapperUInt32 converts a *wrapperspb.UInt32Value to an Option[uint32]. A
+// nil wrapper becomes None.
+func FromWrapperUInt32(w *wrapperspb.UInt32Value) opt.Option[uint32] {
+	if w == nil {
+		return opt.None[uint32]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperUInt32 converts an Option[uint32] to a *wrapperspb.UInt32Value, which
+// is nil if the Option is None.
+func ToWrapperUInt32(o opt.Option[uint32]) *wrapperspb.UInt32Value {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.UInt32(v)
+}
+
+// FromWrapperBool converts a *wrapperspb.BoolValue to an Option[bool]. A
+// nil wrapper becomes None.
+func FromWrapperBool(w *wrapperspb.BoolValue) opt.Option[bool] {
+	if w == nil {
+		return opt.None[bool]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperBool converts an Option[bool] to a *wrapperspb.BoolValue, which
+// is nil if the Option is None.
+func ToWrapperBool(o opt.Option[bool]) *wrapperspb.BoolValue {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Bool(v)
+}
+
+// FromWrapperString converts a *wrapperspb.StringValue to an Option[string]. A
+// nil wrapper becomes None.
+func FromWrapperString(w *wrapperspb.StringValue) opt.Option[string] {
+	if w == nil {
+		return opt.None[string]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperString converts an Option[string] to a *wrapperspb.StringValue, which
+// is nil if the Option is None.
+func ToWrapperString(o opt.Option[string]) *wrapperspb.StringValue {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.String(v)
+}
+
+// FromWrapperBytes converts a *wrapperspb.BytesValue to an Option[[]byte]. A
+// nil wrapper becomes None.
+func FromWrapperBytes(w *wrapperspb.BytesValue) opt.Option[[]byte] {
+	if w == nil {
+		return opt.None[[]byte]()
+	}
+	return opt.Some(w.GetValue())
+}
+
+// ToWrapperBytes converts an Option[[]byte] to a *wrapperspb.BytesValue, which
+// is nil if the Option is None.
+func ToWrapperBytes(o opt.Option[[]byte]) *wrapperspb.BytesValue {
+	v, ok := o.MaybeUnwrap()
+	if !ok {
+		return nil
+	}
+	return wrapperspb.Bytes(v)
+}
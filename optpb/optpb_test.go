@@ -0,0 +1,52 @@
+package optpb
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWrappers(t *testing.T) {
+	require.True(t, proto.Equal(wrapperspb.Double(1.5), ToWrapperDouble(opt.Some(1.5))))
+	require.True(t, proto.Equal(wrapperspb.Float(1.5), ToWrapperFloat(opt.Some(float32(1.5)))))
+	require.True(t, proto.Equal(wrapperspb.Int64(-2), ToWrapperInt64(opt.Some(int64(-2)))))
+	require.True(t, proto.Equal(wrapperspb.UInt64(2), ToWrapperUInt64(opt.Some(uint64(2)))))
+	require.True(t, proto.Equal(wrapperspb.Int32(-3), ToWrapperInt32(opt.Some(int32(-3)))))
+	require.True(t, proto.Equal(wrapperspb.UInt32(3), ToWrapperUInt32(opt.Some(uint32(3)))))
+	require.True(t, proto.Equal(wrapperspb.Bool(false), ToWrapperBool(opt.Some(false))))
+	require.True(t, proto.Equal(wrapperspb.String(""), ToWrapperString(opt.Some(""))))
+	require.True(t, proto.Equal(wrapperspb.Bytes([]byte("x")), ToWrapperBytes(opt.Some([]byte("x")))))
+
+	require.Nil(t, ToWrapperDouble(opt.None[float64]()))
+	require.Nil(t, ToWrapperFloat(opt.None[float32]()))
+	require.Nil(t, ToWrapperInt64(opt.None[int64]()))
+	require.Nil(t, ToWrapperUInt64(opt.None[uint64]()))
+	require.Nil(t, ToWrapperInt32(opt.None[int32]()))
+	require.Nil(t, ToWrapperUInt32(opt.None[uint32]()))
+	require.Nil(t, ToWrapperBool(opt.None[bool]()))
+	require.Nil(t, ToWrapperString(opt.None[string]()))
+	require.Nil(t, ToWrapperBytes(opt.None[[]byte]()))
+
+	require.Equal(t, opt.Some(1.5), FromWrapperDouble(wrapperspb.Double(1.5)))
+	require.Equal(t, opt.Some(float32(1.5)), FromWrapperFloat(wrapperspb.Float(1.5)))
+	require.Equal(t, opt.Some(int64(-2)), FromWrapperInt64(wrapperspb.Int64(-2)))
+	require.Equal(t, opt.Some(uint64(2)), FromWrapperUInt64(wrapperspb.UInt64(2)))
+	require.Equal(t, opt.Some(int32(-3)), FromWrapperInt32(wrapperspb.Int32(-3)))
+	require.Equal(t, opt.Some(uint32(3)), FromWrapperUInt32(wrapperspb.UInt32(3)))
+	require.Equal(t, opt.Some(false), FromWrapperBool(wrapperspb.Bool(false)))
+	require.Equal(t, opt.Some(""), FromWrapperString(wrapperspb.String("")))
+	require.Equal(t, opt.Some([]byte("x")), FromWrapperBytes(wrapperspb.Bytes([]byte("x"))))
+
+	require.True(t, FromWrapperDouble(nil).None())
+	require.True(t, FromWrapperFloat(nil).None())
+	require.True(t, FromWrapperInt64(nil).None())
+	require.True(t, FromWrapperUInt64(nil).None())
+	require.True(t, FromWrapperInt32(nil).None())
+	require.True(t, FromWrapperUInt32(nil).None())
+	require.True(t, FromWrapperBool(nil).None())
+	require.True(t, FromWrapperString(nil).None())
+	require.True(t, FromWrapperBytes(nil).None())
+}
@@ -0,0 +1,150 @@
+// Package optpgx teaches github.com/jackc/pgx/v5 to encode and scan
+// opt.Option values natively: None binds as NULL, NULL scans as None, and a
+// Some value is handled exactly like the value it contains. This also covers
+// arrays of Options, e.g. []opt.Option[int64] for a bigint[] with NULLs.
+//
+// Register is typically called for every new connection:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		optpgx.Register(conn.TypeMap())
+//		return nil
+//	}
+//
+// Option already implements sql.Scanner and driver.Valuer, which pgx falls
+// back to, but those round-trip through database/sql's limited set of types
+// and cannot represent things like arrays or JSON documents.
+package optpgx
+
+import (
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// firstNormalObjectID is the lowest OID PostgreSQL assigns to user defined
+// objects; every built in data type has a lower OID.
+const firstNormalObjectID = 16384
+
+// Register wraps the codec of every built in data type, as well as the given
+// extra types (such as enums or composites loaded with Conn.LoadType), so that
+// m can encode and scan Options. Calling it again on the same m, for instance
+// to add extra types, leaves what is already registered as it is.
+func Register(m *pgtype.Map, extra ...*pgtype.Type) {
+	for oid := uint32(0); oid < firstNormalObjectID; oid++ {
+		if t, ok := m.TypeForOID(oid); ok {
+			m.RegisterType(Wrap(t))
+		}
+	}
+	for _, t := range extra {
+		m.RegisterType(Wrap(t))
+	}
+	if !hasTryWrapOptionEncodePlan(m) {
+		m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapOptionEncodePlan}, m.TryWrapEncodePlanFuncs...)
+	}
+}
+
+func hasTryWrapOptionEncodePlan(m *pgtype.Map) bool {
+	want := reflect.ValueOf(TryWrapOptionEncodePlan).Pointer()
+	for _, fn := range m.TryWrapEncodePlanFuncs {
+		if reflect.ValueOf(fn).Pointer() == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Wrap returns a copy of t whose codec also handles Options.
+func Wrap(t *pgtype.Type) *pgtype.Type {
+	if _, ok := t.Codec.(*codec); ok {
+		return t
+	}
+	return &pgtype.Type{Name: t.Name, OID: t.OID, Codec: &codec{Codec: t.Codec}}
+}
+
+// TryWrapOptionEncodePlan is a pgtype.TryWrapEncodePlanFunc that encodes an
+// Option as the value it contains. It lets Options be encoded when the OID of
+// the parameter is unknown, as with the simple protocol. Register installs it.
+func TryWrapOptionEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	if value == nil {
+		return nil, nil, false
+	}
+	if _, ok := optreflect.Elem(reflect.TypeOf(value)); !ok {
+		return nil, nil, false
+	}
+	inner, _ := optreflect.Get(reflect.ValueOf(value))
+	return &wrapEncodePlan{}, inner.Interface(), true
+}
+
+type wrapEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p *wrapEncodePlan) SetNext(next pgtype.EncodePlan) {
+	p.next = next
+}
+
+func (p *wrapEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	inner, ok := optreflect.Get(reflect.ValueOf(value))
+	if !ok {
+		return nil, nil
+	}
+	return p.next.Encode(inner.Interface(), buf)
+}
+
+// codec intercepts Options before they reach the wrapped codec, which would
+// otherwise see them as an opaque struct or a sql.Scanner.
+type codec struct {
+	pgtype.Codec
+}
+
+func (c *codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	if value != nil {
+		if _, ok := optreflect.Elem(reflect.TypeOf(value)); ok {
+			return &encodePlan{m: m, oid: oid, format: format}
+		}
+	}
+	return c.Codec.PlanEncode(m, oid, format, value)
+}
+
+func (c *codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if t := reflect.TypeOf(target); t != nil && t.Kind() == reflect.Pointer {
+		if elem, ok := optreflect.Elem(t.Elem()); ok {
+			return &scanPlan{elem: elem, next: m.PlanScan(oid, format, reflect.New(elem).Interface())}
+		}
+	}
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+type encodePlan struct {
+	m      *pgtype.Map
+	oid    uint32
+	format int16
+}
+
+func (p *encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	inner, ok := optreflect.Get(reflect.ValueOf(value))
+	if !ok {
+		return nil, nil
+	}
+	return p.m.Encode(p.oid, p.format, inner.Interface(), buf)
+}
+
+type scanPlan struct {
+	elem reflect.Type
+	next pgtype.ScanPlan
+}
+
+func (p *scanPlan) Scan(src []byte, target any) error {
+	ptr := reflect.ValueOf(target)
+	if src == nil {
+		optreflect.Set(ptr, reflect.Value{})
+		return nil
+	}
+	v := reflect.New(p.elem)
+	if err := p.next.Scan(src, v.Interface()); err != nil {
+		return err
+	}
+	optreflect.Set(ptr, v.Elem())
+	return nil
+}
@@ -0,0 +1,98 @@
+package optpgx
+
+import (
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+func TestEncode(t *testing.T) {
+	m := newMap()
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.Int8OID, format, opt.None[int64](), nil)
+		require.NoError(t, err)
+		require.Nil(t, buf)
+
+		want, err := m.Encode(pgtype.Int8OID, format, int64(5), nil)
+		require.NoError(t, err)
+		got, err := m.Encode(pgtype.Int8OID, format, opt.Some(int64(5)), nil)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	buf, err := m.Encode(pgtype.JSONBOID, pgtype.TextFormatCode, opt.None[map[string]int](), nil)
+	require.NoError(t, err)
+	require.Nil(t, buf, "None must be NULL, not the JSON document null")
+
+	buf, err = m.Encode(0, pgtype.TextFormatCode, opt.Some("x"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("x"), buf)
+}
+
+func TestScan(t *testing.T) {
+	m := newMap()
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		src, err := m.Encode(pgtype.Int8OID, format, int64(5), nil)
+		require.NoError(t, err)
+
+		var o opt.Option[int64]
+		require.NoError(t, m.Scan(pgtype.Int8OID, format, src, &o))
+		require.Equal(t, opt.Some(int64(5)), o)
+
+		require.NoError(t, m.Scan(pgtype.Int8OID, format, nil, &o))
+		require.True(t, o.None())
+	}
+
+	var doc opt.Option[map[string]int]
+	require.NoError(t, m.Scan(pgtype.JSONBOID, pgtype.TextFormatCode, []byte(`{"a":1}`), &doc))
+	require.Equal(t, opt.Some(map[string]int{"a": 1}), doc)
+
+	var i opt.Option[int64]
+	require.Error(t, m.Scan(pgtype.TextOID, pgtype.TextFormatCode, []byte("nope"), &i))
+}
+
+func TestArrays(t *testing.T) {
+	m := newMap()
+	in := []opt.Option[int64]{opt.Some(int64(1)), opt.None[int64](), opt.Some(int64(3))}
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.Int8ArrayOID, format, in, nil)
+		require.NoError(t, err)
+
+		var out []opt.Option[int64]
+		require.NoError(t, m.Scan(pgtype.Int8ArrayOID, format, buf, &out))
+		require.Equal(t, in, out)
+	}
+}
+
+func TestRegisterIsIdempotent(t *testing.T) {
+	m := newMap()
+	funcs := len(m.TryWrapEncodePlanFuncs)
+	Register(m)
+	Register(m)
+	require.Len(t, m.TryWrapEncodePlanFuncs, funcs)
+	typ, ok := m.TypeForOID(pgtype.Int8OID)
+	require.True(t, ok)
+	require.Same(t, typ, Wrap(typ))
+	_, ok = typ.Codec.(*codec).Codec.(*codec)
+	require.False(t, ok)
+}
+
+func TestRegisterTwiceStillEncodes(t *testing.T) {
+	m := newMap()
+	Register(m)
+	buf, err := m.Encode(pgtype.Int8OID, pgtype.TextFormatCode, opt.Some(int64(7)), nil)
+	require.NoError(t, err)
+	require.Equal(t, "7", string(buf))
+
+	var out opt.Option[int64]
+	require.NoError(t, m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, buf, &out))
+	require.Equal(t, opt.Some(int64(7)), out)
+}
@@ -0,0 +1,233 @@
+// Package optquery encodes structs with opt.Option fields into url.Values,
+// and decodes them back, in the style of github.com/google/go-querystring.
+//
+// None means "not given": a None field is left out of the query entirely,
+// while a Some field is encoded even if it holds a zero value. That is what
+// API clients need when an absent parameter means "don't filter":
+//
+//	type ListOptions struct {
+//		State  opt.Option[string] `url:"state"`
+//		Closed opt.Option[bool]   `url:"closed"`
+//		Labels []string           `url:"label,omitempty"`
+//	}
+//
+//	q, err := optquery.Encode(ListOptions{Closed: opt.Some(false)})
+//	// q.Encode() == "closed=false"
+//
+// Fields are named by their `url` tag, or by their Go name if they have none,
+// and tagged "-" to be skipped. Fields that are not Options are always
+// encoded, unless tagged "omitempty" and zero. Slices are encoded as repeated
+// keys. Fields of struct types without a MarshalText method are encoded as
+// keys of the form "parent[child]", except for embedded structs whose fields
+// are encoded as if they were those of the outer struct.
+//
+// Values are formatted with their MarshalText method if they have one,
+// time.Duration.String for durations, and according to their kind otherwise,
+// and parsed back the same way.
+package optquery
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+	"code.nkcmr.net/opt/internal/textparse"
+)
+
+// Encode encodes the struct v, or the struct v points to, as url.Values.
+func Encode(v any) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("optquery: cannot encode %T, it is not a struct", v)
+	}
+	values := url.Values{}
+	if err := encodeStruct(values, "", rv); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func encodeStruct(values url.Values, prefix string, rv reflect.Value) error {
+	for _, f := range fields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		key := f.key(prefix)
+		if _, ok := optreflect.Elem(fv.Type()); ok {
+			x, ok := optreflect.Get(fv)
+			if !ok {
+				continue
+			}
+			fv = x
+		} else if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if f.nested {
+			if err := encodeStruct(values, key, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := encodeValue(values, key, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeValue(values url.Values, key string, rv reflect.Value) error {
+	if rv.Kind() == reflect.Slice && !isText(rv.Type()) {
+		for i := 0; i < rv.Len(); i++ {
+			if err := encodeValue(values, key, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	s, err := textparse.Format(rv)
+	if err != nil {
+		return fmt.Errorf("optquery: %s: %w", key, err)
+	}
+	values.Add(key, s)
+	return nil
+}
+
+// Decode sets the fields of the struct dst points to from values, the reverse
+// of Encode. An Option field is None if its key is not in values, and Some if
+// it is, even with an empty value; an Option of a struct is Some if any of its
+// keys are. Any other field is left as it is if its key is not in values.
+func Decode(values url.Values, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optquery: cannot decode into %T, it is not a pointer to a struct", dst)
+	}
+	_, err := decodeStruct(values, "", rv.Elem())
+	return err
+}
+
+// decodeStruct decodes the fields of the struct rv and reports whether any of
+// their keys were in values.
+func decodeStruct(values url.Values, prefix string, rv reflect.Value) (found bool, err error) {
+	for _, f := range fields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index)
+		key := f.key(prefix)
+		elem, isOption := optreflect.Elem(fv.Type())
+		if !isOption {
+			ok, err := decodeValue(values, key, fv, f.nested)
+			if err != nil {
+				return false, err
+			}
+			found = found || ok
+			continue
+		}
+		x := reflect.New(elem).Elem()
+		ok, err := decodeValue(values, key, x, f.nested)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			x = reflect.Value{}
+		}
+		optreflect.Set(fv.Addr(), x)
+		found = found || ok
+	}
+	return found, nil
+}
+
+func decodeValue(values url.Values, key string, rv reflect.Value, nested bool) (bool, error) {
+	if nested {
+		return decodeStruct(values, key, rv)
+	}
+	vs, ok := values[key]
+	if !ok {
+		return false, nil
+	}
+	if rv.Kind() == reflect.Slice && !isText(rv.Type()) {
+		s := reflect.MakeSlice(rv.Type(), len(vs), len(vs))
+		for i, v := range vs {
+			if err := textparse.Set(s.Index(i), v); err != nil {
+				return false, fmt.Errorf("optquery: %s: %w", key, err)
+			}
+		}
+		rv.Set(s)
+		return true, nil
+	}
+	var v string
+	if len(vs) > 0 {
+		v = vs[0]
+	}
+	if err := textparse.Set(rv, v); err != nil {
+		return false, fmt.Errorf("optquery: %s: %w", key, err)
+	}
+	return true, nil
+}
+
+type field struct {
+	index     []int
+	name      string
+	omitEmpty bool
+	// nested is set for fields of struct types, or Options of them, whose
+	// own fields are encoded.
+	nested bool
+}
+
+func (f field) key(prefix string) string {
+	if prefix == "" {
+		return f.name
+	}
+	return prefix + "[" + f.name + "]"
+}
+
+// fields lists the fields of the struct type t, flattening embedded structs.
+func fields(t reflect.Type) []field {
+	var out []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, tagged := sf.Tag.Lookup("url")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if sf.Anonymous && !tagged && isNested(sf.Type) {
+			for _, f := range fields(sf.Type) {
+				f.index = append([]int{i}, f.index...)
+				out = append(out, f)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		out = append(out, field{
+			index:     []int{i},
+			name:      name,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			nested:    isNested(sf.Type),
+		})
+	}
+	return out
+}
+
+var textUnmarshalerType = reflect.TypeFor[interface{ UnmarshalText([]byte) error }]()
+
+func isText(t reflect.Type) bool {
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// isNested reports whether fields of type t, looking through Options, are
+// encoded field by field.
+func isNested(t reflect.Type) bool {
+	if elem, ok := optreflect.Elem(t); ok {
+		t = elem
+	}
+	return t.Kind() == reflect.Struct && !isText(t)
+}
@@ -0,0 +1,109 @@
+package optquery
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type Paging struct {
+	Page    opt.Option[int] `url:"page"`
+	PerPage opt.Option[int] `url:"per_page"`
+}
+
+type Range struct {
+	From opt.Option[time.Time] `url:"from"`
+	To   opt.Option[time.Time] `url:"to"`
+}
+
+type ListOptions struct {
+	Paging
+	State   opt.Option[string]        `url:"state"`
+	Closed  opt.Option[bool]          `url:"closed"`
+	Labels  []string                  `url:"label,omitempty"`
+	IDs     opt.Option[[]int]         `url:"id"`
+	Sort    string                    `url:"sort,omitempty"`
+	Wait    opt.Option[time.Duration] `url:"wait"`
+	Created opt.Option[Range]         `url:"created"`
+	Updated Range                     `url:"updated"`
+	Limit   int
+	Secret  string `url:"-"`
+}
+
+var when = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func TestEncode(t *testing.T) {
+	q, err := Encode(ListOptions{})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{"Limit": {"0"}}, q)
+
+	q, err = Encode(&ListOptions{
+		Paging:  Paging{Page: opt.Some(2)},
+		State:   opt.Some(""),
+		Closed:  opt.Some(false),
+		Labels:  []string{"a", "b"},
+		IDs:     opt.Some([]int{1, 2}),
+		Wait:    opt.Some(time.Second),
+		Created: opt.Some(Range{From: opt.Some(when)}),
+		Limit:   10,
+		Secret:  "x",
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"page":          {"2"},
+		"state":         {""},
+		"closed":        {"false"},
+		"label":         {"a", "b"},
+		"id":            {"1", "2"},
+		"wait":          {"1s"},
+		"created[from]": {"2024-03-01T00:00:00Z"},
+		"Limit":         {"10"},
+	}, q)
+
+	q, err = Encode((*ListOptions)(nil))
+	require.NoError(t, err)
+	require.Empty(t, q)
+
+	_, err = Encode(1)
+	require.Error(t, err)
+}
+
+func TestDecode(t *testing.T) {
+	q, err := url.ParseQuery("page=2&state=&closed=false&label=a&label=b&id=1&id=2&wait=1s&created[from]=2024-03-01T00:00:00Z&updated[to]=2024-03-01T00:00:00Z")
+	require.NoError(t, err)
+
+	opts := ListOptions{Paging: Paging{PerPage: opt.Some(5)}, Sort: "name", Limit: 10}
+	require.NoError(t, Decode(q, &opts))
+	require.Equal(t, ListOptions{
+		Paging:  Paging{Page: opt.Some(2)},
+		State:   opt.Some(""),
+		Closed:  opt.Some(false),
+		Labels:  []string{"a", "b"},
+		IDs:     opt.Some([]int{1, 2}),
+		Sort:    "name",
+		Wait:    opt.Some(time.Second),
+		Created: opt.Some(Range{From: opt.Some(when)}),
+		Updated: Range{To: opt.Some(when)},
+		Limit:   10,
+	}, opts)
+
+	require.ErrorContains(t, Decode(url.Values{"page": {"two"}}, &opts), "optquery: page: ")
+	require.Error(t, Decode(q, opts))
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := ListOptions{
+		Paging:  Paging{PerPage: opt.Some(0)},
+		State:   opt.Some("open"),
+		Created: opt.Some(Range{To: opt.Some(when)}),
+		Limit:   3,
+	}
+	q, err := Encode(in)
+	require.NoError(t, err)
+	var out ListOptions
+	require.NoError(t, Decode(q, &out))
+	require.Equal(t, in, out)
+}
@@ -0,0 +1,93 @@
+// Package optquick generates opt.Option values for testing/quick, which
+// cannot generate them by itself because they have unexported fields.
+//
+// Option wraps an opt.Option and implements quick.Generator, so functions
+// checked by quick.Check can take it as an argument, or have it in the
+// fields of a struct argument, without any configuration:
+//
+//	f := func(o optquick.Option[int]) bool {
+//		return opt.FromPointer(opt.ToPointer(o.Option)) == o.Option
+//	}
+//	err := quick.Check(f, nil)
+//
+// For functions that take opt.Option itself, Values builds the Values
+// function of a quick.Config, generating Options that are None with the
+// given probability:
+//
+//	f := func(o opt.Option[int]) bool {
+//		return opt.FromPointer(opt.ToPointer(o)) == o
+//	}
+//	err := quick.Check(f, &quick.Config{Values: optquick.Values(f, 0.25)})
+package optquick
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// NoneProbability is the probability with which Option generates None.
+const NoneProbability = 0.25
+
+// Option is an opt.Option that implements quick.Generator. All the methods of
+// opt.Option are promoted to it.
+type Option[T any] struct {
+	opt.Option[T]
+}
+
+var _ quick.Generator = Option[int]{}
+
+// Generate implements quick.Generator. It returns None with probability
+// NoneProbability, and Some of a value generated by Value otherwise. It
+// panics if values of T cannot be generated.
+func (Option[T]) Generate(r *rand.Rand, size int) reflect.Value {
+	t := reflect.TypeFor[opt.Option[T]]()
+	v, ok := Value(t, r, NoneProbability)
+	if !ok {
+		panic(fmt.Sprintf("optquick: cannot generate values of type %s", t))
+	}
+	return reflect.ValueOf(Option[T]{v.Interface().(opt.Option[T])})
+}
+
+// Value is like quick.Value, but also generates Options. An Option is None
+// with probability noneProbability, and Some of a value generated by Value
+// otherwise.
+func Value(t reflect.Type, r *rand.Rand, noneProbability float64) (reflect.Value, bool) {
+	elem, ok := optreflect.Elem(t)
+	if !ok {
+		return quick.Value(t, r)
+	}
+	v := reflect.New(t).Elem()
+	if r.Float64() < noneProbability {
+		return v, true
+	}
+	x, ok := Value(elem, r, noneProbability)
+	if !ok {
+		return reflect.Value{}, false
+	}
+	optreflect.Set(v.Addr(), x)
+	return v, true
+}
+
+// Values returns a function for the Values field of a quick.Config that
+// generates the arguments of the function f with Value. It panics if f is not
+// a function, or if one of its arguments cannot be generated.
+func Values(f any, noneProbability float64) func([]reflect.Value, *rand.Rand) {
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		panic(fmt.Sprintf("optquick: Values of %T, which is not a function", f))
+	}
+	return func(args []reflect.Value, r *rand.Rand) {
+		for i := range args {
+			v, ok := Value(ft.In(i), r, noneProbability)
+			if !ok {
+				panic(fmt.Sprintf("optquick: cannot generate values of type %s", ft.In(i)))
+			}
+			args[i] = v
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package optquick
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValues(t *testing.T) {
+	var some, none int
+	f := func(o opt.Option[int], s opt.Option[[]string]) bool {
+		if o.Some() {
+			some++
+		} else {
+			none++
+		}
+		return opt.FromPointer(opt.ToPointer(o)) == o
+	}
+	require.NoError(t, quick.Check(f, &quick.Config{MaxCount: 1000, Values: Values(f, 0.25)}))
+	require.Greater(t, some, 600)
+	require.Greater(t, none, 150)
+}
+
+func TestCheckEqual(t *testing.T) {
+	f := func(o opt.Option[string]) string { return o.UnwrapOr("default") }
+	g := func(o opt.Option[string]) string {
+		if v, ok := o.MaybeUnwrap(); ok {
+			return v
+		}
+		return "default"
+	}
+	require.NoError(t, quick.CheckEqual(f, g, &quick.Config{Values: Values(f, 0.25)}))
+}
+
+func TestNoneProbability(t *testing.T) {
+	none := func(o opt.Option[float64]) bool { return o.None() }
+	require.NoError(t, quick.Check(none, &quick.Config{Values: Values(none, 1)}))
+	some := func(o opt.Option[float64]) bool { return o.Some() }
+	require.NoError(t, quick.Check(some, &quick.Config{Values: Values(some, 0)}))
+}
+
+func TestValue(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	v, ok := Value(reflect.TypeFor[opt.Option[opt.Option[int]]](), r, 0)
+	require.True(t, ok)
+	require.True(t, v.Interface().(opt.Option[opt.Option[int]]).Unwrap().Some())
+
+	v, ok = Value(reflect.TypeFor[opt.Boxed[string]](), r, 1)
+	require.True(t, ok)
+	require.True(t, v.Interface().(opt.Boxed[string]).None())
+}
+
+func TestUnsupported(t *testing.T) {
+	_, ok := Value(reflect.TypeFor[opt.Option[func()]](), rand.New(rand.NewSource(1)), 0)
+	require.False(t, ok)
+
+	f := func(opt.Option[func()]) bool { return true }
+	require.Panics(t, func() {
+		_ = quick.Check(f, &quick.Config{Values: Values(f, 0.25)})
+	})
+	require.Panics(t, func() { Values(1, 0.25) })
+}
+
+func TestGenerator(t *testing.T) {
+	var some, none int
+	f := func(o Option[int], s Option[[]string]) bool {
+		if o.Some() {
+			some++
+		} else {
+			none++
+		}
+		return opt.FromPointer(opt.ToPointer(o.Option)) == o.Option
+	}
+	require.NoError(t, quick.Check(f, &quick.Config{MaxCount: 1000}))
+	require.Greater(t, some, 600)
+	require.Greater(t, none, 150)
+
+	// Options in the fields of struct arguments are generated as well.
+	type args struct {
+		Name  Option[string]
+		Count int
+	}
+	g := func(a args) bool {
+		return a.Name.UnwrapOr("x") == a.Name.Option.UnwrapOr("x")
+	}
+	require.NoError(t, quick.Check(g, nil))
+
+	require.Panics(t, func() {
+		_ = quick.Check(func(Option[func()]) bool { return true }, nil)
+	})
+}
@@ -0,0 +1,35 @@
+// Package optrapid provides pgregory.net/rapid generators of opt.Option
+// values:
+//
+//	rapid.Check(t, func(t *rapid.T) {
+//		limit := optrapid.Option(rapid.IntRange(1, 100)).Draw(t, "limit")
+//		// ...
+//	})
+//
+// Generated Options shrink towards None.
+package optrapid
+
+import (
+	"code.nkcmr.net/opt"
+	"pgregory.net/rapid"
+)
+
+// Option returns a generator of Options that are None about a quarter of the
+// time and Some of a value drawn from gen otherwise.
+func Option[T any](gen *rapid.Generator[T]) *rapid.Generator[opt.Option[T]] {
+	return OptionOf(gen, 0.25)
+}
+
+// OptionOf returns a generator of Options that are None with roughly the
+// probability noneProbability and Some of a value drawn from gen otherwise.
+// Like other rapid generators, it favors edge cases over an exact
+// distribution.
+func OptionOf[T any](gen *rapid.Generator[T], noneProbability float64) *rapid.Generator[opt.Option[T]] {
+	threshold := int(noneProbability * 1000)
+	return rapid.Custom(func(t *rapid.T) opt.Option[T] {
+		if rapid.IntRange(0, 999).Draw(t, "none") < threshold {
+			return opt.None[T]()
+		}
+		return opt.Some(gen.Draw(t, "some"))
+	})
+}
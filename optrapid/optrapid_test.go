@@ -0,0 +1,36 @@
+package optrapid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func TestOption(t *testing.T) {
+	var some, none int
+	rapid.Check(t, func(t *rapid.T) {
+		o := Option(rapid.IntRange(1, 100)).Draw(t, "o")
+		if v, ok := o.MaybeUnwrap(); ok {
+			some++
+			if v < 1 || v > 100 {
+				t.Fatalf("%d out of range", v)
+			}
+		} else {
+			none++
+		}
+	})
+	require.NotZero(t, some)
+	require.NotZero(t, none)
+}
+
+func TestOptionOf(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		if OptionOf(rapid.String(), 1).Draw(t, "o").Some() {
+			t.Fatal("expected None")
+		}
+		if OptionOf(rapid.String(), 0).Draw(t, "o").None() {
+			t.Fatal("expected Some")
+		}
+	})
+}
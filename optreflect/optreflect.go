@@ -0,0 +1,96 @@
+// Package optreflect works with opt.Option values through package reflect,
+// for code such as binders, patchers and ORMs that only learns the type an
+// Option contains at run time. It is built on opt.AnyOption and
+// opt.AnyOptionSetter, so it works the same way with opt.Boxed and
+// opt.PtrOption.
+//
+//	func reset(v reflect.Value) {
+//		if optreflect.IsOption(v.Type()) {
+//			optreflect.Set(v, reflect.Value{})
+//		}
+//	}
+//
+// Like package reflect, the functions here panic when they are given a value
+// they cannot handle, such as one that is not an Option.
+package optreflect
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/optreflect"
+)
+
+// IsOption reports whether t is an Option type: a struct implementing
+// opt.AnyOption, whose pointer implements opt.AnyOptionSetter, and whose
+// Unwrap method returns the type it contains, as opt.Option, opt.Boxed and
+// opt.PtrOption are.
+func IsOption(t reflect.Type) bool {
+	_, ok := optreflect.Elem(t)
+	return ok
+}
+
+// Elem returns the type contained by the Option type t, and false if t is not
+// an Option type.
+func Elem(t reflect.Type) (reflect.Type, bool) {
+	return optreflect.Elem(t)
+}
+
+// Get returns the value contained by the Option v and whether it is Some. If
+// it is None, the value is the zero value of the contained type. The value
+// returned is a copy, so setting it does not change v.
+func Get(v reflect.Value) (reflect.Value, bool) {
+	mustBeOption("Get", v.Type())
+	x, ok := optreflect.Get(v)
+	y := reflect.New(x.Type()).Elem()
+	y.Set(x)
+	return y, ok
+}
+
+// IsSome reports whether the Option v is Some.
+func IsSome(v reflect.Value) bool {
+	mustBeOption("IsSome", v.Type())
+	_, ok := optreflect.Get(v)
+	return ok
+}
+
+// Set replaces the Option v with Some(x), or with None if x is the zero
+// reflect.Value. v must be addressable and x must be assignable to the type
+// v contains. If that type is an interface type, a nil x sets None, as SetAny
+// does.
+func Set(v reflect.Value, x reflect.Value) {
+	elem := mustBeOption("Set", v.Type())
+	if !v.CanSet() {
+		panic("optreflect.Set: value is not settable")
+	}
+	if x.IsValid() && !x.Type().AssignableTo(elem) {
+		panic(fmt.Sprintf("optreflect.Set: value of type %s is not assignable to %s", x.Type(), elem))
+	}
+	optreflect.Set(v.Addr(), x)
+}
+
+// Some returns a new Option of type t that is Some(x). x must be assignable
+// to the type t contains.
+func Some(t reflect.Type, x reflect.Value) reflect.Value {
+	mustBeOption("Some", t)
+	if !x.IsValid() {
+		panic("optreflect.Some: invalid value")
+	}
+	v := reflect.New(t).Elem()
+	Set(v, x)
+	return v
+}
+
+// None returns a new Option of type t that is None.
+func None(t reflect.Type) reflect.Value {
+	mustBeOption("None", t)
+	return reflect.New(t).Elem()
+}
+
+func mustBeOption(fn string, t reflect.Type) reflect.Type {
+	elem, ok := optreflect.Elem(t)
+	if !ok {
+		panic(fmt.Sprintf("optreflect.%s: %s is not an Option type", fn, t))
+	}
+	return elem
+}
@@ -0,0 +1,107 @@
+package optreflect
+
+import (
+	"reflect"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/stretchr/testify/require"
+)
+
+type config struct {
+	Port opt.Option[int]
+	Name string
+}
+
+func TestElem(t *testing.T) {
+	elem, ok := Elem(reflect.TypeFor[opt.Option[[]string]]())
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeFor[[]string](), elem)
+
+	for _, typ := range []reflect.Type{
+		reflect.TypeFor[int](),
+		reflect.TypeFor[*opt.Option[int]](),
+		reflect.TypeFor[config](),
+	} {
+		_, ok := Elem(typ)
+		require.False(t, ok, typ)
+		require.False(t, IsOption(typ), typ)
+	}
+	require.True(t, IsOption(reflect.TypeFor[opt.Option[config]]()))
+
+	for _, typ := range []reflect.Type{
+		reflect.TypeFor[opt.Boxed[int]](),
+		reflect.TypeFor[opt.PtrOption[int]](),
+	} {
+		elem, ok := Elem(typ)
+		require.True(t, ok, typ)
+		require.Equal(t, reflect.TypeFor[int](), elem)
+	}
+}
+
+func TestBoxed(t *testing.T) {
+	var b opt.Boxed[int]
+	v := reflect.ValueOf(&b).Elem()
+	Set(v, reflect.ValueOf(5))
+	require.Equal(t, opt.NewBoxed(5), b)
+	x, ok := Get(v)
+	require.True(t, ok)
+	require.Equal(t, 5, x.Interface())
+	require.True(t, IsSome(v))
+	Set(v, reflect.Value{})
+	require.True(t, b.None())
+
+	p := Some(reflect.TypeFor[opt.PtrOption[string]](), reflect.ValueOf("a"))
+	require.Equal(t, "a", p.Interface().(opt.PtrOption[string]).Unwrap())
+}
+
+func TestGet(t *testing.T) {
+	x, ok := Get(reflect.ValueOf(opt.Some(5)))
+	require.True(t, ok)
+	require.Equal(t, 5, x.Interface())
+	require.True(t, x.CanSet())
+
+	x, ok = Get(reflect.ValueOf(opt.None[string]()))
+	require.False(t, ok)
+	require.Equal(t, "", x.Interface())
+
+	require.True(t, IsSome(reflect.ValueOf(opt.Some(""))))
+	require.False(t, IsSome(reflect.ValueOf(opt.None[string]())))
+
+	require.PanicsWithValue(t, "optreflect.Get: int is not an Option type", func() {
+		Get(reflect.ValueOf(1))
+	})
+}
+
+func TestSet(t *testing.T) {
+	var c config
+	port := reflect.ValueOf(&c).Elem().Field(0)
+
+	Set(port, reflect.ValueOf(8080))
+	require.Equal(t, opt.Some(8080), c.Port)
+
+	Set(port, reflect.Value{})
+	require.Equal(t, opt.None[int](), c.Port)
+
+	require.PanicsWithValue(t, "optreflect.Set: value of type string is not assignable to int", func() {
+		Set(port, reflect.ValueOf("8080"))
+	})
+	require.PanicsWithValue(t, "optreflect.Set: value is not settable", func() {
+		Set(reflect.ValueOf(c).Field(0), reflect.ValueOf(1))
+	})
+	require.Panics(t, func() {
+		Set(reflect.ValueOf(&c).Elem().Field(1), reflect.ValueOf("x"))
+	})
+}
+
+func TestSomeNone(t *testing.T) {
+	typ := reflect.TypeFor[opt.Option[error]]()
+	require.Equal(t, opt.None[error](), None(typ).Interface())
+
+	var err error = &reflect.ValueError{Method: "x"}
+	require.Equal(t, opt.Some(err), Some(typ, reflect.ValueOf(err)).Interface())
+
+	require.Panics(t, func() { Some(typ, reflect.Value{}) })
+	require.Panics(t, func() { Some(typ, reflect.ValueOf(1)) })
+	require.Panics(t, func() { None(reflect.TypeFor[int]()) })
+}
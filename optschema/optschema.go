@@ -0,0 +1,110 @@
+// Package optschema lets github.com/gorilla/schema decode HTML forms and query
+// strings into opt.Option fields, and encode them back.
+//
+// Option implements encoding.TextUnmarshaler, which is enough for schema to
+// decode it, but then an empty value is decoded as None. Once a converter is
+// registered for an Option type, a key that is absent from the form leaves
+// the field as it is, None for a zero value, and a key that is present makes
+// it Some, even when empty:
+//
+//	decoder := schema.NewDecoder()
+//	optschema.RegisterDefaults(decoder, nil)
+//
+//	var form struct {
+//		Nickname opt.Option[string] `schema:"nickname"`
+//		Age      opt.Option[int]    `schema:"age"`
+//	}
+//	err := decoder.Decode(&form, r.PostForm)
+//
+// An empty value that cannot be parsed, such as that of an empty number input,
+// is decoded as None rather than failing. Slices of Options are not supported.
+package optschema
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/textparse"
+	"github.com/gorilla/schema"
+)
+
+// RegisterConverter registers a converter for Option[T] with d, parsing
+// values with parse. If parse is nil, values are parsed with their
+// UnmarshalText method if they have one, time.ParseDuration for durations,
+// and according to their kind otherwise.
+func RegisterConverter[T any](d *schema.Decoder, parse func(string) (T, error)) {
+	if parse == nil {
+		parse = textparse.Parse[T]
+	}
+	d.RegisterConverter(opt.Option[T]{}, func(s string) reflect.Value {
+		v, err := parse(s)
+		if err != nil {
+			if s == "" {
+				return reflect.ValueOf(opt.None[T]())
+			}
+			// An invalid value makes schema report a ConversionError.
+			return reflect.Value{}
+		}
+		return reflect.ValueOf(opt.Some(v))
+	})
+}
+
+// RegisterEncoder registers an encoder for Option[T] with e, formatting
+// values with format. If format is nil, values are formatted with their
+// MarshalText method if they have one, and with fmt.Sprint otherwise. None is
+// encoded as an empty value, so fields should be tagged "omitempty" to leave
+// them out instead.
+func RegisterEncoder[T any](e *schema.Encoder, format func(T) string) {
+	if format == nil {
+		format = formatText[T]
+	}
+	e.RegisterEncoder(opt.Option[T]{}, func(v reflect.Value) string {
+		if x, ok := v.Interface().(opt.Option[T]).MaybeUnwrap(); ok {
+			return format(x)
+		}
+		return ""
+	})
+}
+
+// RegisterDefaults registers converters with d and encoders with e for
+// Options of strings, booleans, integers, floating-point numbers, durations
+// and times (in RFC 3339 format). Either of d and e may be nil.
+func RegisterDefaults(d *schema.Decoder, e *schema.Encoder) {
+	register[string](d, e)
+	register[bool](d, e)
+	register[int](d, e)
+	register[int8](d, e)
+	register[int16](d, e)
+	register[int32](d, e)
+	register[int64](d, e)
+	register[uint](d, e)
+	register[uint8](d, e)
+	register[uint16](d, e)
+	register[uint32](d, e)
+	register[uint64](d, e)
+	register[float32](d, e)
+	register[float64](d, e)
+	register[time.Duration](d, e)
+	register[time.Time](d, e)
+}
+
+func register[T any](d *schema.Decoder, e *schema.Encoder) {
+	if d != nil {
+		RegisterConverter[T](d, nil)
+	}
+	if e != nil {
+		RegisterEncoder[T](e, nil)
+	}
+}
+
+func formatText[T any](v T) string {
+	if m, ok := any(v).(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,98 @@
+package optschema
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/gorilla/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type form struct {
+	Nickname opt.Option[string]        `schema:"nickname,omitempty"`
+	Bio      opt.Option[string]        `schema:"bio,omitempty"`
+	Age      opt.Option[int]           `schema:"age,omitempty"`
+	Score    opt.Option[float64]       `schema:"score,omitempty"`
+	Admin    opt.Option[bool]          `schema:"admin,omitempty"`
+	Timeout  opt.Option[time.Duration] `schema:"timeout,omitempty"`
+	Born     opt.Option[time.Time]     `schema:"born,omitempty"`
+	Port     opt.Option[int]           `schema:"port,omitempty"`
+}
+
+func newDecoder() *schema.Decoder {
+	d := schema.NewDecoder()
+	RegisterDefaults(d, nil)
+	return d
+}
+
+func TestDecode(t *testing.T) {
+	d := newDecoder()
+	var f form
+	err := d.Decode(&f, url.Values{
+		"nickname": {""},
+		"age":      {""},
+		"score":    {"1.5"},
+		"admin":    {"true"},
+		"timeout":  {"1m"},
+		"born":     {"2024-03-01T00:00:00Z"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, form{
+		Nickname: opt.Some(""),
+		Score:    opt.Some(1.5),
+		Admin:    opt.Some(true),
+		Timeout:  opt.Some(time.Minute),
+		Born:     opt.Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}, f)
+
+	f = form{Bio: opt.Some("kept")}
+	require.NoError(t, d.Decode(&f, url.Values{"age": {"3"}}))
+	require.Equal(t, opt.Some("kept"), f.Bio)
+	require.Equal(t, opt.Some(3), f.Age)
+
+	err = d.Decode(&f, url.Values{"age": {"old"}})
+	require.IsType(t, schema.MultiError{}, err)
+}
+
+func TestRegisterConverter(t *testing.T) {
+	d := schema.NewDecoder()
+	RegisterConverter(d, func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		return n * 2, err
+	})
+	var f struct {
+		Port opt.Option[int] `schema:"port"`
+	}
+	require.NoError(t, d.Decode(&f, url.Values{"port": {"4"}}))
+	require.Equal(t, opt.Some(8), f.Port)
+}
+
+func TestEncode(t *testing.T) {
+	e := schema.NewEncoder()
+	RegisterDefaults(nil, e)
+	RegisterEncoder(e, func(port int) string {
+		return ":" + strconv.Itoa(port)
+	})
+
+	values := url.Values{}
+	err := e.Encode(form{
+		Nickname: opt.Some(""),
+		Age:      opt.Some(3),
+		Timeout:  opt.Some(time.Minute),
+		Born:     opt.Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}, values)
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"nickname": {""},
+		"age":      {":3"},
+		"timeout":  {"1m0s"},
+		"born":     {"2024-03-01T00:00:00Z"},
+	}, values)
+
+	var f form
+	require.NoError(t, newDecoder().Decode(&f, url.Values{"timeout": values["timeout"], "born": values["born"]}))
+	require.Equal(t, opt.Some(time.Minute), f.Timeout)
+}
@@ -0,0 +1,382 @@
+// Package optspanner lets opt.Option be used with Cloud Spanner through
+// cloud.google.com/go/spanner, and converts between Options and the Null types
+// of the Spanner client.
+//
+// Option wraps an opt.Option and implements spanner.Encoder and
+// spanner.Decoder, so it can be used for column values, query parameters and
+// fields of row structs, with None written as NULL and NULL read as None:
+//
+//	type Singer struct {
+//		SingerID  int64
+//		FirstName optspanner.Option[string]
+//		BirthDate optspanner.Option[civil.Date]
+//	}
+//
+//	m, err := spanner.InsertStruct("Singers", singer)
+//	...
+//	err = row.ToStruct(&singer)
+//
+// Encode and Decode do the same for an opt.Option itself, for types of your
+// own that implement those interfaces.
+//
+// Options can hold the types the Spanner client reads and writes: strings,
+// integers, floating-point numbers, booleans, []byte, time.Time, civil.Date
+// and big.Rat, types defined on those, types that implement spanner.Decoder,
+// and slices of any of them for ARRAY columns.
+package optspanner
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"code.nkcmr.net/opt"
+)
+
+// Option is an opt.Option that implements spanner.Encoder and
+// spanner.Decoder. All the methods of opt.Option are promoted to it.
+type Option[T any] struct {
+	opt.Option[T]
+}
+
+var (
+	_ spanner.Encoder = Option[int64]{}
+	_ spanner.Decoder = (*Option[int64])(nil)
+)
+
+// EncodeSpanner implements spanner.Encoder, as Encode does.
+func (o Option[T]) EncodeSpanner() (any, error) {
+	return Encode(o.Option)
+}
+
+// DecodeSpanner implements spanner.Decoder, as Decode does.
+func (o *Option[T]) DecodeSpanner(input any) error {
+	return Decode(input, &o.Option)
+}
+
+// Encode returns the value to write to Spanner for o: the value it holds if
+// it is Some, and otherwise a NULL of its type, so that Spanner knows the type
+// of the parameter.
+func Encode[T any](o opt.Option[T]) (any, error) {
+	v, ok := o.MaybeUnwrap()
+	return encode(&v, ok)
+}
+
+// Decode stores input, which is one of the forms the Spanner client passes to
+// spanner.Decoder, in o. NULL is decoded as None.
+func Decode[T any](input any, o *opt.Option[T]) error {
+	var v T
+	null, err := decode(input, &v)
+	if err != nil {
+		return err
+	}
+	if null {
+		*o = opt.None[T]()
+		return nil
+	}
+	*o = opt.Some(v)
+	return nil
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	dateType    = reflect.TypeOf(civil.Date{})
+	ratType     = reflect.TypeOf(big.Rat{})
+	bytesType   = reflect.TypeOf([]byte(nil))
+	decoderType = reflect.TypeOf((*spanner.Decoder)(nil)).Elem()
+	nullType    = reflect.TypeOf((*spanner.NullableValue)(nil)).Elem()
+)
+
+// encode returns the value v points to if ok, and otherwise a NULL of its
+// type, so that Spanner knows the type of the parameter.
+func encode(v any, ok bool) (any, error) {
+	rv := reflect.ValueOf(v).Elem()
+	if ok {
+		return rv.Interface(), nil
+	}
+	t := rv.Type()
+	switch {
+	case t == timeType, t == dateType, t == ratType:
+		return reflect.Zero(reflect.PointerTo(t)).Interface(), nil
+	case t.Implements(nullType):
+		return rv.Interface(), nil
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Pointer, reflect.Map, reflect.Interface:
+		return rv.Interface(), nil
+	case reflect.String:
+		return (*string)(nil), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return (*int64)(nil), nil
+	case reflect.Float32:
+		return (*float32)(nil), nil
+	case reflect.Float64:
+		return (*float64)(nil), nil
+	case reflect.Bool:
+		return (*bool)(nil), nil
+	}
+	return nil, nil
+}
+
+// decode stores input, which is one of the forms the Spanner client passes
+// to DecodeSpanner, in the value v points to.
+func decode(input, v any) (bool, error) {
+	if input == nil || reflect.ValueOf(input).Kind() == reflect.Pointer && reflect.ValueOf(input).IsNil() {
+		return true, nil
+	}
+	if err := decodeValue(input, reflect.ValueOf(v).Elem()); err != nil {
+		return false, fmt.Errorf("optspanner: %w", err)
+	}
+	return false, nil
+}
+
+func decodeValue(input any, dst reflect.Value) error {
+	t := dst.Type()
+	if reflect.PointerTo(t).Implements(decoderType) {
+		return dst.Addr().Interface().(spanner.Decoder).DecodeSpanner(input)
+	}
+	switch t {
+	case timeType:
+		s, err := stringInput(input, t)
+		if err != nil {
+			return err
+		}
+		x, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(x))
+		return nil
+	case dateType:
+		s, err := stringInput(input, t)
+		if err != nil {
+			return err
+		}
+		x, err := civil.ParseDate(s)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(x))
+		return nil
+	case ratType:
+		s, err := stringInput(input, t)
+		if err != nil {
+			return err
+		}
+		if _, ok := dst.Addr().Interface().(*big.Rat).SetString(s); !ok {
+			return fmt.Errorf("invalid NUMERIC %q", s)
+		}
+		return nil
+	case bytesType:
+		s, err := stringInput(input, t)
+		if err != nil {
+			return err
+		}
+		x, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBytes(x)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s, err := stringInput(input, t)
+		if err != nil {
+			return err
+		}
+		dst.SetString(s)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s, err := stringInput(input, t)
+		if err != nil {
+			return err
+		}
+		x, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(x)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		switch x := input.(type) {
+		case float64:
+			dst.SetFloat(x)
+			return nil
+		case string:
+			// NaN and the infinities are sent as strings.
+			f, err := strconv.ParseFloat(x, t.Bits())
+			if err != nil {
+				return err
+			}
+			dst.SetFloat(f)
+			return nil
+		}
+	case reflect.Bool:
+		if x, ok := input.(bool); ok {
+			dst.SetBool(x)
+			return nil
+		}
+	case reflect.Slice:
+		list, ok := input.(*structpb.ListValue)
+		if !ok {
+			break
+		}
+		out := reflect.MakeSlice(t, len(list.Values), len(list.Values))
+		for i, elem := range list.Values {
+			if err := decodeElem(elem, out.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		dst.Set(out)
+		return nil
+	}
+	return fmt.Errorf("cannot decode %T into %s", input, t)
+}
+
+// decodeElem decodes an element of an ARRAY into dst, which is nil for NULL
+// if it can be.
+func decodeElem(elem *structpb.Value, dst reflect.Value) error {
+	var input any
+	switch x := elem.GetKind().(type) {
+	case *structpb.Value_NullValue:
+		switch dst.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Interface:
+			return nil
+		}
+		if reflect.PointerTo(dst.Type()).Implements(decoderType) {
+			return dst.Addr().Interface().(spanner.Decoder).DecodeSpanner(nil)
+		}
+		return fmt.Errorf("cannot decode NULL into %s", dst.Type())
+	case *structpb.Value_StringValue:
+		input = x.StringValue
+	case *structpb.Value_NumberValue:
+		input = x.NumberValue
+	case *structpb.Value_BoolValue:
+		input = x.BoolValue
+	case *structpb.Value_ListValue:
+		input = x.ListValue
+	default:
+		return fmt.Errorf("cannot decode %v into %s", elem, dst.Type())
+	}
+	if dst.Kind() == reflect.Pointer && !dst.Type().Implements(decoderType) {
+		dst.Set(reflect.New(dst.Type().Elem()))
+		dst = dst.Elem()
+	}
+	return decodeValue(input, dst)
+}
+
+func stringInput(input any, t reflect.Type) (string, error) {
+	s, ok := input.(string)
+	if !ok {
+		return "", fmt.Errorf("cannot decode %T into %s", input, t)
+	}
+	return s, nil
+}
+
+// FromString returns Some(n.StringVal) if n is valid, otherwise None.
+func FromString(n spanner.NullString) opt.Option[string] {
+	return opt.FromMaybe(n.StringVal, n.Valid)
+}
+
+// ToString returns a spanner.NullString holding the same value as o.
+func ToString(o opt.Option[string]) spanner.NullString {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullString{StringVal: v, Valid: ok}
+}
+
+// FromInt64 returns Some(n.Int64) if n is valid, otherwise None.
+func FromInt64(n spanner.NullInt64) opt.Option[int64] {
+	return opt.FromMaybe(n.Int64, n.Valid)
+}
+
+// ToInt64 returns a spanner.NullInt64 holding the same value as o.
+func ToInt64(o opt.Option[int64]) spanner.NullInt64 {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullInt64{Int64: v, Valid: ok}
+}
+
+// FromFloat64 returns Some(n.Float64) if n is valid, otherwise None.
+func FromFloat64(n spanner.NullFloat64) opt.Option[float64] {
+	return opt.FromMaybe(n.Float64, n.Valid)
+}
+
+// ToFloat64 returns a spanner.NullFloat64 holding the same value as o.
+func ToFloat64(o opt.Option[float64]) spanner.NullFloat64 {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullFloat64{Float64: v, Valid: ok}
+}
+
+// FromFloat32 returns Some(n.Float32) if n is valid, otherwise None.
+func FromFloat32(n spanner.NullFloat32) opt.Option[float32] {
+	return opt.FromMaybe(n.Float32, n.Valid)
+}
+
+// ToFloat32 returns a spanner.NullFloat32 holding the same value as o.
+func ToFloat32(o opt.Option[float32]) spanner.NullFloat32 {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullFloat32{Float32: v, Valid: ok}
+}
+
+// FromBool returns Some(n.Bool) if n is valid, otherwise None.
+func FromBool(n spanner.NullBool) opt.Option[bool] {
+	return opt.FromMaybe(n.Bool, n.Valid)
+}
+
+// ToBool returns a spanner.NullBool holding the same value as o.
+func ToBool(o opt.Option[bool]) spanner.NullBool {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullBool{Bool: v, Valid: ok}
+}
+
+// FromTime returns Some(n.Time) if n is valid, otherwise None.
+func FromTime(n spanner.NullTime) opt.Option[time.Time] {
+	return opt.FromMaybe(n.Time, n.Valid)
+}
+
+// ToTime returns a spanner.NullTime holding the same value as o.
+func ToTime(o opt.Option[time.Time]) spanner.NullTime {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullTime{Time: v, Valid: ok}
+}
+
+// FromDate returns Some(n.Date) if n is valid, otherwise None.
+func FromDate(n spanner.NullDate) opt.Option[civil.Date] {
+	return opt.FromMaybe(n.Date, n.Valid)
+}
+
+// ToDate returns a spanner.NullDate holding the same value as o.
+func ToDate(o opt.Option[civil.Date]) spanner.NullDate {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullDate{Date: v, Valid: ok}
+}
+
+// FromNumeric returns Some(n.Numeric) if n is valid, otherwise None.
+func FromNumeric(n spanner.NullNumeric) opt.Option[big.Rat] {
+	return opt.FromMaybe(n.Numeric, n.Valid)
+}
+
+// ToNumeric returns a spanner.NullNumeric holding the same value as o.
+func ToNumeric(o opt.Option[big.Rat]) spanner.NullNumeric {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullNumeric{Numeric: v, Valid: ok}
+}
+
+// FromJSON returns Some(n.Value) if n is valid, otherwise None.
+func FromJSON(n spanner.NullJSON) opt.Option[any] {
+	return opt.FromMaybe(n.Value, n.Valid)
+}
+
+// ToJSON returns a spanner.NullJSON holding the same value as o.
+func ToJSON(o opt.Option[any]) spanner.NullJSON {
+	v, ok := o.MaybeUnwrap()
+	return spanner.NullJSON{Value: v, Valid: ok}
+}
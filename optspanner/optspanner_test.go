@@ -0,0 +1,125 @@
+package optspanner
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+type Status string
+
+type Singer struct {
+	SingerID  int64
+	FirstName Option[string]
+	Age       Option[int]
+	Score     Option[float64]
+	Active    Option[bool]
+	Avatar    Option[[]byte]
+	BirthDate Option[civil.Date]
+	SignedAt  Option[time.Time]
+	Balance   Option[big.Rat]
+	Status    Option[Status]
+	Tags      Option[[]string]
+	Ratings   Option[[]*int64]
+}
+
+// roundTrip writes s to a row the way the Spanner client does when inserting
+// it, and reads it back.
+func roundTrip(t *testing.T, s Singer) Singer {
+	t.Helper()
+	row, err := spanner.NewRow(
+		[]string{"SingerID", "FirstName", "Age", "Score", "Active", "Avatar", "BirthDate", "SignedAt", "Balance", "Status", "Tags", "Ratings"},
+		[]any{s.SingerID, s.FirstName, s.Age, s.Score, s.Active, s.Avatar, s.BirthDate, s.SignedAt, s.Balance, s.Status, s.Tags, s.Ratings},
+	)
+	require.NoError(t, err)
+	var out Singer
+	require.NoError(t, row.ToStruct(&out))
+	return out
+}
+
+func some[T any](v T) Option[T] {
+	return Option[T]{opt.Some(v)}
+}
+
+func TestRoundTrip(t *testing.T) {
+	five := int64(5)
+	in := Singer{
+		SingerID:  1,
+		FirstName: some("Marc"),
+		Age:       some(42),
+		Score:     some(math.Inf(1)),
+		Active:    some(false),
+		Avatar:    some([]byte("png")),
+		BirthDate: some(civil.Date{Year: 1980, Month: 2, Day: 3}),
+		SignedAt:  some(time.Date(2024, 5, 1, 12, 0, 0, 5, time.UTC)),
+		Balance:   some(*big.NewRat(3, 2)),
+		Status:    some(Status("active")),
+		Tags:      some([]string{"a", "b"}),
+		Ratings:   some([]*int64{&five, nil}),
+	}
+	require.Equal(t, in, roundTrip(t, in))
+
+	none := Singer{SingerID: 2}
+	require.Equal(t, none, roundTrip(t, none))
+}
+
+func TestColumn(t *testing.T) {
+	row, err := spanner.NewRow([]string{"a", "b"}, []any{some("x"), Option[int64]{}})
+	require.NoError(t, err)
+	var a Option[string]
+	b := some[int64](1)
+	require.NoError(t, row.Columns(&a, &b))
+	require.Equal(t, opt.Some("x"), a.Option)
+	require.Equal(t, opt.None[int64](), b.Option)
+
+	// A NULL column can also be read by the Null types, and the other way round.
+	var ns spanner.NullInt64
+	require.NoError(t, row.Column(1, &ns))
+	require.False(t, ns.Valid)
+
+	var wrong Option[bool]
+	require.ErrorContains(t, row.Column(0, &wrong), "optspanner: cannot decode string into bool")
+}
+
+func TestEncodeDecode(t *testing.T) {
+	v, err := Encode(opt.Some("x"))
+	require.NoError(t, err)
+	require.Equal(t, "x", v)
+	v, err = Encode(opt.None[int]())
+	require.NoError(t, err)
+	require.Equal(t, (*int64)(nil), v)
+
+	o := opt.Some(1)
+	require.NoError(t, Decode(nil, &o))
+	require.True(t, o.None())
+	require.NoError(t, Decode("42", &o))
+	require.Equal(t, opt.Some(42), o)
+	require.Error(t, Decode(true, &o))
+}
+
+func TestConverters(t *testing.T) {
+	require.Equal(t, opt.Some("a"), FromString(ToString(opt.Some("a"))))
+	require.Equal(t, spanner.NullString{}, ToString(opt.None[string]()))
+	require.Equal(t, opt.None[string](), FromString(spanner.NullString{}))
+	require.Equal(t, opt.Some[int64](0), FromInt64(spanner.NullInt64{Valid: true}))
+	require.Equal(t, spanner.NullInt64{Int64: 3, Valid: true}, ToInt64(opt.Some[int64](3)))
+	require.Equal(t, opt.Some(1.5), FromFloat64(ToFloat64(opt.Some(1.5))))
+	require.Equal(t, opt.Some[float32](1.5), FromFloat32(ToFloat32(opt.Some[float32](1.5))))
+	require.Equal(t, opt.Some(true), FromBool(ToBool(opt.Some(true))))
+	ts := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, opt.Some(ts), FromTime(ToTime(opt.Some(ts))))
+	require.Equal(t, spanner.NullTime{}, ToTime(opt.None[time.Time]()))
+	d := civil.Date{Year: 2024, Month: 5, Day: 1}
+	require.Equal(t, opt.Some(d), FromDate(ToDate(opt.Some(d))))
+	require.Equal(t, opt.Some(*big.NewRat(1, 4)), FromNumeric(ToNumeric(opt.Some(*big.NewRat(1, 4)))))
+	require.Equal(t, opt.None[big.Rat](), FromNumeric(spanner.NullNumeric{}))
+	require.Equal(t, opt.Some[any](map[string]any{"a": 1.0}), FromJSON(ToJSON(opt.Some[any](map[string]any{"a": 1.0}))))
+	require.Equal(t, spanner.NullJSON{}, ToJSON(opt.None[any]()))
+}
@@ -0,0 +1,159 @@
+// Package optsql builds the parts of SQL statements that depend on which
+// fields of a struct of opt.Option values are Some, which is how optional
+// input such as a PATCH request body or the filters of a list endpoint
+// usually arrives:
+//
+//	type UserPatch struct {
+//		Name  opt.Option[string] `db:"name"`
+//		Email opt.Option[string] `db:"email"`
+//	}
+//
+//	type UserFilter struct {
+//		Team    opt.Option[string]    `db:"team"`
+//		MinAge  opt.Option[int]       `db:"age,>="`
+//		Deleted opt.Option[time.Time] `db:"deleted_at,<"`
+//	}
+//
+//	set, args := optsql.BuildUpdate("users", patch)
+//	where, whereArgs := optsql.BuildWhere(UserFilter{Team: opt.Some("core")})
+//	// set == "UPDATE users SET name = ?", where == "WHERE team = ?"
+//	_, err := db.Exec(set+" "+where, append(args, whereArgs...)...)
+//
+// Only Some fields take part. In a WHERE clause, fields that are not Options
+// always do, so a key such as an ID can sit next to the Options; an UPDATE
+// never sets them, so a patch can carry the key of the row it updates.
+// Columns are named by the "db" tag of a field, or its name in lower case, as
+// github.com/jmoiron/sqlx names them, and fields tagged "-" are skipped. The
+// fields of embedded structs are treated as fields of the outer struct.
+//
+// Statements use ? placeholders; for databases that number them, such as
+// PostgreSQL, rebind the finished query, for example with sqlx.Rebind.
+// Table and column names are written as given, without quoting.
+package optsql
+
+import (
+	"fmt"
+	"strings"
+
+	"code.nkcmr.net/opt/internal/sqlcols"
+)
+
+// BuildUpdate returns an UPDATE statement for table that sets the column of
+// each field of patch that is Some to its value, and the arguments for its
+// placeholders in order. Fields that are not Options are left out. It returns
+// an empty query and no arguments if there is nothing to set. The statement
+// has no WHERE clause; add one, for example with BuildWhere.
+//
+// To set a column to NULL, use an Option of an Option and make it Some(None).
+//
+// BuildUpdate panics if patch is not a struct or a pointer to one.
+func BuildUpdate(table string, patch any) (query string, args []any) {
+	var cols []sqlcols.Column
+	for _, c := range columns("BuildUpdate", patch) {
+		if c.Option {
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) == 0 {
+		return "", nil
+	}
+	var b strings.Builder
+	b.WriteString("UPDATE ")
+	b.WriteString(table)
+	b.WriteString(" SET ")
+	for i, c := range cols {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(c.Name)
+		b.WriteString(" = ?")
+		args = append(args, c.Value.Interface())
+	}
+	return b.String(), args
+}
+
+var operators = map[string]bool{
+	"=": true, "<>": true, "!=": true,
+	"<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "ILIKE": true, "NOT ILIKE": true,
+}
+
+// BuildWhere returns a WHERE clause with a predicate for each field of filter
+// that is Some, joined with AND, and the arguments for its placeholders in
+// order. It returns an empty clause and no arguments if there are no
+// predicates.
+//
+// A predicate compares its column to the value of the field with =, or with
+// the operator given after the column name in the "db" tag: one of <>, !=,
+// <, <=, >, >=, LIKE, NOT LIKE, ILIKE and NOT ILIKE. A slice value, other
+// than []byte, matches any of its elements with IN, or none of them with NOT
+// IN if the operator is <> or !=. A NULL value, such as Some(None) for an
+// Option of an Option, is matched with IS NULL, or IS NOT NULL.
+//
+// BuildWhere panics if filter is not a struct or a pointer to one, or a tag
+// gives an unknown operator, or an operator other than = or <> for a slice
+// or NULL value.
+func BuildWhere(filter any) (clause string, args []any) {
+	cols := columns("BuildWhere", filter)
+	if len(cols) == 0 {
+		return "", nil
+	}
+	preds := make([]string, len(cols))
+	for i, c := range cols {
+		preds[i], args = predicate(c, args)
+	}
+	return "WHERE " + strings.Join(preds, " AND "), args
+}
+
+// predicate returns the predicate for c, and args with the arguments for its
+// placeholders appended.
+func predicate(c sqlcols.Column, args []any) (string, []any) {
+	op := c.Op
+	if op == "" {
+		op = "="
+	}
+	if !operators[op] {
+		panic(fmt.Sprintf("optsql: BuildWhere: field %s: unknown operator %q", c.Field, op))
+	}
+	negate := op == "<>" || op == "!="
+	switch {
+	case c.IsNull():
+		if op != "=" && !negate {
+			panic(fmt.Sprintf("optsql: BuildWhere: field %s: cannot compare NULL with %s", c.Field, op))
+		}
+		if negate {
+			return c.Name + " IS NOT NULL", args
+		}
+		return c.Name + " IS NULL", args
+	case c.IsList():
+		if op != "=" && !negate {
+			panic(fmt.Sprintf("optsql: BuildWhere: field %s: cannot compare a list with %s", c.Field, op))
+		}
+		n := c.Value.Len()
+		if n == 0 {
+			// Nothing is in an empty list, but IN () is not valid SQL.
+			if negate {
+				return "1 = 1", args
+			}
+			return "1 = 0", args
+		}
+		for i := 0; i < n; i++ {
+			args = append(args, c.Value.Index(i).Interface())
+		}
+		list := strings.Repeat(", ?", n)[2:]
+		if negate {
+			return c.Name + " NOT IN (" + list + ")", args
+		}
+		return c.Name + " IN (" + list + ")", args
+	}
+	return c.Name + " " + op + " ?", append(args, c.Value.Interface())
+}
+
+// columns returns the columns of the struct v, panicking if it is not one.
+func columns(fn string, v any) []sqlcols.Column {
+	cols, ok := sqlcols.Of(v)
+	if !ok {
+		panic(fmt.Sprintf("optsql: %s: %T is not a struct or a pointer to one", fn, v))
+	}
+	return cols
+}
@@ -0,0 +1,116 @@
+package optsql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+type userPatch struct {
+	ID       int64
+	Name     opt.Option[string]
+	Email    opt.Option[string]             `db:"email_address"`
+	Nickname opt.Option[opt.Option[string]] `db:"nickname"`
+	Secret   opt.Option[string]             `db:"-"`
+}
+
+func TestBuildUpdate(t *testing.T) {
+	query, args := BuildUpdate("users", userPatch{
+		Name:     opt.Some("Ada"),
+		Nickname: opt.Some(opt.None[string]()),
+		Secret:   opt.Some("x"),
+	})
+	require.Equal(t, "UPDATE users SET name = ?, nickname = ?", query)
+	require.Equal(t, []any{"Ada", opt.None[string]()}, args)
+
+	query, args = BuildUpdate("users", &userPatch{Email: opt.Some("")})
+	require.Equal(t, "UPDATE users SET email_address = ?", query)
+	require.Equal(t, []any{""}, args)
+
+	// Fields that are not Options are never set.
+	patch := userPatch{ID: 7, Name: opt.Some("Ada")}
+	query, args = BuildUpdate("users", patch)
+	require.Equal(t, "UPDATE users SET name = ?", query)
+	require.Equal(t, []any{"Ada"}, args)
+	where, whereArgs := BuildWhere(struct{ ID int64 }{patch.ID})
+	require.Equal(t, "WHERE id = ?", where)
+	require.Equal(t, []any{int64(7)}, whereArgs)
+
+	query, args = BuildUpdate("users", userPatch{ID: 7})
+	require.Empty(t, query)
+	require.Nil(t, args)
+
+	require.PanicsWithValue(t, "optsql: BuildUpdate: string is not a struct or a pointer to one", func() {
+		BuildUpdate("users", "name")
+	})
+}
+
+type timestamps struct {
+	CreatedAfter opt.Option[time.Time] `db:"created_at,>="`
+}
+
+type userFilter struct {
+	OrgID    int64 `db:"org_id"`
+	Team     opt.Option[string]
+	Name     opt.Option[string]                `db:"name,like"`
+	Roles    opt.Option[[]string]              `db:"role"`
+	NotIDs   opt.Option[[]int64]               `db:"id,<>"`
+	Deleted  opt.Option[opt.Option[time.Time]] `db:"deleted_at"`
+	Archived opt.Option[*time.Time]            `db:"archived_at,!="`
+	Avatar   opt.Option[[]byte]                `db:"avatar"`
+	timestamps
+}
+
+func TestBuildWhere(t *testing.T) {
+	clause, args := BuildWhere(userFilter{OrgID: 1})
+	require.Equal(t, "WHERE org_id = ?", clause)
+	require.Equal(t, []any{int64(1)}, args)
+
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clause, args = BuildWhere(&userFilter{
+		OrgID:    1,
+		Team:     opt.Some("core"),
+		Name:     opt.Some("A%"),
+		Roles:    opt.Some([]string{"admin", "owner"}),
+		NotIDs:   opt.Some([]int64{7}),
+		Deleted:  opt.Some(opt.None[time.Time]()),
+		Archived: opt.Some[*time.Time](nil),
+		Avatar:   opt.Some([]byte{1}),
+		timestamps: timestamps{
+			CreatedAfter: opt.Some(ts),
+		},
+	})
+	require.Equal(t, "WHERE org_id = ? AND team = ? AND name LIKE ? AND role IN (?, ?) AND id NOT IN (?)"+
+		" AND deleted_at IS NULL AND archived_at IS NOT NULL AND avatar = ? AND created_at >= ?", clause)
+	require.Equal(t, []any{int64(1), "core", "A%", "admin", "owner", int64(7), []byte{1}, ts}, args)
+
+	clause, args = BuildWhere(userFilter{Roles: opt.Some([]string{}), NotIDs: opt.Some([]int64(nil))})
+	require.Equal(t, "WHERE org_id = ? AND 1 = 0 AND 1 = 1", clause)
+	require.Equal(t, []any{int64(0)}, args)
+
+	clause, args = BuildWhere(struct{ Team opt.Option[string] }{})
+	require.Empty(t, clause)
+	require.Nil(t, args)
+}
+
+func TestBuildWherePanics(t *testing.T) {
+	require.PanicsWithValue(t, `optsql: BuildWhere: field Age: unknown operator "~"`, func() {
+		BuildWhere(struct {
+			Age opt.Option[int] `db:"age,~"`
+		}{opt.Some(1)})
+	})
+	require.PanicsWithValue(t, "optsql: BuildWhere: field IDs: cannot compare a list with >", func() {
+		BuildWhere(struct {
+			IDs opt.Option[[]int] `db:"id,>"`
+		}{opt.Some([]int{1})})
+	})
+	require.PanicsWithValue(t, "optsql: BuildWhere: field Deleted: cannot compare NULL with <", func() {
+		BuildWhere(struct {
+			Deleted opt.Option[*time.Time] `db:"deleted_at,<"`
+		}{opt.Some[*time.Time](nil)})
+	})
+	require.Panics(t, func() { BuildWhere(nil) })
+}
@@ -0,0 +1,164 @@
+// Package optsqlx lets github.com/jmoiron/sqlx scan into and bind from structs
+// that have opt.Option fields.
+//
+// Option implements sql.Scanner and driver.Valuer, so a flat Option field
+// already works with sqlx on its own. What sqlx cannot do is look inside an
+// Option: an Option[Address] field holding the columns "address.city" and
+// "address.zip" is invisible to its reflection, so those columns are reported
+// as missing destinations and the named parameters :address.city and
+// :address.zip cannot be bound. The functions here map structs to a mirror
+// type in which every Option[T] is a *T, which sqlx understands, and map the
+// results back.
+//
+//	var users []User
+//	err := optsqlx.Select(db, &users, "SELECT id, name, address.city FROM ...")
+//	...
+//	_, err = optsqlx.NamedExec(db, "INSERT INTO users (name) VALUES (:name)", user)
+//
+// None binds as NULL, as do the fields of an Option holding a struct that is
+// None. When scanning, a NULL column leaves its Option as None, and an Option
+// holding a struct becomes Some as soon as any of its columns is part of the
+// result.
+package optsqlx
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sync"
+
+	"code.nkcmr.net/opt/internal/shadow"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+)
+
+var mapper = &shadow.Mapper{}
+
+// defaultMapper is the equivalent of the mapper sqlx uses when it is not given
+// a *sqlx.DB or *sqlx.Tx.
+var defaultMapper = sync.OnceValue(func() *reflectx.Mapper {
+	return reflectx.NewMapperFunc("db", sqlx.NameMapper)
+})
+
+// Arg returns arg in a form sqlx can bind named parameters from, naming struct
+// fields with m, or like sqlx does by default if m is nil. Use it to pass
+// structs with Options to sqlx functions that this package does not wrap, such
+// as NamedQuery or (*sqlx.NamedStmt).Exec.
+//
+// A struct (or a slice of structs) that contains Options is turned into a map
+// (or a slice of maps) keyed by parameter name, because sqlx cannot bind the
+// fields of a struct behind a nil pointer.
+func Arg(m *reflectx.Mapper, arg any) any {
+	if arg == nil || !shadow.Needs(reflect.TypeOf(arg)) {
+		return arg
+	}
+	if m == nil {
+		m = defaultMapper()
+	}
+	v := reflect.Indirect(mapper.To(reflect.ValueOf(arg)))
+	switch v.Kind() {
+	case reflect.Struct:
+		return flatten(m, v)
+	case reflect.Slice, reflect.Array:
+		args := make([]map[string]any, v.Len())
+		for i := range args {
+			if elem := reflect.Indirect(v.Index(i)); elem.Kind() == reflect.Struct {
+				args[i] = flatten(m, elem)
+			} else {
+				return v.Interface()
+			}
+		}
+		return args
+	}
+	return v.Interface()
+}
+
+// flatten returns the value of every field of the struct v by the name sqlx
+// would bind it as. Fields behind a nil pointer are nil.
+func flatten(m *reflectx.Mapper, v reflect.Value) map[string]any {
+	fields := m.TypeMap(v.Type()).Index
+	args := make(map[string]any, len(fields))
+	for _, fi := range fields {
+		if fi.Path != "" {
+			args[fi.Path] = fieldByIndex(v, fi.Index)
+		}
+	}
+	return args
+}
+
+func fieldByIndex(v reflect.Value, index []int) any {
+	for _, i := range index {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v.Interface()
+}
+
+// mapperFor returns the mapper sqlx uses for e.
+func mapperFor(e any) *reflectx.Mapper {
+	switch e := e.(type) {
+	case *sqlx.DB:
+		return e.Mapper
+	case *sqlx.Tx:
+		return e.Mapper
+	}
+	return nil
+}
+
+// StructScanner is implemented by *sqlx.Row and *sqlx.Rows.
+type StructScanner interface {
+	StructScan(dest any) error
+}
+
+// StructScan is like rows.StructScan but dest may contain Options.
+func StructScan(rows StructScanner, dest any) error {
+	return mapper.Decode(dest, rows.StructScan)
+}
+
+// Get is like sqlx.Get but dest may contain Options.
+func Get(q sqlx.Queryer, dest any, query string, args ...any) error {
+	return mapper.Decode(dest, func(dest any) error {
+		return sqlx.Get(q, dest, query, args...)
+	})
+}
+
+// GetContext is like sqlx.GetContext but dest may contain Options.
+func GetContext(ctx context.Context, q sqlx.QueryerContext, dest any, query string, args ...any) error {
+	return mapper.Decode(dest, func(dest any) error {
+		return sqlx.GetContext(ctx, q, dest, query, args...)
+	})
+}
+
+// Select is like sqlx.Select but dest may contain Options.
+func Select(q sqlx.Queryer, dest any, query string, args ...any) error {
+	return mapper.Decode(dest, func(dest any) error {
+		return sqlx.Select(q, dest, query, args...)
+	})
+}
+
+// SelectContext is like sqlx.SelectContext but dest may contain Options.
+func SelectContext(ctx context.Context, q sqlx.QueryerContext, dest any, query string, args ...any) error {
+	return mapper.Decode(dest, func(dest any) error {
+		return sqlx.SelectContext(ctx, q, dest, query, args...)
+	})
+}
+
+// Named is like sqlx.Named but arg may contain Options.
+func Named(query string, arg any) (string, []any, error) {
+	return sqlx.Named(query, Arg(nil, arg))
+}
+
+// NamedExec is like sqlx.NamedExec but arg may contain Options.
+func NamedExec(e sqlx.Ext, query string, arg any) (sql.Result, error) {
+	return sqlx.NamedExec(e, query, Arg(mapperFor(e), arg))
+}
+
+// NamedExecContext is like sqlx.NamedExecContext but arg may contain Options.
+func NamedExecContext(ctx context.Context, e sqlx.ExtContext, query string, arg any) (sql.Result, error) {
+	return sqlx.NamedExecContext(ctx, e, query, Arg(mapperFor(e), arg))
+}
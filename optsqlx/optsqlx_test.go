@@ -0,0 +1,145 @@
+package optsqlx
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string             `db:"city"`
+	Zip  opt.Option[string] `db:"zip"`
+}
+
+type user struct {
+	ID      int                 `db:"id"`
+	Name    opt.Option[string]  `db:"name"`
+	Age     opt.Option[int64]   `db:"age"`
+	Address opt.Option[address] `db:"address"`
+}
+
+func newDB(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+	return sqlx.NewDb(db, "sqlmock"), mock
+}
+
+func TestGet(t *testing.T) {
+	db, mock := newDB(t)
+	mock.ExpectQuery("SELECT 1").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "age", "address.city", "address.zip"}).
+			AddRow(1, "nk", nil, "Austin", nil),
+	)
+
+	u := user{Age: opt.Some(int64(9))}
+	require.NoError(t, Get(db, &u, "SELECT 1"))
+	require.Equal(t, user{
+		ID:      1,
+		Name:    opt.Some("nk"),
+		Address: opt.Some(address{City: "Austin"}),
+	}, u)
+}
+
+func TestGetWithoutNestedColumns(t *testing.T) {
+	db, mock := newDB(t)
+	mock.ExpectQuery("SELECT 1").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name"}).AddRow(1, nil),
+	)
+
+	var u user
+	require.NoError(t, GetContext(context.Background(), db, &u, "SELECT 1"))
+	require.Equal(t, user{ID: 1}, u)
+}
+
+func TestSelect(t *testing.T) {
+	db, mock := newDB(t)
+	mock.ExpectQuery("SELECT 1").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "age"}).AddRow(1, 30).AddRow(2, nil),
+	)
+
+	var users []user
+	require.NoError(t, Select(db, &users, "SELECT 1"))
+	require.Equal(t, []user{{ID: 1, Age: opt.Some(int64(30))}, {ID: 2}}, users)
+}
+
+func TestStructScan(t *testing.T) {
+	db, mock := newDB(t)
+	mock.ExpectQuery("SELECT 1").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "address.city", "address.zip"}).
+			AddRow(1, "Austin", "78701").
+			AddRow(2, "Denver", nil),
+	)
+
+	rows, err := db.Queryx("SELECT 1")
+	require.NoError(t, err)
+	defer rows.Close()
+	var got []user
+	for rows.Next() {
+		var u user
+		require.NoError(t, StructScan(rows, &u))
+		got = append(got, u)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []user{
+		{ID: 1, Address: opt.Some(address{City: "Austin", Zip: opt.Some("78701")})},
+		{ID: 2, Address: opt.Some(address{City: "Denver"})},
+	}, got)
+}
+
+func TestNamed(t *testing.T) {
+	query, args, err := Named(
+		"INSERT INTO users VALUES (:id, :name, :age, :address.city, :address.zip)",
+		user{ID: 1, Age: opt.Some(int64(30)), Address: opt.Some(address{City: "Austin"})},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO users VALUES (?, ?, ?, ?, ?)", query)
+	require.Len(t, args, 5)
+	require.Equal(t, 1, args[0])
+	require.Nil(t, args[1])
+	require.Equal(t, int64(30), *args[2].(*int64))
+	require.Equal(t, "Austin", args[3])
+	require.Nil(t, args[4])
+
+	_, args, err = Named("INSERT INTO users VALUES (:address.city, :address.zip)", &user{})
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, nil}, args)
+
+	_, _, err = Named("INSERT INTO users VALUES (:nope)", user{})
+	require.Error(t, err)
+}
+
+func TestNamedExec(t *testing.T) {
+	db, mock := newDB(t)
+	mock.ExpectExec("INSERT INTO users (id, name) VALUES (?, ?),(?, ?)").
+		WithArgs(1, nil, 2, "nk").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	_, err := NamedExec(db, "INSERT INTO users (id, name) VALUES (:id, :name)", []user{
+		{ID: 1},
+		{ID: 2, Name: opt.Some("nk")},
+	})
+	require.NoError(t, err)
+
+	mock.ExpectExec("UPDATE users SET name = ?").
+		WithArgs(nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = NamedExecContext(context.Background(), db, "UPDATE users SET name = :name", user{})
+	require.NoError(t, err)
+
+	db.Mapper = reflectx.NewMapperFunc("json", strings.ToUpper)
+	mock.ExpectExec("UPDATE users SET name = ?").
+		WithArgs("nk").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = NamedExec(db, "UPDATE users SET name = :NAME", user{Name: opt.Some("nk")})
+	require.NoError(t, err)
+}
@@ -0,0 +1,111 @@
+// Package optsquirrel turns structs of opt.Option values into the maps and
+// conditions of github.com/Masterminds/squirrel, leaving out the fields that
+// are None, so updates and filters built from optional input only touch what
+// was given:
+//
+//	sq.Update("users").SetMap(optsquirrel.SetMap(patch)).Where(sq.Eq{"id": id})
+//	sq.Select("*").From("users").Where(optsquirrel.Where(filter))
+//
+// Fields are read as optsql reads them: columns are named by the "db" tag of
+// a field, or its name in lower case, fields tagged "-" are skipped, fields
+// that are not Options are always included in conditions but never set, and
+// the fields of embedded structs are treated as fields of the outer struct. As in optsql, an Option
+// of an Option that is Some(None) stands for NULL.
+package optsquirrel
+
+import (
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"code.nkcmr.net/opt/internal/sqlcols"
+)
+
+// SetMap returns the value of each field of v that is Some by column name,
+// for UpdateBuilder.SetMap. Fields that are not Options are left out. It
+// panics if v is not a struct or a pointer to one.
+func SetMap(v any) map[string]any {
+	cols := columns("SetMap", v)
+	m := make(map[string]any, len(cols))
+	for _, c := range cols {
+		if c.Option {
+			m[c.Name] = c.Value.Interface()
+		}
+	}
+	return m
+}
+
+// Eq returns the value of each field of v that is Some by column name, as an
+// equality condition. Like any sq.Eq, it matches slices with IN and NULL
+// with IS NULL. Operators in tags are ignored; use Where for those. It panics
+// if v is not a struct or a pointer to one.
+func Eq(v any) sq.Eq {
+	cols := columns("Eq", v)
+	eq := make(sq.Eq, len(cols))
+	for _, c := range cols {
+		eq[c.Name] = c.Value.Interface()
+	}
+	return eq
+}
+
+// Where returns a condition for each field of v that is Some, joined with
+// AND. A condition compares its column to the value of the field with sq.Eq,
+// or with the condition for the operator given after the column name in the
+// "db" tag:
+//
+//	<> or !=   sq.NotEq
+//	<          sq.Lt
+//	<=         sq.LtOrEq
+//	>          sq.Gt
+//	>=         sq.GtOrEq
+//	LIKE       sq.Like
+//	NOT LIKE   sq.NotLike
+//	ILIKE      sq.ILike
+//	NOT ILIKE  sq.NotILike
+//
+// If no field is Some, the condition is always true. Where panics if v is not
+// a struct or a pointer to one, or a tag gives an unknown operator.
+func Where(v any) sq.And {
+	cols := columns("Where", v)
+	conds := make(sq.And, len(cols))
+	for i, c := range cols {
+		conds[i] = condition(c)
+	}
+	return conds
+}
+
+func condition(c sqlcols.Column) sq.Sqlizer {
+	x := c.Value.Interface()
+	switch c.Op {
+	case "", "=":
+		return sq.Eq{c.Name: x}
+	case "<>", "!=":
+		return sq.NotEq{c.Name: x}
+	case "<":
+		return sq.Lt{c.Name: x}
+	case "<=":
+		return sq.LtOrEq{c.Name: x}
+	case ">":
+		return sq.Gt{c.Name: x}
+	case ">=":
+		return sq.GtOrEq{c.Name: x}
+	case "LIKE":
+		return sq.Like{c.Name: x}
+	case "NOT LIKE":
+		return sq.NotLike{c.Name: x}
+	case "ILIKE":
+		return sq.ILike{c.Name: x}
+	case "NOT ILIKE":
+		return sq.NotILike{c.Name: x}
+	}
+	panic(fmt.Sprintf("optsquirrel: Where: field %s: unknown operator %q", c.Field, c.Op))
+}
+
+// columns returns the columns of the struct v, panicking if it is not one.
+func columns(fn string, v any) []sqlcols.Column {
+	cols, ok := sqlcols.Of(v)
+	if !ok {
+		panic(fmt.Sprintf("optsquirrel: %s: %T is not a struct or a pointer to one", fn, v))
+	}
+	return cols
+}
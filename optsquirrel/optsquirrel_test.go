@@ -0,0 +1,101 @@
+package optsquirrel
+
+import (
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+type userPatch struct {
+	ID       int64
+	Name     opt.Option[string]
+	Email    opt.Option[string]             `db:"email_address"`
+	Nickname opt.Option[opt.Option[string]] `db:"nickname"`
+	Secret   opt.Option[string]             `db:"-"`
+}
+
+func TestSetMap(t *testing.T) {
+	patch := userPatch{
+		Name:     opt.Some("Ada"),
+		Nickname: opt.Some(opt.None[string]()),
+		Secret:   opt.Some("x"),
+	}
+	require.Equal(t, map[string]any{"name": "Ada", "nickname": opt.None[string]()}, SetMap(patch))
+	require.Empty(t, SetMap(&userPatch{ID: 1}))
+
+	// Fields that are not Options are never set, but Eq compares them.
+	patch.ID = 1
+	query, args, err := sq.Update("users").SetMap(SetMap(patch)).Where(Eq(struct{ ID int64 }{patch.ID})).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE users SET name = ?, nickname = ? WHERE id = ?", query)
+	require.Equal(t, []any{"Ada", opt.None[string](), int64(1)}, args)
+
+	require.PanicsWithValue(t, "optsquirrel: SetMap: int is not a struct or a pointer to one", func() { SetMap(1) })
+}
+
+type userFilter struct {
+	Team         opt.Option[string]
+	Roles        opt.Option[[]string]              `db:"role"`
+	Deleted      opt.Option[opt.Option[time.Time]] `db:"deleted_at"`
+	Name         opt.Option[string]                `db:"name,like"`
+	NotName      opt.Option[string]                `db:"name,not ilike"`
+	NotTeam      opt.Option[string]                `db:"team,<>"`
+	MinAge       opt.Option[int]                   `db:"age,>="`
+	MaxAge       opt.Option[int]                   `db:"age,<"`
+	CreatedAfter opt.Option[time.Time]             `db:"created_at,>"`
+	Score        opt.Option[float64]               `db:"score,<="`
+	Title        opt.Option[string]                `db:"title,ilike"`
+	Bio          opt.Option[string]                `db:"bio,not like"`
+}
+
+func TestEq(t *testing.T) {
+	filter := userFilter{
+		Team:    opt.Some("core"),
+		Roles:   opt.Some([]string{"admin", "owner"}),
+		Deleted: opt.Some(opt.None[time.Time]()),
+		MinAge:  opt.Some(18),
+	}
+	query, args, err := sq.Select("*").From("users").Where(Eq(filter)).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM users WHERE age = ? AND deleted_at IS NULL AND role IN (?,?) AND team = ?", query)
+	require.Equal(t, []any{18, "admin", "owner", "core"}, args)
+}
+
+func TestWhere(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := userFilter{
+		Team:         opt.Some("core"),
+		Roles:        opt.Some([]string{"admin"}),
+		Deleted:      opt.Some(opt.None[time.Time]()),
+		Name:         opt.Some("A%"),
+		NotName:      opt.Some("%bot%"),
+		NotTeam:      opt.Some("ops"),
+		MinAge:       opt.Some(18),
+		MaxAge:       opt.Some(65),
+		CreatedAfter: opt.Some(ts),
+		Score:        opt.Some(0.5),
+		Title:        opt.Some("dr%"),
+		Bio:          opt.Some("%spam%"),
+	}
+	query, args, err := sq.Select("*").From("users").Where(Where(filter)).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM users WHERE (team = ? AND role IN (?) AND deleted_at IS NULL"+
+		" AND name LIKE ? AND name NOT ILIKE ? AND team <> ? AND age >= ? AND age < ?"+
+		" AND created_at > ? AND score <= ? AND title ILIKE ? AND bio NOT LIKE ?)", query)
+	require.Equal(t, []any{"core", "admin", "A%", "%bot%", "ops", 18, 65, ts, 0.5, "dr%", "%spam%"}, args)
+
+	query, args, err = sq.Select("*").From("users").Where(Where(userFilter{})).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM users WHERE (1=1)", query)
+	require.Empty(t, args)
+
+	require.PanicsWithValue(t, `optsquirrel: Where: field Age: unknown operator "~"`, func() {
+		Where(struct {
+			Age opt.Option[int] `db:"age,~"`
+		}{opt.Some(1)})
+	})
+}
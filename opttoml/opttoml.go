@@ -0,0 +1,90 @@
+// Package opttoml encodes and decodes TOML with opt.Option fields, using
+// either github.com/pelletier/go-toml/v2 or github.com/BurntSushi/toml.
+//
+// TOML has no null, so a None value can only be written by leaving its key
+// out. Option implements the TOML marshalers of both libraries, but neither
+// lets a value leave its own key out unless the encoder is set up for it or
+// the field is tagged, and go-toml cannot decode tables or arrays into an
+// Option. The functions here instead encode and decode a mirror type in which
+// every Option[T] is a *T, which both libraries understand and which they
+// leave out when it is nil, without the need for "omitempty" tags:
+//
+//	type Config struct {
+//		Name  opt.Option[string]   `toml:"name"`
+//		Tags  opt.Option[[]string] `toml:"tags"`
+//		Proxy opt.Option[Proxy]    `toml:"proxy"`
+//	}
+//
+//	data, err := opttoml.Marshal(cfg)
+//	err = opttoml.Unmarshal(data, &cfg)
+//
+// Marshal, Unmarshal, Encode and Decode use go-toml; EncodeBurntSushi and
+// DecodeBurntSushi use BurntSushi/toml.
+//
+// None values are left out of the document. A key that is absent from the
+// document leaves its Option as it was, so None unless it was set before
+// decoding. An empty string is decoded as Some("").
+package opttoml
+
+import (
+	"reflect"
+
+	burntsushi "github.com/BurntSushi/toml"
+	"github.com/pelletier/go-toml/v2"
+
+	"code.nkcmr.net/opt/internal/shadow"
+)
+
+var mapper = &shadow.Mapper{}
+
+// Marshal is like toml.Marshal but also accepts Options.
+func Marshal(v any) ([]byte, error) {
+	return toml.Marshal(mirror(v))
+}
+
+// Unmarshal is like toml.Unmarshal but also accepts Options.
+func Unmarshal(data []byte, v any) error {
+	return mapper.Decode(v, func(out any) error {
+		return toml.Unmarshal(data, out)
+	})
+}
+
+// Encode is like enc.Encode but also accepts Options, so that the encoder
+// can be configured before it is used.
+func Encode(enc *toml.Encoder, v any) error {
+	return enc.Encode(mirror(v))
+}
+
+// Decode is like dec.Decode but also accepts Options, so that the decoder
+// can be configured, for instance with DisallowUnknownFields, before it is
+// used.
+func Decode(dec *toml.Decoder, v any) error {
+	return mapper.Decode(v, func(out any) error {
+		return dec.Decode(out)
+	})
+}
+
+// EncodeBurntSushi is like enc.Encode, for an Encoder of
+// github.com/BurntSushi/toml, but also accepts Options.
+func EncodeBurntSushi(enc *burntsushi.Encoder, v any) error {
+	return enc.Encode(mirror(v))
+}
+
+// DecodeBurntSushi is like toml.Decode of github.com/BurntSushi/toml, but
+// also accepts Options.
+func DecodeBurntSushi(data string, v any) (burntsushi.MetaData, error) {
+	var md burntsushi.MetaData
+	err := mapper.Decode(v, func(out any) error {
+		var err error
+		md, err = burntsushi.Decode(data, out)
+		return err
+	})
+	return md, err
+}
+
+func mirror(v any) any {
+	if v == nil || !shadow.Needs(reflect.TypeOf(v)) {
+		return v
+	}
+	return mapper.To(reflect.ValueOf(v)).Interface()
+}
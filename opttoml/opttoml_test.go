@@ -0,0 +1,154 @@
+package opttoml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	burntsushi "github.com/BurntSushi/toml"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+type proxy struct {
+	Host string          `toml:"host"`
+	Port opt.Option[int] `toml:"port"`
+}
+
+type config struct {
+	Name    opt.Option[string]    `toml:"name"`
+	Blank   opt.Option[string]    `toml:"blank"`
+	Timeout opt.Option[float64]   `toml:"timeout"`
+	Tags    opt.Option[[]string]  `toml:"tags"`
+	At      opt.Option[time.Time] `toml:"at"`
+	Proxy   opt.Option[proxy]     `toml:"proxy"`
+	Missing opt.Option[bool]      `toml:"missing"`
+}
+
+const doc = `
+name = "beep"
+blank = ""
+timeout = 5.5
+tags = ["a", "b"]
+at = 2024-03-01T12:30:00Z
+
+[proxy]
+host = "localhost"
+`
+
+func TestUnmarshal(t *testing.T) {
+	want := config{
+		Name:    opt.Some("beep"),
+		Blank:   opt.Some(""),
+		Timeout: opt.Some(5.5),
+		Tags:    opt.Some([]string{"a", "b"}),
+		At:      opt.Some(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)),
+		Proxy:   opt.Some(proxy{Host: "localhost"}),
+	}
+
+	var got config
+	require.NoError(t, Unmarshal([]byte(doc), &got))
+	require.True(t, want.At.Unwrap().Equal(got.At.Unwrap()))
+	got.At = want.At
+	require.Equal(t, want, got)
+
+	// BurntSushi/toml decodes the same document to the same values.
+	var fromBurntSushi config
+	md, err := DecodeBurntSushi(doc, &fromBurntSushi)
+	require.NoError(t, err)
+	require.True(t, md.IsDefined("proxy", "host"))
+	require.Empty(t, md.Undecoded())
+	require.True(t, want.At.Unwrap().Equal(fromBurntSushi.At.Unwrap()))
+	fromBurntSushi.At = want.At
+	require.Equal(t, want, fromBurntSushi)
+}
+
+func TestUnmarshalKeepsAbsent(t *testing.T) {
+	got := config{Name: opt.Some("kept")}
+	require.NoError(t, Unmarshal([]byte(`tags = []`), &got))
+	require.Equal(t, config{Name: opt.Some("kept"), Tags: opt.Some([]string{})}, got)
+}
+
+func TestDecode(t *testing.T) {
+	var got config
+	dec := toml.NewDecoder(strings.NewReader("name = \"x\"\nunknown = 1")).DisallowUnknownFields()
+	require.Error(t, Decode(dec, &got))
+
+	m := map[string]opt.Option[[]int]{}
+	require.NoError(t, Decode(toml.NewDecoder(strings.NewReader(`l = [1, 2]`)), &m))
+	require.Equal(t, map[string]opt.Option[[]int]{"l": opt.Some([]int{1, 2})}, m)
+}
+
+func TestUnmarshalTypeMismatch(t *testing.T) {
+	var got config
+	require.Error(t, Unmarshal([]byte(`name = 5`), &got))
+	_, err := DecodeBurntSushi(`name = 5`, &got)
+	require.Error(t, err)
+}
+
+// none has no "omitempty" or "omitzero" tags, so that its None fields are
+// only left out because they are None.
+type none struct {
+	Name  opt.Option[string] `toml:"name"`
+	Blank opt.Option[string] `toml:"blank"`
+	Proxy opt.Option[proxy]  `toml:"proxy"`
+	Count int                `toml:"count"`
+}
+
+func TestMarshal(t *testing.T) {
+	in := none{Blank: opt.Some(""), Count: 3}
+
+	data, err := Marshal(in)
+	require.NoError(t, err)
+	require.Equal(t, "blank = ''\ncount = 3\n", string(data))
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(toml.NewEncoder(&buf), in))
+	require.Equal(t, string(data), buf.String())
+
+	var out none
+	require.NoError(t, Unmarshal(data, &out))
+	require.Equal(t, in, out)
+}
+
+func TestEncodeBurntSushi(t *testing.T) {
+	in := none{Blank: opt.Some(""), Count: 3}
+	var buf bytes.Buffer
+	require.NoError(t, EncodeBurntSushi(burntsushi.NewEncoder(&buf), in))
+	require.Equal(t, "blank = \"\"\ncount = 3\n", buf.String())
+
+	var out none
+	_, err := DecodeBurntSushi(buf.String(), &out)
+	require.NoError(t, err)
+	require.Equal(t, in, out)
+
+	at := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	full := config{
+		Name:    opt.Some(`say "hi"`),
+		Timeout: opt.Some(2.0),
+		Tags:    opt.Some([]string{"a"}),
+		At:      opt.Some(at),
+		Proxy:   opt.Some(proxy{Host: "localhost", Port: opt.Some(80)}),
+	}
+	buf.Reset()
+	require.NoError(t, EncodeBurntSushi(burntsushi.NewEncoder(&buf), full))
+	require.Equal(t, `name = "say \"hi\""
+timeout = 2.0
+tags = ["a"]
+at = 2024-03-01T12:30:00Z
+
+[proxy]
+  host = "localhost"
+  port = 80
+`, buf.String())
+
+	var roundTrip config
+	_, err = DecodeBurntSushi(buf.String(), &roundTrip)
+	require.NoError(t, err)
+	require.True(t, at.Equal(roundTrip.At.Unwrap()))
+	roundTrip.At = full.At
+	require.Equal(t, full, roundTrip)
+}
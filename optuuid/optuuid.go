@@ -0,0 +1,48 @@
+// Package optuuid converts between opt.Option and the types of
+// github.com/google/uuid, for optional UUIDs such as nullable foreign keys.
+//
+// An Option[uuid.UUID] needs nothing from this package to be encoded: it
+// marshals to JSON as the string form of the UUID or null, to text as the
+// string form or empty text, and it scans from and is written to SQL columns
+// through uuid.UUID's own Scan and Value methods. The functions here bridge
+// code that uses uuid.Nil or uuid.NullUUID for absence.
+package optuuid
+
+import (
+	"github.com/google/uuid"
+
+	"code.nkcmr.net/opt"
+)
+
+// FromUUID returns Some(u), or None if u is uuid.Nil.
+func FromUUID(u uuid.UUID) opt.Option[uuid.UUID] {
+	return opt.FromMaybe(u, u != uuid.Nil)
+}
+
+// ToUUID returns the UUID o holds, or uuid.Nil if o is None.
+func ToUUID(o opt.Option[uuid.UUID]) uuid.UUID {
+	return o.UnwrapOr(uuid.Nil)
+}
+
+// FromNullUUID returns Some(n.UUID) if n is valid, otherwise None.
+func FromNullUUID(n uuid.NullUUID) opt.Option[uuid.UUID] {
+	return opt.FromMaybe(n.UUID, n.Valid)
+}
+
+// ToNullUUID returns a uuid.NullUUID holding the same value as o.
+func ToNullUUID(o opt.Option[uuid.UUID]) uuid.NullUUID {
+	v, ok := o.MaybeUnwrap()
+	return uuid.NullUUID{UUID: v, Valid: ok}
+}
+
+// Parse parses s with uuid.Parse, returning None if s is empty.
+func Parse(s string) (opt.Option[uuid.UUID], error) {
+	if s == "" {
+		return opt.None[uuid.UUID](), nil
+	}
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return opt.None[uuid.UUID](), err
+	}
+	return opt.Some(u), nil
+}
@@ -0,0 +1,88 @@
+package optuuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt"
+)
+
+var id = uuid.MustParse("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+
+func TestConvert(t *testing.T) {
+	require.Equal(t, opt.Some(id), FromUUID(id))
+	require.Equal(t, opt.None[uuid.UUID](), FromUUID(uuid.Nil))
+	require.Equal(t, id, ToUUID(opt.Some(id)))
+	require.Equal(t, uuid.Nil, ToUUID(opt.None[uuid.UUID]()))
+
+	require.Equal(t, opt.Some(id), FromNullUUID(uuid.NullUUID{UUID: id, Valid: true}))
+	require.Equal(t, opt.Some(uuid.Nil), FromNullUUID(uuid.NullUUID{Valid: true}))
+	require.Equal(t, opt.None[uuid.UUID](), FromNullUUID(uuid.NullUUID{UUID: id}))
+	require.Equal(t, uuid.NullUUID{UUID: id, Valid: true}, ToNullUUID(opt.Some(id)))
+	require.Equal(t, uuid.NullUUID{}, ToNullUUID(opt.None[uuid.UUID]()))
+
+	o, err := Parse(id.String())
+	require.NoError(t, err)
+	require.Equal(t, opt.Some(id), o)
+	o, err = Parse("")
+	require.NoError(t, err)
+	require.Equal(t, opt.None[uuid.UUID](), o)
+	_, err = Parse("not-a-uuid")
+	require.Error(t, err)
+}
+
+func TestJSON(t *testing.T) {
+	type order struct {
+		ID       uuid.UUID             `json:"id"`
+		ParentID opt.Option[uuid.UUID] `json:"parent_id"`
+	}
+	data, err := json.Marshal(order{ID: id, ParentID: opt.Some(id)})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+id.String()+`","parent_id":"`+id.String()+`"}`, string(data))
+	data, err = json.Marshal(order{ID: id})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":"`+id.String()+`","parent_id":null}`, string(data))
+
+	var o order
+	require.NoError(t, json.Unmarshal([]byte(`{"parent_id":"`+id.String()+`"}`), &o))
+	require.Equal(t, opt.Some(id), o.ParentID)
+	require.NoError(t, json.Unmarshal([]byte(`{"parent_id":null}`), &o))
+	require.Equal(t, opt.None[uuid.UUID](), o.ParentID)
+	require.Error(t, json.Unmarshal([]byte(`{"parent_id":"nope"}`), &o))
+}
+
+func TestText(t *testing.T) {
+	text, err := opt.Some(id).MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, id.String(), string(text))
+	text, err = opt.None[uuid.UUID]().MarshalText()
+	require.NoError(t, err)
+	require.Empty(t, text)
+
+	var o opt.Option[uuid.UUID]
+	require.NoError(t, o.UnmarshalText([]byte(id.String())))
+	require.Equal(t, opt.Some(id), o)
+	require.Error(t, o.UnmarshalText([]byte("nope")))
+	require.NoError(t, o.UnmarshalText(nil))
+	require.Equal(t, opt.None[uuid.UUID](), o)
+}
+
+func TestSQL(t *testing.T) {
+	var o opt.Option[uuid.UUID]
+	require.NoError(t, o.Scan(id.String()))
+	require.Equal(t, opt.Some(id), o)
+	require.NoError(t, o.Scan(id[:]))
+	require.Equal(t, opt.Some(id), o)
+	require.NoError(t, o.Scan(nil))
+	require.Equal(t, opt.None[uuid.UUID](), o)
+
+	v, err := opt.Some(id).Value()
+	require.NoError(t, err)
+	require.Equal(t, id.String(), v)
+	v, err = opt.None[uuid.UUID]().Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
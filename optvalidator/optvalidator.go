@@ -0,0 +1,81 @@
+// Package optvalidator lets github.com/go-playground/validator validate
+// opt.Option fields by the value they contain.
+//
+// Without it, validator sees an Option as an opaque struct. Once its type is
+// registered, validation tags apply to the contained value when the Option is
+// Some. When it is None, validator sees nil, so an Option behaves like a
+// pointer: tags after omitempty are skipped, and any other tag fails.
+//
+//	v := validator.New()
+//	if err := optvalidator.RegisterValidations(v); err != nil {
+//		return err
+//	}
+//
+//	type Signup struct {
+//		Email    opt.Option[string] `validate:"required_some,email"`
+//		Nickname opt.Option[string] `validate:"omitempty,min=3,max=20"`
+//		Age      opt.Option[int]    `validate:"omitempty,gte=13"`
+//	}
+//
+// The required tag fails for Some of a zero value, as it does for a pointer
+// to one. The required_some tag only requires the Option to be Some.
+package optvalidator
+
+import (
+	"reflect"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/internal/optreflect"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterType registers a custom type func for Option[T] with v, so that
+// its validation tags apply to the value it contains.
+func RegisterType[T any](v *validator.Validate) {
+	v.RegisterCustomTypeFunc(func(rv reflect.Value) any {
+		if x, ok := rv.Interface().(opt.Option[T]).MaybeUnwrap(); ok {
+			return x
+		}
+		return nil
+	}, opt.Option[T]{})
+}
+
+// RegisterValidations registers the required_some tag with v, along with
+// Option types of strings, booleans, integers, floating-point numbers,
+// durations and times. Options of other types must be registered with
+// RegisterType.
+func RegisterValidations(v *validator.Validate) error {
+	RegisterType[string](v)
+	RegisterType[bool](v)
+	RegisterType[int](v)
+	RegisterType[int8](v)
+	RegisterType[int16](v)
+	RegisterType[int32](v)
+	RegisterType[int64](v)
+	RegisterType[uint](v)
+	RegisterType[uint8](v)
+	RegisterType[uint16](v)
+	RegisterType[uint32](v)
+	RegisterType[uint64](v)
+	RegisterType[float32](v)
+	RegisterType[float64](v)
+	RegisterType[time.Duration](v)
+	RegisterType[time.Time](v)
+	return v.RegisterValidation("required_some", requiredSome, true)
+}
+
+// requiredSome is only called with a valid field when the Option is Some and
+// its type is registered, or when its type is not registered, in which case
+// the Option itself is checked.
+func requiredSome(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if !field.IsValid() {
+		return false
+	}
+	if _, ok := optreflect.Elem(field.Type()); ok {
+		_, ok = optreflect.Get(field)
+		return ok
+	}
+	return true
+}
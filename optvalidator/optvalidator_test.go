@@ -0,0 +1,82 @@
+package optvalidator
+
+import (
+	"errors"
+	"testing"
+
+	"code.nkcmr.net/opt"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/require"
+)
+
+type Address struct {
+	City string `validate:"required"`
+}
+
+type Signup struct {
+	Email    opt.Option[string]  `validate:"required_some,email"`
+	Nickname opt.Option[string]  `validate:"omitempty,min=3,max=20"`
+	Age      opt.Option[int]     `validate:"omitempty,gte=13"`
+	Bio      opt.Option[string]  `validate:"required"`
+	Address  opt.Option[Address] `validate:"required_some"`
+	Home     opt.Option[Address]
+}
+
+func tags(t *testing.T, err error) map[string]string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verrs validator.ValidationErrors
+	require.True(t, errors.As(err, &verrs), "%v", err)
+	m := map[string]string{}
+	for _, fe := range verrs {
+		m[fe.Namespace()] = fe.Tag()
+	}
+	return m
+}
+
+func TestRegisterValidations(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, RegisterValidations(v))
+	RegisterType[Address](v)
+
+	require.Nil(t, tags(t, v.Struct(Signup{
+		Email:   opt.Some("a@example.com"),
+		Bio:     opt.Some("hi"),
+		Address: opt.Some(Address{City: "Paris"}),
+	})))
+
+	require.Equal(t, map[string]string{
+		"Signup.Email":   "required_some",
+		"Signup.Bio":     "required",
+		"Signup.Address": "required_some",
+	}, tags(t, v.Struct(Signup{})))
+
+	require.Equal(t, map[string]string{
+		"Signup.Email":        "email",
+		"Signup.Nickname":     "min",
+		"Signup.Age":          "gte",
+		"Signup.Bio":          "required",
+		"Signup.Address.City": "required",
+		"Signup.Home.City":    "required",
+	}, tags(t, v.Struct(Signup{
+		Email:    opt.Some("nope"),
+		Nickname: opt.Some("a"),
+		Age:      opt.Some(3),
+		Bio:      opt.Some(""),
+		Address:  opt.Some(Address{}),
+		Home:     opt.Some(Address{}),
+	})))
+}
+
+func TestRequiredSomeUnregistered(t *testing.T) {
+	v := validator.New()
+	require.NoError(t, v.RegisterValidation("required_some", requiredSome, true))
+
+	type Upload struct {
+		Data opt.Option[[]byte] `validate:"required_some"`
+	}
+	require.Equal(t, map[string]string{"Upload.Data": "required_some"}, tags(t, v.Struct(Upload{})))
+	require.NoError(t, v.Struct(Upload{Data: opt.Some([]byte(nil))}))
+}
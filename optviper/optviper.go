@@ -0,0 +1,62 @@
+// Package optviper reads github.com/spf13/viper configuration into
+// opt.Option values, which tell a key that was never configured apart from
+// one set to its zero value.
+//
+// Get looks up a single key:
+//
+//	port := optviper.Get[int](v, "server.port")
+//
+// Unmarshal and UnmarshalKey decode into structs with Option fields, which
+// are None for the keys that are not set:
+//
+//	type Config struct {
+//		Port    opt.Option[int]           `mapstructure:"port"`
+//		Timeout opt.Option[time.Duration] `mapstructure:"timeout"`
+//	}
+//	var cfg Config
+//	err := optviper.UnmarshalKey(v, "server", &cfg)
+package optviper
+
+import (
+	"code.nkcmr.net/opt"
+	"code.nkcmr.net/opt/optmapstructure"
+	"github.com/spf13/viper"
+)
+
+// DecodeHook returns a viper.DecoderConfigOption that lets viper decode into
+// Options. It replaces viper's default hooks with optmapstructure.DecodeHook,
+// which includes them.
+func DecodeHook() viper.DecoderConfigOption {
+	return viper.DecodeHook(optmapstructure.DecodeHook())
+}
+
+// Get returns the value of key decoded as a T, or None if the key is not set
+// in v, according to v.IsSet. Like the getters of viper, it ignores values
+// that cannot be decoded, returning None for them; use GetE to find out why.
+func Get[T any](v *viper.Viper, key string) opt.Option[T] {
+	o, _ := GetE[T](v, key)
+	return o
+}
+
+// GetE is like Get, but returns an error if the value of key cannot be
+// decoded as a T.
+func GetE[T any](v *viper.Viper, key string) (opt.Option[T], error) {
+	if !v.IsSet(key) {
+		return opt.None[T](), nil
+	}
+	var x T
+	if err := v.UnmarshalKey(key, &x, DecodeHook()); err != nil {
+		return opt.None[T](), err
+	}
+	return opt.Some(x), nil
+}
+
+// Unmarshal is v.Unmarshal with DecodeHook prepended to opts.
+func Unmarshal(v *viper.Viper, rawVal any, opts ...viper.DecoderConfigOption) error {
+	return v.Unmarshal(rawVal, append([]viper.DecoderConfigOption{DecodeHook()}, opts...)...)
+}
+
+// UnmarshalKey is v.UnmarshalKey with DecodeHook prepended to opts.
+func UnmarshalKey(v *viper.Viper, key string, rawVal any, opts ...viper.DecoderConfigOption) error {
+	return v.UnmarshalKey(key, rawVal, append([]viper.DecoderConfigOption{DecodeHook()}, opts...)...)
+}
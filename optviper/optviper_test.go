@@ -0,0 +1,83 @@
+package optviper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"code.nkcmr.net/opt"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const config = `
+server:
+  host: ""
+  port: 8080
+  timeout: 5s
+database:
+  host: db
+  port: "5432"
+`
+
+func newViper(t *testing.T) *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(strings.NewReader(config)))
+	return v
+}
+
+func TestGet(t *testing.T) {
+	v := newViper(t)
+	v.SetDefault("server.workers", 4)
+
+	require.Equal(t, opt.Some(""), Get[string](v, "server.host"))
+	require.Equal(t, opt.Some(8080), Get[int](v, "server.port"))
+	require.Equal(t, opt.Some(5*time.Second), Get[time.Duration](v, "server.timeout"))
+	require.Equal(t, opt.Some(4), Get[int](v, "server.workers"))
+	require.True(t, Get[int](v, "server.missing").None())
+	require.True(t, Get[int](v, "database.host").None())
+
+	_, err := GetE[int](v, "database.host")
+	require.Error(t, err)
+
+	type Database struct {
+		Host string             `mapstructure:"host"`
+		Port opt.Option[int]    `mapstructure:"port"`
+		User opt.Option[string] `mapstructure:"user"`
+	}
+	require.Equal(t, opt.Some(Database{Host: "db", Port: opt.Some(5432)}), Get[Database](v, "database"))
+}
+
+func TestUnmarshal(t *testing.T) {
+	type Server struct {
+		Host    opt.Option[string]        `mapstructure:"host"`
+		Port    opt.Option[int]           `mapstructure:"port"`
+		Timeout opt.Option[time.Duration] `mapstructure:"timeout"`
+		Workers opt.Option[int]           `mapstructure:"workers"`
+	}
+	type Config struct {
+		Server   Server `mapstructure:"server"`
+		Database opt.Option[struct {
+			Host string `mapstructure:"host"`
+		}] `mapstructure:"database"`
+		Cache opt.Option[struct {
+			Size int `mapstructure:"size"`
+		}] `mapstructure:"cache"`
+	}
+
+	v := newViper(t)
+	var cfg Config
+	require.NoError(t, Unmarshal(v, &cfg))
+	require.Equal(t, Server{
+		Host:    opt.Some(""),
+		Port:    opt.Some(8080),
+		Timeout: opt.Some(5 * time.Second),
+	}, cfg.Server)
+	require.Equal(t, "db", cfg.Database.Unwrap().Host)
+	require.True(t, cfg.Cache.None())
+
+	var server Server
+	require.NoError(t, UnmarshalKey(v, "server", &server))
+	require.Equal(t, cfg.Server, server)
+}
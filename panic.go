@@ -0,0 +1,56 @@
+package opt
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// FromPanic calls fn and returns Some of its result, or None and the value
+// passed to panic if fn panics. It makes calls to code that panics on bad
+// input safe to make:
+//
+//	re, r := opt.FromPanic(func() *regexp.Regexp { return regexp.MustCompile(expr) })
+//	if r != nil {
+//		log.Printf("bad expression %q: %v", expr, r)
+//	}
+//
+// A call to runtime.Goexit in fn is not recovered.
+func FromPanic[T any](fn func() T) (result Option[T], recovered any) {
+	defer func() {
+		// A panic with a nil value is recovered as a *runtime.PanicNilError,
+		// so recover only returns nil if fn returned or called runtime.Goexit.
+		if recovered = recover(); recovered != nil {
+			result = None[T]()
+		}
+	}()
+	return Some(fn()), nil
+}
+
+var panicHandler atomic.Pointer[func(typeName string)]
+
+// SetPanicHandler sets a function to be called when Unwrap is called on a
+// None, just before it panics. It is given the type of the value Unwrap was
+// called on, such as "opt.Option[int]", and can be used to count or log
+// unwrap failures before the panic unwinds the stack; a stack trace taken in
+// it still shows the call to Unwrap. When the handler returns, Unwrap panics
+// as usual.
+//
+// Passing nil removes the handler. It is safe to call SetPanicHandler while
+// other goroutines are calling Unwrap.
+func SetPanicHandler(fn func(typeName string)) {
+	if fn == nil {
+		panicHandler.Store(nil)
+		return
+	}
+	panicHandler.Store(&fn)
+}
+
+// unwrapPanic calls the panic handler, if there is one, and returns the value
+// Unwrap on o panics with.
+func unwrapPanic(o any) string {
+	typeName := fmt.Sprintf("%T", o)
+	if fn := panicHandler.Load(); fn != nil {
+		(*fn)(typeName)
+	}
+	return typeName + ".Unwrap: no value to unwrap"
+}
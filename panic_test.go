@@ -0,0 +1,67 @@
+package opt
+
+import (
+	"errors"
+	"regexp"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPanic(t *testing.T) {
+	compile := func(expr string) func() *regexp.Regexp {
+		return func() *regexp.Regexp { return regexp.MustCompile(expr) }
+	}
+
+	re, r := FromPanic(compile("a+"))
+	require.Nil(t, r)
+	require.True(t, re.Some())
+
+	re, r = FromPanic(compile("a("))
+	require.NotNil(t, r)
+	require.True(t, re.None())
+
+	errBoom := errors.New("boom")
+	_, r = FromPanic(func() int { panic(errBoom) })
+	require.Equal(t, errBoom, r)
+
+	o, r := FromPanic(func() int { panic(nil) })
+	var pne *runtime.PanicNilError
+	require.ErrorAs(t, r.(error), &pne)
+	require.True(t, o.None())
+
+	n, r := FromPanic(func() *int { return nil })
+	require.Nil(t, r)
+	require.True(t, n.Some())
+}
+
+func TestFromPanicGoexit(t *testing.T) {
+	var wg sync.WaitGroup
+	returned := false
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		FromPanic(func() int { runtime.Goexit(); return 0 })
+		returned = true
+	}()
+	wg.Wait()
+	require.False(t, returned)
+}
+
+func TestSetPanicHandler(t *testing.T) {
+	var got []string
+	SetPanicHandler(func(typeName string) { got = append(got, typeName) })
+	defer SetPanicHandler(nil)
+
+	require.PanicsWithValue(t, "opt.Option[int].Unwrap: no value to unwrap", func() { None[int]().Unwrap() })
+	require.Panics(t, func() { Boxed[string]{}.Unwrap() })
+	require.Panics(t, func() { NonePtr[bool]().Unwrap() })
+	require.Equal(t, 1, Some(1).Unwrap())
+	require.Equal(t, []string{"opt.Option[int]", "opt.Boxed[string]", "opt.PtrOption[bool]"}, got)
+
+	SetPanicHandler(nil)
+	require.Panics(t, func() { None[int]().Unwrap() })
+	require.Len(t, got, 3)
+}
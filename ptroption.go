@@ -0,0 +1,163 @@
+package opt
+
+import "fmt"
+
+// PtrOption is an optional value held by pointer, with a nil pointer meaning
+// None. It is a single word, where an Option is the size of its value plus a
+// bool and padding, so it suits graph structures with many optional links:
+//
+//	type Node struct {
+//		Value  int
+//		Parent opt.PtrOption[Node]
+//	}
+//
+// PtrOption has the same methods as Option for checking and unwrapping its
+// value. Unlike an Option, it shares the value it holds: WrapPtr keeps the
+// pointer it is given and Ptr returns it, so changes made through the pointer
+// are seen by every copy of the PtrOption. Boxed also keeps its value behind a
+// pointer, but never shares it; PtrOption is for values that already live
+// behind pointers, such as the nodes of a graph.
+//
+// The zero PtrOption is None.
+type PtrOption[T any] struct {
+	p *T
+}
+
+// SomePtr returns a PtrOption holding a pointer to a copy of v.
+func SomePtr[T any](v T) PtrOption[T] {
+	return PtrOption[T]{p: &v}
+}
+
+// NonePtr returns a PtrOption that holds nothing.
+func NonePtr[T any]() PtrOption[T] {
+	return PtrOption[T]{}
+}
+
+// WrapPtr returns a PtrOption holding p, which is None if p is nil.
+func WrapPtr[T any](p *T) PtrOption[T] {
+	return PtrOption[T]{p: p}
+}
+
+// ToPtrOption converts o to a PtrOption holding a pointer to a copy of its
+// value.
+func ToPtrOption[T any](o Option[T]) PtrOption[T] {
+	return PtrOption[T]{p: ToPointer(o)}
+}
+
+// Option converts p to an Option holding a copy of its value.
+func (p PtrOption[T]) Option() Option[T] {
+	return FromPointer(p.p)
+}
+
+// Some reports whether p holds a value.
+func (p PtrOption[T]) Some() bool {
+	return p.p != nil
+}
+
+// None reports whether p holds nothing.
+func (p PtrOption[T]) None() bool {
+	return p.p == nil
+}
+
+// IsSome reports whether p holds a value. It is the same as Some, for use
+// through AnyOption.
+func (p PtrOption[T]) IsSome() bool {
+	return p.p != nil
+}
+
+// IsZero reports whether p is None, for encoders that honor "omitzero".
+func (p PtrOption[T]) IsZero() bool {
+	return p.p == nil
+}
+
+// Unwrap returns the value p holds. It panics if p is None.
+func (p PtrOption[T]) Unwrap() T {
+	if p.p != nil {
+		return *p.p
+	}
+	panic(unwrapPanic(p))
+}
+
+// UnwrapOr returns the value p holds, or v if p is None.
+func (p PtrOption[T]) UnwrapOr(v T) T {
+	if p.p == nil {
+		return v
+	}
+	return *p.p
+}
+
+// MaybeUnwrap returns the value p holds and true, or the zero value of T and
+// false if p is None.
+func (p PtrOption[T]) MaybeUnwrap() (T, bool) {
+	if p.p == nil {
+		var zv T
+		return zv, false
+	}
+	return *p.p, true
+}
+
+// UnwrapOrZero returns the value p holds, or the zero value of T if p is
+// None.
+func (p PtrOption[T]) UnwrapOrZero() T {
+	v, _ := p.MaybeUnwrap()
+	return v
+}
+
+// UnwrapAny returns the value p holds as an any, or nil if p is None.
+func (p PtrOption[T]) UnwrapAny() any {
+	if p.p == nil {
+		return nil
+	}
+	return *p.p
+}
+
+// SetAny replaces p with a PtrOption holding a pointer to a copy of v, or with
+// None if v is nil. v may also be an Option[T]. It returns an error, leaving p
+// as it is, if v cannot be assigned to a T.
+func (p *PtrOption[T]) SetAny(v any) error {
+	o, ok := fromAny[T](v)
+	if !ok {
+		return fmt.Errorf("opt: cannot set %T from a value of type %T", *p, v)
+	}
+	*p = ToPtrOption(o)
+	return nil
+}
+
+// Ptr returns the pointer p holds, or nil if p is None. Unlike Option.Ptr, it
+// does not copy the value.
+func (p PtrOption[T]) Ptr() *T {
+	return p.p
+}
+
+// ToSlice returns a slice holding the value p holds, or nil if p is None.
+func (p PtrOption[T]) ToSlice() []T {
+	if p.p == nil {
+		return nil
+	}
+	return []T{*p.p}
+}
+
+// String formats the value p holds as fmt.Sprint would, or returns an empty
+// string if p is None.
+func (p PtrOption[T]) String() string {
+	if p.p == nil {
+		return ""
+	}
+	return fmt.Sprint(*p.p)
+}
+
+// MarshalJSON implements json.Marshaler, encoding p as the Option it converts
+// to would be.
+func (p PtrOption[T]) MarshalJSON() ([]byte, error) {
+	return p.Option().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding null as None.
+func (p *PtrOption[T]) UnmarshalJSON(data []byte) error {
+	var o Option[T]
+	if err := o.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*p = ToPtrOption(o)
+	return nil
+}
@@ -0,0 +1,69 @@
+package opt
+
+import (
+	"encoding/json"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPtrOption(t *testing.T) {
+	var n PtrOption[int]
+	require.True(t, n.None())
+	require.False(t, n.Some())
+	require.True(t, n.IsZero())
+	require.Equal(t, 7, n.UnwrapOr(7))
+	require.Equal(t, 0, n.UnwrapOrZero())
+	require.Nil(t, n.UnwrapAny())
+	require.Nil(t, n.Ptr())
+	require.Nil(t, n.ToSlice())
+	require.Equal(t, "", n.String())
+	require.Equal(t, None[int](), n.Option())
+	require.Equal(t, n, NonePtr[int]())
+	require.PanicsWithValue(t, "opt.PtrOption[int].Unwrap: no value to unwrap", func() { n.Unwrap() })
+
+	s := SomePtr(42)
+	require.True(t, s.Some())
+	require.True(t, s.IsSome())
+	require.False(t, s.IsZero())
+	require.Equal(t, 42, s.Unwrap())
+	require.Equal(t, 42, s.UnwrapOr(7))
+	require.Equal(t, 42, s.UnwrapAny())
+	require.Equal(t, []int{42}, s.ToSlice())
+	require.Equal(t, "42", s.String())
+	require.Equal(t, Some(42), s.Option())
+	require.Equal(t, s, ToPtrOption(Some(42)))
+	require.Equal(t, n, ToPtrOption(None[int]()))
+
+	require.Equal(t, unsafe.Sizeof(uintptr(0)), unsafe.Sizeof(s))
+}
+
+func TestPtrOptionShares(t *testing.T) {
+	type node struct {
+		value  int
+		parent PtrOption[node]
+	}
+	root := &node{value: 1}
+	child := node{value: 2, parent: WrapPtr(root)}
+	root.value = 10
+	require.Equal(t, 10, child.parent.Unwrap().value)
+	require.Same(t, root, child.parent.Ptr())
+	require.True(t, WrapPtr[node](nil).None())
+}
+
+func TestPtrOptionJSON(t *testing.T) {
+	type doc struct {
+		A PtrOption[string] `json:"a"`
+		B PtrOption[string] `json:"b"`
+	}
+	out, err := json.Marshal(doc{A: SomePtr("x")})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":"x","b":null}`, string(out))
+
+	var d doc
+	require.NoError(t, json.Unmarshal([]byte(`{"a":null,"b":"y"}`), &d))
+	require.True(t, d.A.None())
+	require.Equal(t, "y", d.B.Unwrap())
+	require.Error(t, json.Unmarshal([]byte(`{"a":1}`), &d))
+}
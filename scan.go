@@ -0,0 +1,50 @@
+package opt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NoneToken is the token ScanInto reads as None.
+const NoneToken = "-"
+
+// ScanInto returns a fmt.Scanner that stores what it scans in o, so Options
+// can be read with fmt.Sscan, fmt.Fscan and friends. Option cannot implement
+// fmt.Scanner itself because its Scan method is taken by sql.Scanner.
+//
+// The scanner reads one space-separated token. NoneToken, or no token at all
+// at the end of the input, is scanned as None; anything else is scanned into
+// a T with the same verb, so T may implement fmt.Scanner itself. The whole
+// token must be scanned into the T, so "12abc" is an error for an Option[int]:
+//
+//	var name string
+//	var age opt.Option[int]
+//	_, err := fmt.Sscan("ann -", &name, opt.ScanInto(&age))
+func ScanInto[T any](o *Option[T]) fmt.Scanner {
+	return optionScanner[T]{o: o}
+}
+
+type optionScanner[T any] struct {
+	o *Option[T]
+}
+
+func (s optionScanner[T]) Scan(state fmt.ScanState, verb rune) error {
+	tok, err := state.Token(true, nil)
+	if err != nil {
+		return err
+	}
+	if len(tok) == 0 || string(tok) == NoneToken {
+		*s.o = None[T]()
+		return nil
+	}
+	var v T
+	r := strings.NewReader(string(tok))
+	if _, err := fmt.Fscanf(r, "%"+string(verb), &v); err != nil {
+		return fmt.Errorf("opt: scan %q: %w", tok, err)
+	}
+	if r.Len() > 0 {
+		return fmt.Errorf("opt: scan %q: unexpected %q after value", tok, string(tok[len(tok)-r.Len():]))
+	}
+	*s.o = Some(v)
+	return nil
+}
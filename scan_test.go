@@ -0,0 +1,44 @@
+package opt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanInto(t *testing.T) {
+	var (
+		name  string
+		age   Option[int]
+		score Option[float64]
+		nick  Option[string]
+	)
+	n, err := fmt.Sscan("ann 30 - bobby", &name, ScanInto(&age), ScanInto(&score), ScanInto(&nick))
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, "ann", name)
+	require.Equal(t, Some(30), age)
+	require.Equal(t, None[float64](), score)
+	require.Equal(t, Some("bobby"), nick)
+
+	_, err = fmt.Sscanf("ff", "%x", ScanInto(&age))
+	require.NoError(t, err)
+	require.Equal(t, Some(255), age)
+
+	age = Some(1)
+	_, err = fmt.Sscanf("age=", "age=%v", ScanInto(&age))
+	require.NoError(t, err)
+	require.Equal(t, None[int](), age)
+
+	_, err = fmt.Sscan("x", ScanInto(&age))
+	require.ErrorContains(t, err, `opt: scan "x"`)
+
+	age = Some(1)
+	_, err = fmt.Sscan("12abc", ScanInto(&age))
+	require.ErrorContains(t, err, `opt: scan "12abc": unexpected "abc" after value`)
+	require.Equal(t, Some(1), age)
+
+	_, err = fmt.Sscan("1.5", ScanInto(&age))
+	require.Error(t, err)
+}
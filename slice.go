@@ -0,0 +1,88 @@
+package opt
+
+// Slice is a list of Options stored as a slice of values and a bitmap of which
+// of them are Some. A []Option[T] spends a bool and its padding on every
+// element, up to 8 bytes for an int64; a Slice spends one bit, which matters
+// for tables of millions of rows held in memory.
+//
+// The zero Slice is empty and ready to use. Like a slice, a Slice must not be
+// used from several goroutines at once while it is being modified.
+type Slice[T any] struct {
+	values []T
+	valid  []uint64
+}
+
+// SliceOf returns a Slice holding os.
+func SliceOf[T any](os []Option[T]) *Slice[T] {
+	s := &Slice[T]{
+		values: make([]T, 0, len(os)),
+		valid:  make([]uint64, 0, (len(os)+63)/64),
+	}
+	s.Append(os...)
+	return s
+}
+
+// Len returns the number of Options in s.
+func (s *Slice[T]) Len() int {
+	return len(s.values)
+}
+
+// Get returns the Option at index i. It panics if i is out of range.
+func (s *Slice[T]) Get(i int) Option[T] {
+	v := s.values[i]
+	if s.valid[i/64]&(1<<(i%64)) == 0 {
+		return None[T]()
+	}
+	return Some(v)
+}
+
+// Set replaces the Option at index i with o. It panics if i is out of range.
+func (s *Slice[T]) Set(i int, o Option[T]) {
+	var zero T
+	s.values[i] = zero // so that None does not keep a stale value alive
+	if v, ok := o.MaybeUnwrap(); ok {
+		s.values[i] = v
+		s.valid[i/64] |= 1 << (i % 64)
+	} else {
+		s.valid[i/64] &^= 1 << (i % 64)
+	}
+}
+
+// Append adds os to the end of s.
+func (s *Slice[T]) Append(os ...Option[T]) {
+	for _, o := range os {
+		i := len(s.values)
+		if i%64 == 0 {
+			s.valid = append(s.valid, 0)
+		}
+		var zero T
+		s.values = append(s.values, zero)
+		s.Set(i, o)
+	}
+}
+
+// Iter returns a function that calls yield with the index and Option of each
+// element of s in order, until yield returns false. From Go 1.23 it can be
+// ranged over:
+//
+//	for i, o := range s.Iter() {
+//		...
+//	}
+func (s *Slice[T]) Iter() func(yield func(int, Option[T]) bool) {
+	return func(yield func(int, Option[T]) bool) {
+		for i := range s.values {
+			if !yield(i, s.Get(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Options returns the Options in s as a newly allocated []Option[T].
+func (s *Slice[T]) Options() []Option[T] {
+	out := make([]Option[T], len(s.values))
+	for i := range out {
+		out[i] = s.Get(i)
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package opt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlice(t *testing.T) {
+	var s Slice[int]
+	require.Equal(t, 0, s.Len())
+	require.Empty(t, s.Options())
+
+	var want []Option[int]
+	for i := 0; i < 200; i++ {
+		o := Some(i)
+		if i%3 == 0 {
+			o = None[int]()
+		}
+		s.Append(o)
+		want = append(want, o)
+	}
+	require.Equal(t, 200, s.Len())
+	require.Equal(t, want, s.Options())
+	require.Equal(t, None[int](), s.Get(0))
+	require.Equal(t, Some(199), s.Get(199))
+
+	s.Set(0, Some(-1))
+	s.Set(199, None[int]())
+	require.Equal(t, Some(-1), s.Get(0))
+	require.Equal(t, None[int](), s.Get(199))
+	require.Equal(t, 0, s.values[199])
+
+	require.Equal(t, want[1:], SliceOf(want[1:]).Options())
+	require.Panics(t, func() { s.Get(200) })
+	require.Panics(t, func() { s.Set(-1, None[int]()) })
+}
+
+func TestSliceIter(t *testing.T) {
+	s := SliceOf([]Option[string]{Some("a"), None[string](), Some("c")})
+	var got []Option[string]
+	s.Iter()(func(i int, o Option[string]) bool {
+		require.Equal(t, len(got), i)
+		got = append(got, o)
+		return true
+	})
+	require.Equal(t, s.Options(), got)
+
+	n := 0
+	s.Iter()(func(int, Option[string]) bool {
+		n++
+		return false
+	})
+	require.Equal(t, 1, n)
+}
+
+func BenchmarkSliceMemory(b *testing.B) {
+	const n = 1 << 16
+	b.Run("[]Option", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			os := make([]Option[int64], 0, n)
+			for j := 0; j < n; j++ {
+				os = append(os, Some(int64(j)))
+			}
+		}
+	})
+	b.Run("Slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s := &Slice[int64]{values: make([]int64, 0, n), valid: make([]uint64, 0, n/64)}
+			for j := 0; j < n; j++ {
+				s.Append(Some(int64(j)))
+			}
+		}
+	})
+}
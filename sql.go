@@ -0,0 +1,130 @@
+package opt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"time"
+)
+
+// FromSQLNull converts a sql.Null[T] to an Option[T]. A valid value becomes
+// Some, an invalid one becomes None.
+func FromSQLNull[T any](n sql.Null[T]) Option[T] {
+	return FromMaybe(n.V, n.Valid)
+}
+
+// ToSQLNull converts an Option[T] to a sql.Null[T], which is only valid if the
+// Option is Some.
+func ToSQLNull[T any](o Option[T]) sql.Null[T] {
+	v, ok := o.MaybeUnwrap()
+	return sql.Null[T]{V: v, Valid: ok}
+}
+
+// FromNullString converts a sql.NullString to an Option[string].
+func FromNullString(n sql.NullString) Option[string] {
+	return FromMaybe(n.String, n.Valid)
+}
+
+// ToNullString converts an Option[string] to a sql.NullString.
+func ToNullString(o Option[string]) sql.NullString {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullString{String: v, Valid: ok}
+}
+
+// FromNullInt64 converts a sql.NullInt64 to an Option[int64].
+func FromNullInt64(n sql.NullInt64) Option[int64] {
+	return FromMaybe(n.Int64, n.Valid)
+}
+
+// ToNullInt64 converts an Option[int64] to a sql.NullInt64.
+func ToNullInt64(o Option[int64]) sql.NullInt64 {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullInt64{Int64: v, Valid: ok}
+}
+
+// FromNullInt32 converts a sql.NullInt32 to an Option[int32].
+func FromNullInt32(n sql.NullInt32) Option[int32] {
+	return FromMaybe(n.Int32, n.Valid)
+}
+
+// ToNullInt32 converts an Option[int32] to a sql.NullInt32.
+func ToNullInt32(o Option[int32]) sql.NullInt32 {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullInt32{Int32: v, Valid: ok}
+}
+
+// FromNullInt16 converts a sql.NullInt16 to an Option[int16].
+func FromNullInt16(n sql.NullInt16) Option[int16] {
+	return FromMaybe(n.Int16, n.Valid)
+}
+
+// ToNullInt16 converts an Option[int16] to a sql.NullInt16.
+func ToNullInt16(o Option[int16]) sql.NullInt16 {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullInt16{Int16: v, Valid: ok}
+}
+
+// FromNullByte converts a sql.NullByte to an Option[byte].
+func FromNullByte(n sql.NullByte) Option[byte] {
+	return FromMaybe(n.Byte, n.Valid)
+}
+
+// ToNullByte converts an Option[byte] to a sql.NullByte.
+func ToNullByte(o Option[byte]) sql.NullByte {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullByte{Byte: v, Valid: ok}
+}
+
+// FromNullFloat64 converts a sql.NullFloat64 to an Option[float64].
+func FromNullFloat64(n sql.NullFloat64) Option[float64] {
+	return FromMaybe(n.Float64, n.Valid)
+}
+
+// ToNullFloat64 converts an Option[float64] to a sql.NullFloat64.
+func ToNullFloat64(o Option[float64]) sql.NullFloat64 {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullFloat64{Float64: v, Valid: ok}
+}
+
+// FromNullBool converts a sql.NullBool to an Option[bool].
+func FromNullBool(n sql.NullBool) Option[bool] {
+	return FromMaybe(n.Bool, n.Valid)
+}
+
+// ToNullBool converts an Option[bool] to a sql.NullBool.
+func ToNullBool(o Option[bool]) sql.NullBool {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullBool{Bool: v, Valid: ok}
+}
+
+// FromNullTime converts a sql.NullTime to an Option[time.Time].
+func FromNullTime(n sql.NullTime) Option[time.Time] {
+	return FromMaybe(n.Time, n.Valid)
+}
+
+// ToNullTime converts an Option[time.Time] to a sql.NullTime.
+func ToNullTime(o Option[time.Time]) sql.NullTime {
+	v, ok := o.MaybeUnwrap()
+	return sql.NullTime{Time: v, Valid: ok}
+}
+
+// Value implements driver.Valuer. None is stored as NULL, a Some value is
+// converted by driver.DefaultParameterConverter, which defers to the contained
+// value if it implements driver.Valuer itself.
+func (o Option[T]) Value() (driver.Value, error) {
+	if !o.ok {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.v)
+}
+
+// Scan implements sql.Scanner. NULL is scanned as None, anything else is
+// converted just like database/sql would when scanning into a *T, including
+// deferring to T if it implements sql.Scanner.
+func (o *Option[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*o = FromMaybe(n.V, n.Valid)
+	return nil
+}
@@ -0,0 +1,114 @@
+package opt
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testValuer struct {
+	s string
+}
+
+func (v testValuer) Value() (driver.Value, error) {
+	return "valuer:" + v.s, nil
+}
+
+func (v *testValuer) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return errors.New("not a string")
+	}
+	v.s = s
+	return nil
+}
+
+func TestSQL(t *testing.T) {
+	var (
+		_ driver.Valuer = Option[int]{}
+		_ sql.Scanner   = &Option[int]{}
+	)
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := None[int]().Value()
+		require.NoError(t, err)
+		require.Nil(t, v)
+
+		v, err = Some(int(5)).Value()
+		require.NoError(t, err)
+		require.Equal(t, int64(5), v)
+
+		type myString string
+		v, err = Some(myString("x")).Value()
+		require.NoError(t, err)
+		require.Equal(t, "x", v)
+
+		v, err = Some(testValuer{s: "x"}).Value()
+		require.NoError(t, err)
+		require.Equal(t, "valuer:x", v)
+
+		_, err = Some(struct{}{}).Value()
+		require.Error(t, err)
+	})
+	t.Run("Scan", func(t *testing.T) {
+		i := Some(int(1))
+		require.NoError(t, i.Scan(nil))
+		require.True(t, i.None())
+
+		require.NoError(t, i.Scan(int64(7)))
+		require.Equal(t, Some(int(7)), i)
+
+		require.NoError(t, i.Scan([]byte("8")))
+		require.Equal(t, Some(int(8)), i)
+
+		require.Error(t, i.Scan("nope"))
+
+		var s Option[string]
+		require.NoError(t, s.Scan([]byte("hi")))
+		require.Equal(t, Some("hi"), s)
+
+		var ts Option[time.Time]
+		now := time.Now()
+		require.NoError(t, ts.Scan(now))
+		require.Equal(t, Some(now), ts)
+
+		var tv Option[testValuer]
+		require.NoError(t, tv.Scan("x"))
+		require.Equal(t, Some(testValuer{s: "x"}), tv)
+		require.Error(t, tv.Scan(int64(1)))
+	})
+}
+
+func TestSQLNull(t *testing.T) {
+	require.Equal(t, Some(int(3)), FromSQLNull(sql.Null[int]{V: 3, Valid: true}))
+	require.Equal(t, None[int](), FromSQLNull(sql.Null[int]{V: 3}))
+	require.Equal(t, sql.Null[int]{V: 3, Valid: true}, ToSQLNull(Some(int(3))))
+	require.Equal(t, sql.Null[int]{}, ToSQLNull(None[int]()))
+
+	now := time.Now()
+	require.Equal(t, Some("a"), FromNullString(sql.NullString{String: "a", Valid: true}))
+	require.Equal(t, Some(int64(1)), FromNullInt64(sql.NullInt64{Int64: 1, Valid: true}))
+	require.Equal(t, Some(int32(1)), FromNullInt32(sql.NullInt32{Int32: 1, Valid: true}))
+	require.Equal(t, Some(int16(1)), FromNullInt16(sql.NullInt16{Int16: 1, Valid: true}))
+	require.Equal(t, Some(byte(1)), FromNullByte(sql.NullByte{Byte: 1, Valid: true}))
+	require.Equal(t, Some(float64(1)), FromNullFloat64(sql.NullFloat64{Float64: 1, Valid: true}))
+	require.Equal(t, Some(true), FromNullBool(sql.NullBool{Bool: true, Valid: true}))
+	require.Equal(t, Some(now), FromNullTime(sql.NullTime{Time: now, Valid: true}))
+	require.True(t, FromNullString(sql.NullString{String: "a"}).None())
+	require.True(t, FromNullTime(sql.NullTime{}).None())
+
+	require.Equal(t, sql.NullString{String: "a", Valid: true}, ToNullString(Some("a")))
+	require.Equal(t, sql.NullInt64{Int64: 1, Valid: true}, ToNullInt64(Some(int64(1))))
+	require.Equal(t, sql.NullInt32{Int32: 1, Valid: true}, ToNullInt32(Some(int32(1))))
+	require.Equal(t, sql.NullInt16{Int16: 1, Valid: true}, ToNullInt16(Some(int16(1))))
+	require.Equal(t, sql.NullByte{Byte: 1, Valid: true}, ToNullByte(Some(byte(1))))
+	require.Equal(t, sql.NullFloat64{Float64: 1, Valid: true}, ToNullFloat64(Some(float64(1))))
+	require.Equal(t, sql.NullBool{Bool: true, Valid: true}, ToNullBool(Some(true)))
+	require.Equal(t, sql.NullTime{Time: now, Valid: true}, ToNullTime(Some(now)))
+	require.Equal(t, sql.NullString{}, ToNullString(None[string]()))
+	require.Equal(t, sql.NullTime{}, ToNullTime(None[time.Time]()))
+}
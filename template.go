@@ -0,0 +1,79 @@
+package opt
+
+import (
+	"reflect"
+	"text/template"
+)
+
+// TemplateFuncs returns functions that let text/template and html/template
+// templates work with Option fields without calling their methods directly:
+//
+//	isSome X       reports whether X is Some
+//	isNone X       reports whether X is None
+//	someOr D X     returns the value in X, or D if X is None
+//	deref X        returns the value in X, or nil if X is None
+//
+// X may be an Option, a pointer to one, or any other value; a nil pointer or
+// nil interface counts as None and any other non-Option value as Some of
+// itself. someOr reads well at the end of a pipeline:
+//
+//	{{ .Nickname | someOr "anonymous" }}
+//
+// The result may be passed to html/template's Funcs as it is, since
+// html/template.FuncMap is the same type.
+//
+// Note that templates consider every struct true, so {{ if .Nickname }} is
+// taken even when Nickname is None. Use {{ if isSome .Nickname }}, or call the
+// method as {{ if .Nickname.Some }}, instead. Likewise {{ .Nickname }} prints
+// the fields of the Option rather than its value; print
+// {{ .Nickname | someOr "" }} or {{ .Nickname.UnwrapOrZero }} instead.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"isSome": templateIsSome,
+		"isNone": templateIsNone,
+		"someOr": templateSomeOr,
+		"deref":  templateDeref,
+	}
+}
+
+// templateValue unwraps v for the functions returned by TemplateFuncs.
+func templateValue(v any) (any, bool) {
+	if v == nil {
+		return nil, false
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, false
+		}
+		if o, ok := v.(AnyOption); ok {
+			return o.UnwrapAny(), o.IsSome()
+		}
+		return rv.Elem().Interface(), true
+	}
+	if o, ok := v.(AnyOption); ok {
+		return o.UnwrapAny(), o.IsSome()
+	}
+	return v, true
+}
+
+func templateIsSome(v any) bool {
+	_, ok := templateValue(v)
+	return ok
+}
+
+func templateIsNone(v any) bool {
+	_, ok := templateValue(v)
+	return !ok
+}
+
+func templateSomeOr(fallback, v any) any {
+	if x, ok := templateValue(v); ok {
+		return x
+	}
+	return fallback
+}
+
+func templateDeref(v any) any {
+	x, _ := templateValue(v)
+	return x
+}
@@ -0,0 +1,107 @@
+package opt
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	type profile struct {
+		Name     string
+		Nickname Option[string]
+		Age      Option[int]
+		Bio      *Option[string]
+	}
+	const src = `{{ .Name }} ({{ .Nickname | someOr "anonymous" }})` +
+		`{{ if isSome .Age }}, {{ deref .Age }}{{ end }}` +
+		`{{ if isNone .Bio }}, no bio{{ else }}, {{ deref .Bio }}{{ end }}`
+
+	bio := Some("<b>hi</b>")
+	tests := []struct {
+		name    string
+		in      profile
+		text    string
+		escaped string
+	}{
+		{
+			name:    "none",
+			in:      profile{Name: "ann"},
+			text:    "ann (anonymous), no bio",
+			escaped: "ann (anonymous), no bio",
+		},
+		{
+			name:    "some",
+			in:      profile{Name: "bob", Nickname: Some("bobby"), Age: Some(0), Bio: &bio},
+			text:    "bob (bobby), 0, <b>hi</b>",
+			escaped: "bob (bobby), 0, &lt;b&gt;hi&lt;/b&gt;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			tmpl := template.Must(template.New("").Funcs(TemplateFuncs()).Parse(src))
+			require.NoError(t, tmpl.Execute(&sb, tt.in))
+			require.Equal(t, tt.text, sb.String())
+
+			sb.Reset()
+			htmpl := htmltemplate.Must(htmltemplate.New("").Funcs(TemplateFuncs()).Parse(src))
+			require.NoError(t, htmpl.Execute(&sb, tt.in))
+			require.Equal(t, tt.escaped, sb.String())
+		})
+	}
+}
+
+func TestTemplateValue(t *testing.T) {
+	n := 3
+	var nilOpt *Option[int]
+	tests := []struct {
+		in   any
+		want any
+		ok   bool
+	}{
+		{nil, nil, false},
+		{None[int](), nil, false},
+		{Some(1), 1, true},
+		{nilOpt, nil, false},
+		{&n, 3, true},
+		{(*int)(nil), nil, false},
+		{"x", "x", true},
+	}
+	for _, tt := range tests {
+		got, ok := templateValue(tt.in)
+		require.Equal(t, tt.ok, ok, "%#v", tt.in)
+		require.Equal(t, tt.want, got, "%#v", tt.in)
+	}
+}
+
+func TestTemplateRendering(t *testing.T) {
+	const src = `{{ .Name }}{{ with .Nickname.Ptr }} "{{ . }}"{{ end }}, {{ .Age.UnwrapOrZero }}`
+	type person struct {
+		Name     string
+		Nickname Option[string]
+		Age      Option[int]
+	}
+	tmpl := template.Must(template.New("").Parse(src))
+
+	var sb strings.Builder
+	require.NoError(t, tmpl.Execute(&sb, person{Name: "ann"}))
+	require.Equal(t, "ann, 0", sb.String())
+
+	sb.Reset()
+	require.NoError(t, tmpl.Execute(&sb, person{Name: "bob", Nickname: Some(""), Age: Some(0)}))
+	require.Equal(t, `bob "", 0`, sb.String())
+
+	// Options have no String method, so printing one shows the struct.
+	sb.Reset()
+	require.NoError(t, template.Must(template.New("").Parse(`{{ .Age }}`)).Execute(&sb, person{}))
+	require.Equal(t, "{0 false}", sb.String())
+}
+
+func TestPtr(t *testing.T) {
+	require.Nil(t, None[int]().Ptr())
+	require.Equal(t, 4, *Some(4).Ptr())
+}
@@ -0,0 +1,77 @@
+package opt
+
+import (
+	"fmt"
+	"reflect"
+
+	"code.nkcmr.net/opt/internal/textparse"
+)
+
+// formatText renders a simple value (or an encoding.TextMarshaler) as text. It
+// uses the same rules as the subpackages that read values from text, such as
+// optenv and optcli, so that what MarshalText writes they can read.
+func formatText(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return "", fmt.Errorf("opt: cannot encode %T as text", v)
+	}
+	return textparse.Format(rv)
+}
+
+// parseText is the inverse of formatText, storing the value parsed from s into
+// the value ptr points to.
+func parseText(ptr any, s string) error {
+	return textparse.Set(reflect.ValueOf(ptr).Elem(), s)
+}
+
+// MarshalText implements encoding.TextMarshaler. A None value is written as
+// empty text, which is what encoders with no way to leave a value out, such
+// as those for YAML, slog's TextHandler and JSON map keys, expect of it.
+// Empty text is read back as None by UnmarshalText, so a Some holding a value
+// that formats as empty text, such as Some(""), does not survive a round trip
+// through text.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if !o.ok {
+		return []byte{}, nil
+	}
+	s, err := formatText(o.v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text is None, as
+// for a query parameter or header that is present but has no value, and any
+// other text is parsed into a Some.
+//
+// Together with MarshalJSON and UnmarshalJSON this lets oapi-codegen's
+// `x-go-type` point at an Option for schema properties:
+//
+//	components:
+//	  schemas:
+//	    User:
+//	      properties:
+//	        nickname:
+//	          type: string
+//	          nullable: true
+//	          x-go-type: opt.Option[string]
+//	          x-go-type-import:
+//	            path: code.nkcmr.net/opt
+//	          x-go-type-skip-optional-pointer: true
+//
+// Parameters should keep the pointers oapi-codegen generates for them, since
+// its runtime only binds query parameters into structs through an interface
+// of its own; ToPointer and FromPointer bridge the two.
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = None[T]()
+		return nil
+	}
+	var v T
+	if err := parseText(&v, string(text)); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
@@ -0,0 +1,154 @@
+package opt
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	"github.com/stretchr/testify/require"
+
+	"code.nkcmr.net/opt/internal/textparse"
+)
+
+func TestText(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		b, err := Some(42).MarshalText()
+		require.NoError(t, err)
+		require.Equal(t, "42", string(b))
+
+		b, err = None[int]().MarshalText()
+		require.NoError(t, err)
+		require.NotNil(t, b)
+		require.Empty(t, b)
+
+		b, err = Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)).MarshalText()
+		require.NoError(t, err)
+		require.Equal(t, "2024-03-01T00:00:00Z", string(b))
+	})
+	t.Run("unmarshal", func(t *testing.T) {
+		o := Some(1)
+		require.NoError(t, o.UnmarshalText([]byte("42")))
+		require.Equal(t, Some(42), o)
+
+		require.Error(t, o.UnmarshalText([]byte("nope")))
+		require.NoError(t, o.UnmarshalText(nil))
+		require.True(t, o.None())
+
+		var s Option[string]
+		require.NoError(t, s.UnmarshalText([]byte("a")))
+		require.Equal(t, Some("a"), s)
+		require.NoError(t, s.UnmarshalText([]byte{}))
+		require.True(t, s.None())
+	})
+	t.Run("same as textparse", func(t *testing.T) {
+		// UnmarshalText reads text as the flag, environment and form
+		// subpackages do, which all use textparse.
+		for _, in := range []string{"16", " 0x10", "0o20\n", "1_6"} {
+			var o Option[int]
+			require.NoError(t, o.UnmarshalText([]byte(in)), in)
+			require.Equal(t, Some(16), o, in)
+			v, err := textparse.Parse[int](in)
+			require.NoError(t, err, in)
+			require.Equal(t, 16, v, in)
+		}
+
+		text, err := Some(90 * time.Second).MarshalText()
+		require.NoError(t, err)
+		require.Equal(t, "1m30s", string(text))
+		var d Option[time.Duration]
+		require.NoError(t, d.UnmarshalText(text))
+		require.Equal(t, Some(90*time.Second), d)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, in := range []Option[string]{None[string](), Some("a")} {
+			text, err := in.MarshalText()
+			require.NoError(t, err)
+			var out Option[string]
+			require.NoError(t, out.UnmarshalText(text))
+			require.Equal(t, in, out)
+		}
+
+		// Some("") is written as empty text too, which reads back as None.
+		text, err := Some("").MarshalText()
+		require.NoError(t, err)
+		var out Option[string]
+		require.NoError(t, out.UnmarshalText(text))
+		require.True(t, out.None())
+	})
+	t.Run("encoders", func(t *testing.T) {
+		// Encoders that only know about text write None as empty text
+		// rather than failing.
+		b, err := json.Marshal(map[Option[string]]int{None[string](): 1, Some("a"): 2})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"":1,"a":2}`, string(b))
+		var m map[Option[string]]int
+		require.NoError(t, json.Unmarshal(b, &m))
+		require.Equal(t, map[Option[string]]int{None[string](): 1, Some("a"): 2}, m)
+
+		var buf bytes.Buffer
+		slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		})).Info("hi", "limit", Some(10), "cursor", None[string]())
+		require.Equal(t, "level=INFO msg=hi limit=10 cursor=\"\"\n", buf.String())
+	})
+}
+
+func TestOapiCodegen(t *testing.T) {
+	t.Run("bind query", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "/?limit=10", nil)
+		require.NoError(t, err)
+
+		// The runtime only binds query parameters into structs through an
+		// interface of its own, so parameters keep the pointers generated
+		// for them and FromPointer turns those into Options.
+		var params struct {
+			Limit  *int
+			Offset *int
+		}
+		require.NoError(t, runtime.BindQueryParameter("form", true, false, "limit", req.URL.Query(), &params.Limit))
+		require.NoError(t, runtime.BindQueryParameter("form", true, false, "offset", req.URL.Query(), &params.Offset))
+		require.Equal(t, Some(10), FromPointer(params.Limit))
+		require.Equal(t, None[int](), FromPointer(params.Offset))
+	})
+	t.Run("bind path", func(t *testing.T) {
+		var id Option[string]
+		err := runtime.BindStyledParameterWithOptions("simple", "id", "a%20b", &id, runtime.BindStyledParameterOptions{
+			ParamLocation: runtime.ParamLocationPath,
+			Required:      true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, Some("a b"), id)
+	})
+	t.Run("style", func(t *testing.T) {
+		s, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, Some(10))
+		require.NoError(t, err)
+		require.Equal(t, "limit=10", s)
+
+		s, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, Some("a b"))
+		require.NoError(t, err)
+		require.Equal(t, "a%20b", s)
+	})
+	t.Run("json body", func(t *testing.T) {
+		type Body struct {
+			Name Option[string] `json:"name"`
+			Note Option[string] `json:"note,omitempty"`
+		}
+		var b Body
+		require.NoError(t, json.Unmarshal([]byte(`{"name":null}`), &b))
+		require.True(t, b.Name.None())
+		require.True(t, b.Note.None())
+
+		out, err := json.Marshal(Body{Name: Some("a")})
+		require.NoError(t, err)
+		require.JSONEq(t, `{"name":"a","note":null}`, string(out))
+	})
+}
@@ -0,0 +1,73 @@
+package opt
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	burntsushi "github.com/BurntSushi/toml"
+	"github.com/pelletier/go-toml/v2"
+
+	"code.nkcmr.net/opt/internal/shadow"
+)
+
+// tomlMapper mirrors the values Options hold for go-toml, which leaves out
+// nil pointers but not Options nested in them.
+var tomlMapper = &shadow.Mapper{}
+
+// tomlValue holds a value so that it can be decoded from a TOML document,
+// which only has tables at the top level.
+type tomlValue[T any] struct {
+	V T `toml:"v"`
+}
+
+// MarshalTOML implements the Marshaler interfaces of github.com/BurntSushi/toml
+// and github.com/pelletier/go-toml/v2. A Some value is written as the TOML
+// value go-toml would write for it, with tables written inline. TOML has no
+// null, so None is written as nothing at all, which go-toml takes to mean that
+// the key is left out. go-toml only calls MarshalTOML if its Encoder had
+// EnableMarshalerInterface called on it, and writes Options as strings with
+// MarshalText otherwise:
+//
+//	err := toml.NewEncoder(w).EnableMarshalerInterface().Encode(cfg)
+//
+// BurntSushi/toml writes the key regardless, so Option fields encoded with it
+// must be tagged with "omitempty", which leaves None values out.
+func (o Option[T]) MarshalTOML() ([]byte, error) {
+	if !o.ok {
+		return []byte{}, nil
+	}
+	var buf bytes.Buffer
+	v := tomlMapper.To(reflect.ValueOf(&o.v).Elem()).Interface()
+	err := toml.NewEncoder(&buf).SetTablesInline(true).Encode(map[string]any{"v": v})
+	if err != nil {
+		return nil, err
+	}
+	text, ok := bytes.CutPrefix(bytes.TrimSpace(buf.Bytes()), []byte("v = "))
+	if !ok {
+		return nil, fmt.Errorf("opt: cannot encode %T as a TOML value", o.v)
+	}
+	return text, nil
+}
+
+// UnmarshalTOML implements the Unmarshaler interface of
+// github.com/BurntSushi/toml, decoding data, the value BurntSushi/toml read
+// for the key, into a Some. A key that is absent leaves the Option as it was,
+// so None unless it was set before decoding.
+//
+// go-toml has an Unmarshaler interface of its own whose method has the same
+// name, so it cannot be implemented as well. go-toml instead decodes strings,
+// numbers and booleans into Options with UnmarshalText, so an empty string is
+// None; tables, arrays and date-times need the functions of opttoml.
+func (o *Option[T]) UnmarshalTOML(data any) error {
+	var buf bytes.Buffer
+	if err := burntsushi.NewEncoder(&buf).Encode(map[string]any{"v": data}); err != nil {
+		return err
+	}
+	var v tomlValue[T]
+	if _, err := burntsushi.Decode(buf.String(), &v); err != nil {
+		return err
+	}
+	*o = Some(v.V)
+	return nil
+}
@@ -0,0 +1,97 @@
+package opt
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	burntsushi "github.com/BurntSushi/toml"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type tomlProxy struct {
+	Host string      `toml:"host"`
+	Port Option[int] `toml:"port"`
+}
+
+type tomlConfig struct {
+	Name  Option[string]    `toml:"name,omitempty"`
+	Count Option[int]       `toml:"count,omitempty"`
+	Ratio Option[float64]   `toml:"ratio,omitempty"`
+	On    Option[bool]      `toml:"on,omitempty"`
+	At    Option[time.Time] `toml:"at,omitempty"`
+	Tags  Option[[]string]  `toml:"tags,omitempty"`
+	Proxy Option[tomlProxy] `toml:"proxy,omitempty"`
+}
+
+var tomlFull = tomlConfig{
+	Name:  Some("beep"),
+	Count: Some(0),
+	Ratio: Some(1.5),
+	On:    Some(false),
+	At:    Some(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)),
+	Tags:  Some([]string{"a", "b"}),
+	Proxy: Some(tomlProxy{Host: "localhost"}),
+}
+
+const tomlFullDoc = `name = 'beep'
+count = 0
+ratio = 1.5
+on = false
+at = 2024-03-01T12:30:00Z
+tags = ['a', 'b']
+proxy = {host = 'localhost'}
+`
+
+func TestTOML(t *testing.T) {
+	t.Run("BurntSushi", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, burntsushi.NewEncoder(&buf).Encode(tomlFull))
+		require.Equal(t, tomlFullDoc, buf.String())
+
+		var out tomlConfig
+		_, err := burntsushi.Decode(buf.String(), &out)
+		require.NoError(t, err)
+		require.Equal(t, tomlFull, out)
+
+		buf.Reset()
+		require.NoError(t, burntsushi.NewEncoder(&buf).Encode(tomlConfig{}))
+		require.Empty(t, buf.String())
+
+		out = tomlConfig{Name: Some("kept")}
+		_, err = burntsushi.Decode("[proxy]\nhost = 'h'\nport = 80\n", &out)
+		require.NoError(t, err)
+		require.Equal(t, tomlConfig{
+			Name:  Some("kept"),
+			Proxy: Some(tomlProxy{Host: "h", Port: Some(80)}),
+		}, out)
+
+		_, err = burntsushi.Decode("count = 'nope'", &out)
+		require.Error(t, err)
+	})
+	t.Run("go-toml", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, toml.NewEncoder(&buf).EnableMarshalerInterface().Encode(tomlFull))
+		require.Equal(t, tomlFullDoc, buf.String())
+
+		// None values are left out without the need for tags.
+		type untagged struct {
+			Name  Option[string]
+			Count Option[int]
+		}
+		buf.Reset()
+		require.NoError(t, toml.NewEncoder(&buf).EnableMarshalerInterface().Encode(untagged{Count: Some(1)}))
+		require.Equal(t, "Count = 1\n", buf.String())
+
+		// Strings, numbers and booleans are decoded with UnmarshalText.
+		var out tomlConfig
+		require.NoError(t, toml.Unmarshal([]byte("name = 'beep'\ncount = 0x10\nratio = 1.5\non = true\n"), &out))
+		require.Equal(t, tomlConfig{
+			Name:  Some("beep"),
+			Count: Some(16),
+			Ratio: Some(1.5),
+			On:    Some(true),
+		}, out)
+	})
+}
@@ -0,0 +1,97 @@
+package opt
+
+import "errors"
+
+// Explained is an Option that can remember why it is None. The reason lives
+// next to the Option rather than in it, so Option itself stays the size of its
+// value plus a bool, and two Nones are always == to each other and encode the
+// same way no matter how they came about.
+//
+// Explained values are built with NoneBecause, NoneBecauseError and Explain,
+// and chained with MapExplained and JoinExplained, which carry the reason
+// along, so the end of a long chain can report where it went wrong:
+//
+//	email := opt.MapExplained(user, func(u User) opt.Explained[string] {
+//		if u.Email == "" {
+//			return opt.NoneBecause[string]("user has no email")
+//		}
+//		return opt.Explain(opt.Some(u.Email))
+//	})
+//	log.Printf("no email: %s", email.Why().UnwrapOr("unknown"))
+//
+// The zero Explained is a None with no reason.
+type Explained[T any] struct {
+	o   Option[T]
+	why error
+}
+
+// NoneBecause returns a None that remembers why there is no value.
+func NoneBecause[T any](reason string) Explained[T] {
+	return Explained[T]{why: errors.New(reason)}
+}
+
+// NoneBecauseError is like NoneBecause, but takes the reason from err. err is
+// not formatted until Why is called. A nil err gives a None with no reason.
+func NoneBecauseError[T any](err error) Explained[T] {
+	return Explained[T]{why: err}
+}
+
+// Explain returns o as an Explained with no reason.
+func Explain[T any](o Option[T]) Explained[T] {
+	return Explained[T]{o: o}
+}
+
+// Option returns e without its reason.
+func (e Explained[T]) Option() Option[T] {
+	return e.o
+}
+
+// Some reports whether e holds a value.
+func (e Explained[T]) Some() bool {
+	return e.o.ok
+}
+
+// None reports whether e holds nothing.
+func (e Explained[T]) None() bool {
+	return !e.o.ok
+}
+
+// Err returns the reason e is None as an error, or nil if e is Some or no
+// reason was given.
+func (e Explained[T]) Err() error {
+	if e.o.ok {
+		return nil
+	}
+	return e.why
+}
+
+// Why returns the reason given to NoneBecause or NoneBecauseError if e was
+// created by one of them, or derived from one by MapExplained or
+// JoinExplained. It returns None for Some and for any other None.
+func (e Explained[T]) Why() Option[string] {
+	if err := e.Err(); err != nil {
+		return Some(err.Error())
+	}
+	return None[string]()
+}
+
+// MapExplained is Map for Explained values: if in is None, so is the result,
+// and it carries the same reason.
+func MapExplained[I, O any](in Explained[I], mapfn func(I) Explained[O]) Explained[O] {
+	if in.o.ok {
+		return mapfn(in.o.v)
+	}
+	return Explained[O]{why: in.why}
+}
+
+// JoinExplained is Join for Explained values: if either of a and b is None,
+// so is the result, and it carries the reason of the first one that was None.
+func JoinExplained[A, B, R any](a Explained[A], b Explained[B], joinfn func(A, B) R) Explained[R] {
+	if !a.o.ok {
+		return Explained[R]{why: a.why}
+	}
+	if !b.o.ok {
+		return Explained[R]{why: b.why}
+	}
+	return Explained[R]{o: Some(joinfn(a.o.v, b.o.v))}
+}
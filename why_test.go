@@ -0,0 +1,60 @@
+package opt
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoneBecause(t *testing.T) {
+	require.Equal(t, None[string](), Explained[int]{}.Why())
+	require.Equal(t, None[string](), Explain(Some(1)).Why())
+	require.Equal(t, None[string](), Explain(None[int]()).Why())
+	require.Equal(t, Some("missing"), NoneBecause[int]("missing").Why())
+	require.True(t, NoneBecause[int]("missing").None())
+	require.Equal(t, None[int](), NoneBecause[int]("missing").Option())
+
+	errBoom := errors.New("boom")
+	require.Equal(t, Some("boom"), NoneBecauseError[int](errBoom).Why())
+	require.Equal(t, errBoom, NoneBecauseError[int](errBoom).Err())
+	require.Equal(t, None[string](), NoneBecauseError[int](nil).Why())
+}
+
+func TestWhyPropagates(t *testing.T) {
+	parse := func(s string) Explained[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return NoneBecauseError[int](err)
+		}
+		return Explain(Some(n))
+	}
+	double := func(n int) Explained[int] { return Explain(Some(n * 2)) }
+
+	o := MapExplained(MapExplained(parse("x"), double), double)
+	require.True(t, o.None())
+	require.Equal(t, Some(`strconv.Atoi: parsing "x": invalid syntax`), o.Why())
+	require.Equal(t, Some(8), MapExplained(MapExplained(parse("2"), double), double).Option())
+
+	add := func(a, b int) int { return a + b }
+	j := JoinExplained(Explain(Some(1)), NoneBecause[int]("b"), add)
+	require.Equal(t, Some("b"), j.Why())
+	j = JoinExplained(NoneBecause[int]("a"), NoneBecause[int]("b"), add)
+	require.Equal(t, Some("a"), j.Why())
+	require.Equal(t, Some(3), JoinExplained(Explain(Some(1)), Explain(Some(2)), add).Option())
+}
+
+func TestOptionSize(t *testing.T) {
+	// Reasons are kept in Explained, so an Option is no bigger than its value
+	// and a bool.
+	require.Equal(t, unsafe.Sizeof(struct {
+		v  int64
+		ok bool
+	}{}), unsafe.Sizeof(Some[int64](0)))
+	require.Equal(t, unsafe.Sizeof(struct {
+		v  bool
+		ok bool
+	}{}), unsafe.Sizeof(Some(true)))
+}
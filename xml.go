@@ -0,0 +1,70 @@
+package opt
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// xmlSchemaInstance is the namespace of the xsi:nil attribute used by SOAP and
+// XML Schema to mark an element as explicitly empty.
+const xmlSchemaInstance = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML implements xml.Marshaler. A None value writes nothing, so the
+// element is omitted from the document.
+func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !o.ok {
+		return nil
+	}
+	return e.EncodeElement(o.v, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. Elements that are missing from the
+// document are never decoded, so the Option is left as None. An element marked
+// with xsi:nil="true" is also decoded as None.
+func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && (attr.Name.Space == xmlSchemaInstance || attr.Name.Space == "xsi") {
+			if isNil, _ := strconv.ParseBool(attr.Value); isNil {
+				*o = None[T]()
+				return d.Skip()
+			}
+		}
+	}
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr. A None value produces no
+// attribute.
+func (o Option[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !o.ok {
+		return xml.Attr{}, nil
+	}
+	if m, ok := any(o.v).(xml.MarshalerAttr); ok {
+		return m.MarshalXMLAttr(name)
+	}
+	s, err := formatText(o.v)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: s}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr. Attributes that are missing
+// from the element are never decoded, so the Option is left as None.
+func (o *Option[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var v T
+	if u, ok := any(&v).(xml.UnmarshalerAttr); ok {
+		if err := u.UnmarshalXMLAttr(attr); err != nil {
+			return err
+		}
+	} else if err := parseText(&v, attr.Value); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
@@ -0,0 +1,67 @@
+package opt
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXML(t *testing.T) {
+	type TestStruct struct {
+		XMLName xml.Name          `xml:"test"`
+		ID      Option[int]       `xml:"id,attr"`
+		Lang    Option[string]    `xml:"lang,attr"`
+		Foo     string            `xml:"foo"`
+		Bar     Option[int]       `xml:"bar"`
+		At      Option[time.Time] `xml:"at"`
+	}
+
+	t.Run("encode", func(t *testing.T) {
+		a := TestStruct{}
+		adata, err := xml.Marshal(a)
+		require.NoError(t, err)
+		require.Equal(t, `<test><foo></foo></test>`, string(adata))
+
+		b := TestStruct{
+			ID:   Some(7),
+			Lang: Some("en"),
+			Foo:  "beep",
+			Bar:  Some(5),
+			At:   Some(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		}
+		bdata, err := xml.Marshal(b)
+		require.NoError(t, err)
+		require.Equal(t, `<test id="7" lang="en"><foo>beep</foo><bar>5</bar><at>2024-03-01T00:00:00Z</at></test>`, string(bdata))
+	})
+	t.Run("decode", func(t *testing.T) {
+		var v TestStruct
+		err := xml.Unmarshal([]byte(`<test><foo>bar</foo></test>`), &v)
+		require.NoError(t, err)
+		require.Equal(t, "bar", v.Foo)
+		require.True(t, v.ID.None())
+		require.True(t, v.Lang.None())
+		require.True(t, v.Bar.None())
+		require.True(t, v.At.None())
+
+		var v2 TestStruct
+		err = xml.Unmarshal([]byte(`<test id=" 9 " lang="fr"><foo>bap</foo><bar>8</bar><at>2024-03-01T00:00:00Z</at></test>`), &v2)
+		require.NoError(t, err)
+		require.Equal(t, int(9), v2.ID.Unwrap())
+		require.Equal(t, "fr", v2.Lang.Unwrap())
+		require.Equal(t, int(8), v2.Bar.Unwrap())
+		require.True(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Equal(v2.At.Unwrap()))
+	})
+	t.Run("decode xsi:nil", func(t *testing.T) {
+		var v TestStruct
+		err := xml.Unmarshal([]byte(`<test xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><bar xsi:nil="true"/></test>`), &v)
+		require.NoError(t, err)
+		require.True(t, v.Bar.None())
+	})
+	t.Run("decode invalid attr", func(t *testing.T) {
+		var v TestStruct
+		err := xml.Unmarshal([]byte(`<test id="x"></test>`), &v)
+		require.Error(t, err)
+	})
+}